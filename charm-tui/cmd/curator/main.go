@@ -1,11 +1,19 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
-	"os/exec"
+	"strings"
+	"time"
 
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/cli"
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
 	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/curator"
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/style"
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/version"
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 )
 
@@ -13,6 +21,19 @@ var (
 	tuiMode    bool
 	newSession bool
 	projectPath string
+	baseBranch  string
+	outputFile  string
+	spinnerName string
+	reduceMotion bool
+	freshChat   bool
+	jsonOutput  bool
+	timeout     time.Duration
+	themeName   string
+	plainMode   bool
+	promptFile  string
+	modelName   string
+	debugMode   bool
+	debugLog    string
 )
 
 var rootCmd = &cobra.Command{
@@ -22,8 +43,36 @@ var rootCmd = &cobra.Command{
 	
 By default, curator runs in CLI mode.
 Use --tui for an interactive terminal interface with beautiful markdown rendering.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if jsonOutput && tuiMode {
+			return fmt.Errorf("--json and --tui cannot be used together")
+		}
+		// The theme default was read before --project was known; take it
+		// again from the project's config file
+		config.SetProjectDir(projectPath)
+		if !cmd.Flags().Changed("theme") {
+			themeName = config.GetThemeMode()
+		}
+		config.SetDebug(debugMode)
+		if debugLog != "" {
+			if err := style.OpenLog(debugLog, "curator "); err != nil {
+				return fmt.Errorf("failed to open log file: %w", err)
+			}
+		}
+		style.SetReducedMotion(reduceMotion)
+		if err := curator.SetModel(modelName); err != nil {
+			return err
+		}
+		if err := style.SetTheme(themeName); err != nil {
+			return err
+		}
+		return style.SetSpinner(spinnerName)
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if tuiMode {
+			if err := requireExecutor(); err != nil {
+				return err
+			}
 			// Launch interactive TUI
 			return curator.RunTUI(projectPath)
 		}
@@ -38,6 +87,10 @@ var overviewCmd = &cobra.Command{
 	Short: "Get comprehensive codebase overview",
 	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireExecutor(); err != nil {
+			return err
+		}
+		
 		path := projectPath
 		if len(args) > 0 {
 			path = args[0]
@@ -48,7 +101,7 @@ var overviewCmd = &cobra.Command{
 		}
 		
 		// Pass through to TypeScript implementation
-		cmdArgs := []string{"run", "../../src/tools/curator-cli/cli.ts", "overview"}
+		cmdArgs := []string{"overview"}
 		if path != "" {
 			cmdArgs = append(cmdArgs, path)
 		}
@@ -56,49 +109,49 @@ var overviewCmd = &cobra.Command{
 			cmdArgs = append(cmdArgs, "--new-session")
 		}
 		
-		execCmd := exec.Command("bun", cmdArgs...)
-		execCmd.Stdout = os.Stdout
-		execCmd.Stderr = os.Stderr
-		execCmd.Stdin = os.Stdin
-		
-		return execCmd.Run()
+		return runPassthrough(cmd, cmdArgs...)
 	},
 }
 
 var askCmd = &cobra.Command{
-	Use:   "ask [project-path] [question]",
+	Use:   "ask [project-path] [question | -]",
 	Short: "Ask questions about the codebase",
-	Args:  cobra.MinimumNArgs(1),
+	Long: `Ask questions about the codebase.
+
+The question can be given as an argument, read from a file with --file, or
+piped in on stdin (pass "-" or leave the question out).`,
+	Args: cobra.RangeArgs(0, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		var path, question string
+		if err := requireExecutor(); err != nil {
+			return err
+		}
 		
-		if len(args) == 1 {
-			// Just question provided
-			path = projectPath
-			question = args[0]
-		} else {
-			// Path and question provided
-			path = args[0]
-			question = args[1]
+		path, question, err := questionArgs(args, promptFile)
+		if err != nil {
+			return err
 		}
 		
+		if plainMode && (tuiMode || jsonOutput) {
+			return fmt.Errorf("--plain cannot be used with --tui or --json")
+		}
 		if tuiMode {
 			return curator.RunAskTUI(path, question)
 		}
+		if plainMode {
+			ctx, cancel := config.TimeoutContext(timeout)
+			defer cancel()
+			err := curator.AskPlain(ctx, os.Stdout, path, question)
+			return cli.Failure(cmd, config.TimeoutError(ctx, timeout, err))
+		}
 		
 		// Pass through to TypeScript implementation
-		cmdArgs := []string{"run", "../../src/tools/curator-cli/cli.ts", "ask"}
+		cmdArgs := []string{"ask"}
 		if path != "" {
 			cmdArgs = append(cmdArgs, path)
 		}
 		cmdArgs = append(cmdArgs, question)
 		
-		execCmd := exec.Command("bun", cmdArgs...)
-		execCmd.Stdout = os.Stdout
-		execCmd.Stderr = os.Stderr
-		execCmd.Stdin = os.Stdin
-		
-		return execCmd.Run()
+		return runPassthrough(cmd, cmdArgs...)
 	},
 }
 
@@ -107,29 +160,35 @@ var chatCmd = &cobra.Command{
 	Short: "Start interactive chat session",
 	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireExecutor(); err != nil {
+			return err
+		}
+		
 		path := projectPath
 		if len(args) > 0 {
 			path = args[0]
 		}
 		
 		// Chat is always interactive - launch TUI
-		return curator.RunChatTUI(path)
+		if jsonOutput {
+			return fmt.Errorf("chat is interactive and has no --json output")
+		}
+		return curator.RunChatTUI(path, freshChat)
 	},
 }
 
 var featureCmd = &cobra.Command{
-	Use:   "feature [project-path] [description]",
+	Use:   "feature [project-path] [description | -]",
 	Short: "Get implementation guidance for new features",
-	Args:  cobra.MinimumNArgs(1),
+	Args:  cobra.RangeArgs(0, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		var path, description string
+		if err := requireExecutor(); err != nil {
+			return err
+		}
 		
-		if len(args) == 1 {
-			path = projectPath
-			description = args[0]
-		} else {
-			path = args[0]
-			description = args[1]
+		path, description, err := questionArgs(args, promptFile)
+		if err != nil {
+			return err
 		}
 		
 		if tuiMode {
@@ -137,34 +196,28 @@ var featureCmd = &cobra.Command{
 		}
 		
 		// Pass through to TypeScript implementation
-		cmdArgs := []string{"run", "../../src/tools/curator-cli/cli.ts", "feature"}
+		cmdArgs := []string{"feature"}
 		if path != "" {
 			cmdArgs = append(cmdArgs, path)
 		}
 		cmdArgs = append(cmdArgs, description)
 		
-		execCmd := exec.Command("bun", cmdArgs...)
-		execCmd.Stdout = os.Stdout
-		execCmd.Stderr = os.Stderr
-		execCmd.Stdin = os.Stdin
-		
-		return execCmd.Run()
+		return runPassthrough(cmd, cmdArgs...)
 	},
 }
 
 var changeCmd = &cobra.Command{
-	Use:   "change [project-path] [description]",
+	Use:   "change [project-path] [description | -]",
 	Short: "Understand impact and risks of changes",
-	Args:  cobra.MinimumNArgs(1),
+	Args:  cobra.RangeArgs(0, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		var path, description string
+		if err := requireExecutor(); err != nil {
+			return err
+		}
 		
-		if len(args) == 1 {
-			path = projectPath
-			description = args[0]
-		} else {
-			path = args[0]
-			description = args[1]
+		path, description, err := questionArgs(args, promptFile)
+		if err != nil {
+			return err
 		}
 		
 		if tuiMode {
@@ -172,18 +225,13 @@ var changeCmd = &cobra.Command{
 		}
 		
 		// Pass through to TypeScript implementation
-		cmdArgs := []string{"run", "../../src/tools/curator-cli/cli.ts", "change"}
+		cmdArgs := []string{"change"}
 		if path != "" {
 			cmdArgs = append(cmdArgs, path)
 		}
 		cmdArgs = append(cmdArgs, description)
 		
-		execCmd := exec.Command("bun", cmdArgs...)
-		execCmd.Stdout = os.Stdout
-		execCmd.Stderr = os.Stderr
-		execCmd.Stdin = os.Stdin
-		
-		return execCmd.Run()
+		return runPassthrough(cmd, cmdArgs...)
 	},
 }
 
@@ -192,6 +240,10 @@ var memoryCmd = &cobra.Command{
 	Short: "View curator's memory about the codebase",
 	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireExecutor(); err != nil {
+			return err
+		}
+		
 		path := projectPath
 		if len(args) > 0 {
 			path = args[0]
@@ -202,17 +254,12 @@ var memoryCmd = &cobra.Command{
 		}
 		
 		// Pass through to TypeScript implementation
-		cmdArgs := []string{"run", "../../src/tools/curator-cli/cli.ts", "memory"}
+		cmdArgs := []string{"memory"}
 		if path != "" {
 			cmdArgs = append(cmdArgs, path)
 		}
 		
-		execCmd := exec.Command("bun", cmdArgs...)
-		execCmd.Stdout = os.Stdout
-		execCmd.Stderr = os.Stderr
-		execCmd.Stdin = os.Stdin
-		
-		return execCmd.Run()
+		return runPassthrough(cmd, cmdArgs...)
 	},
 }
 
@@ -221,23 +268,119 @@ var clearCmd = &cobra.Command{
 	Short: "Clear curator's memory",
 	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireExecutor(); err != nil {
+			return err
+		}
+		
 		path := projectPath
 		if len(args) > 0 {
 			path = args[0]
 		}
 		
 		// Pass through to TypeScript implementation
-		cmdArgs := []string{"run", "../../src/tools/curator-cli/cli.ts", "clear"}
+		cmdArgs := []string{"clear"}
 		if path != "" {
 			cmdArgs = append(cmdArgs, path)
 		}
 		
-		execCmd := exec.Command("bun", cmdArgs...)
-		execCmd.Stdout = os.Stdout
+		return runPassthrough(cmd, cmdArgs...)
+	},
+}
+
+var prDescriptionCmd = &cobra.Command{
+	Use:   "pr-description [project-path]",
+	Short: "Generate a PR title, summary, and testing notes from changes",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireExecutor(); err != nil {
+			return err
+		}
+		
+		path := projectPath
+		if len(args) > 0 {
+			path = args[0]
+		}
+		if path == "" {
+			path, _ = os.Getwd()
+		}
+		
+		if tuiMode {
+			return curator.RunPRDescriptionTUI(path, baseBranch, outputFile)
+		}
+		
+		diff, err := curator.GatherDiff(path, baseBranch)
+		if err != nil {
+			return err
+		}
+		
+		// Pass through to TypeScript implementation
+		cmdArgs := []string{"ask", path, curator.BuildPRPrompt(diff)}
+		if jsonOutput {
+			cmdArgs = append(cmdArgs, "--json")
+		}
+		
+		ctx, cancel := config.TimeoutContext(timeout)
+		defer cancel()
+		var captured bytes.Buffer
+		execCmd := curator.CommandContext(ctx, "", cmdArgs...)
+		execCmd.Stdout = io.MultiWriter(os.Stdout, &captured)
 		execCmd.Stderr = os.Stderr
 		execCmd.Stdin = os.Stdin
 		
-		return execCmd.Run()
+		if err := config.TimeoutError(ctx, timeout, execCmd.Run()); err != nil {
+			return cli.Failure(cmd, err)
+		}
+		
+		if outputFile != "" {
+			if err := os.WriteFile(outputFile, captured.Bytes(), 0644); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "💾 Saved to %s\n", outputFile)
+		}
+		return nil
+	},
+}
+
+// runPassthrough runs the TypeScript curator CLI attached to the terminal,
+// asking it for JSON output when --json is set
+func runPassthrough(cmd *cobra.Command, args ...string) error {
+	if jsonOutput {
+		args = append(args, "--json")
+	}
+	
+	ctx, cancel := config.TimeoutContext(timeout)
+	defer cancel()
+	execCmd := curator.CommandContext(ctx, "", args...)
+	execCmd.Stdout = os.Stdout
+	execCmd.Stderr = os.Stderr
+	execCmd.Stdin = os.Stdin
+	
+	err := config.TimeoutError(ctx, timeout, execCmd.Run())
+	return cli.Failure(cmd, err)
+}
+
+var doctorCmd = &cobra.Command{
+	Use:           "doctor",
+	Short:         "Check that the TypeScript CLI and index are set up",
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		results := config.Diagnose(config.GetCuratorPath(), curator.Command("", "--version"), projectPath)
+		fmt.Print(style.Checklist(results))
+		if failed := config.FailedChecks(results); failed > 0 {
+			return fmt.Errorf("%d of %d checks failed", failed, len(results))
+		}
+		return nil
+	},
+}
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Show the TUI and TypeScript CLI versions",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		version.Print(os.Stdout, "curator", curator.Command("", "--version"))
 	},
 }
 
@@ -245,9 +388,24 @@ func init() {
 	// Global flags
 	rootCmd.PersistentFlags().BoolVar(&tuiMode, "tui", false, "Launch interactive TUI mode")
 	rootCmd.PersistentFlags().StringVarP(&projectPath, "project", "p", "", "Project path (defaults to current directory)")
+	rootCmd.PersistentFlags().StringVar(&spinnerName, "spinner", "dot", "Loading spinner style: dot, line, pulse, none")
+	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Print machine-readable JSON instead of launching a TUI")
+	rootCmd.PersistentFlags().BoolVar(&reduceMotion, "reduced-motion", false, "Replace animated spinners with a static indicator")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "Stop the TypeScript CLI if it runs longer than this, e.g. 60s (0 for no limit)")
+	rootCmd.PersistentFlags().StringVar(&modelName, "model", os.Getenv(curator.ModelEnv), "Model the curator uses: "+strings.Join(curator.KnownModels, ", ")+", or a full model name (default: the Claude CLI's)")
+	rootCmd.PersistentFlags().StringVar(&themeName, "theme", config.GetThemeMode(), "Color theme for the terminal background: light, dark, auto")
+	rootCmd.PersistentFlags().BoolVar(&debugMode, "debug", false, "Log spawned commands to stderr (or set CURATOR_DEBUG)")
+	rootCmd.PersistentFlags().StringVar(&debugLog, "log", "", "Append a log of key presses, spawned commands and parse errors to this file")
 	
 	// Command-specific flags
 	overviewCmd.Flags().BoolVar(&newSession, "new-session", false, "Start fresh analysis session")
+	askCmd.Flags().BoolVar(&plainMode, "plain", false, "Print the rendered answer and exit instead of opening the TUI")
+	askCmd.Flags().StringVarP(&promptFile, "file", "f", "", "Read the question from a file (- for stdin)")
+	featureCmd.Flags().StringVarP(&promptFile, "file", "f", "", "Read the description from a file (- for stdin)")
+	changeCmd.Flags().StringVarP(&promptFile, "file", "f", "", "Read the description from a file (- for stdin)")
+	chatCmd.Flags().BoolVar(&freshChat, "fresh", false, "Start without loading the saved conversation")
+	prDescriptionCmd.Flags().StringVar(&baseBranch, "base", "", "Describe changes against this base branch instead of the working tree")
+	prDescriptionCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Write the description to a file")
 	
 	// Add subcommands
 	rootCmd.AddCommand(overviewCmd)
@@ -257,11 +415,90 @@ func init() {
 	rootCmd.AddCommand(changeCmd)
 	rootCmd.AddCommand(memoryCmd)
 	rootCmd.AddCommand(clearCmd)
+	rootCmd.AddCommand(prDescriptionCmd)
+	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(doctorCmd)
+	
+	// Shell completion: `curator completion bash|zsh|fish|powershell`
+	rootCmd.CompletionOptions.HiddenDefaultCmd = true
+}
+
+// questionArgs resolves the project path and question of ask, feature and
+// change from their arguments and --file
+func questionArgs(args []string, file string) (path, question string, err error) {
+	piped := !isatty.IsTerminal(os.Stdin.Fd()) && !isatty.IsCygwinTerminal(os.Stdin.Fd())
+	return resolveQuestion(args, projectPath, file, os.Stdin, piped)
+}
+
+// resolveQuestion takes the question from the last argument, the file, or
+// stdin when the question is "-" or left out with input piped in. With a
+// file, a single argument must be the project path.
+func resolveQuestion(args []string, defaultPath, file string, stdin io.Reader, piped bool) (path, question string, err error) {
+	path = defaultPath
+	switch {
+	case file != "":
+		if len(args) == 2 {
+			return "", "", fmt.Errorf("the question was given both as an argument and with --file")
+		}
+		if len(args) == 1 {
+			if info, statErr := os.Stat(args[0]); statErr != nil || !info.IsDir() {
+				return "", "", fmt.Errorf("%q is not a project directory; give the question either as an argument or with --file, not both", args[0])
+			}
+			path = args[0]
+		}
+		if file == "-" {
+			question, err = readQuestion(stdin)
+		} else {
+			var data []byte
+			data, err = os.ReadFile(file)
+			question = string(data)
+		}
+	case len(args) == 0:
+		if !piped {
+			return "", "", fmt.Errorf("no question given: pass it as an argument, with --file, or on stdin")
+		}
+		question, err = readQuestion(stdin)
+	default:
+		if len(args) == 2 {
+			path = args[0]
+		}
+		question = args[len(args)-1]
+		if question == "-" {
+			question, err = readQuestion(stdin)
+		}
+	}
+	if err != nil {
+		return "", "", err
+	}
+	
+	question = strings.TrimSpace(question)
+	if question == "" {
+		return "", "", fmt.Errorf("the question is empty")
+	}
+	return path, question, nil
+}
+
+func readQuestion(r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the question from stdin: %w", err)
+	}
+	return string(data), nil
+}
+
+// requireExecutor fails early, with instructions, when the runtime that
+// runs the TypeScript curator CLI isn't installed
+func requireExecutor() error {
+	return config.RequireExecutor(config.GetCuratorPath())
 }
 
 func main() {
+	style.Init()
+	
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		if !config.CLIReported(err) {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		os.Exit(config.ExitCode(err))
 	}
 }
\ No newline at end of file
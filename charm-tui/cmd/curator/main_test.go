@@ -0,0 +1,57 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveQuestion(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "prompt.md")
+	if err := os.WriteFile(file, []byte("  How does auth work?\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	
+	tests := []struct {
+		name      string
+		args      []string
+		file      string
+		stdin     string
+		piped     bool
+		wantPath  string
+		wantQ     string
+		wantError bool
+	}{
+		{"question only", []string{"why?"}, "", "", false, "/default", "why?", false},
+		{"path and question", []string{"/repo", "why?"}, "", "", false, "/repo", "why?", false},
+		{"dash reads stdin", []string{"/repo", "-"}, "", "piped question\n", true, "/repo", "piped question", false},
+		{"omitted reads piped stdin", nil, "", "piped question", true, "/default", "piped question", false},
+		{"omitted on a terminal", nil, "", "", false, "", "", true},
+		{"file", nil, file, "", false, "/default", "How does auth work?", false},
+		{"file with project path", []string{dir}, file, "", false, dir, "How does auth work?", false},
+		{"file and question", []string{"why?"}, file, "", false, "", "", true},
+		{"file, path and question", []string{dir, "why?"}, file, "", false, "", "", true},
+		{"file from stdin", nil, "-", "from stdin", true, "/default", "from stdin", false},
+		{"missing file", nil, filepath.Join(dir, "missing.md"), "", false, "", "", true},
+		{"empty stdin", []string{"-"}, "", "  \n", true, "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, question, err := resolveQuestion(tt.args, "/default", tt.file, strings.NewReader(tt.stdin), tt.piped)
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("expected an error, got path %q question %q", path, question)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveQuestion: %v", err)
+			}
+			if path != tt.wantPath || question != tt.wantQ {
+				t.Errorf("resolveQuestion() = %q, %q, want %q, %q", path, question, tt.wantPath, tt.wantQ)
+			}
+		})
+	}
+}
@@ -3,15 +3,28 @@ package main
 import (
 	"fmt"
 	"os"
-	"os/exec"
+	"time"
 
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/cli"
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
 	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/monitor"
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/style"
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/version"
 	"github.com/spf13/cobra"
 )
 
 var (
-	tuiMode      bool
-	withOverview bool
+	tuiMode        bool
+	withOverview   bool
+	autoIndex      bool
+	logFile        string
+	statusInterval time.Duration
+	projectPath    string
+	timeout        time.Duration
+	themeName      string
+	debugMode      bool
+	debugLog       string
+	jsonOutput     bool
 )
 
 var rootCmd = &cobra.Command{
@@ -21,8 +34,35 @@ var rootCmd = &cobra.Command{
 	
 By default, monitor runs in CLI mode.
 Use --tui for an interactive terminal interface with live updates.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if jsonOutput && tuiMode {
+			return fmt.Errorf("--json and --tui cannot be used together")
+		}
+		if projectPath != "" {
+			if info, err := os.Stat(projectPath); err != nil || !info.IsDir() {
+				return fmt.Errorf("project path %q is not a directory", projectPath)
+			}
+		}
+		monitor.SetProjectDir(projectPath)
+		// The theme default was read before --project was known; take it
+		// again from the project's config file
+		config.SetProjectDir(projectPath)
+		if !cmd.Flags().Changed("theme") {
+			themeName = config.GetThemeMode()
+		}
+		config.SetDebug(debugMode)
+		if debugLog != "" {
+			if err := style.OpenLog(debugLog, "monitor "); err != nil {
+				return fmt.Errorf("failed to open log file: %w", err)
+			}
+		}
+		return style.SetTheme(themeName)
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if tuiMode {
+			if err := requireExecutor(); err != nil {
+				return err
+			}
 			// Launch TUI dashboard
 			return monitor.RunTUI()
 		}
@@ -36,22 +76,20 @@ var watchCmd = &cobra.Command{
 	Use:   "watch",
 	Short: "Start live file monitoring",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireExecutor(); err != nil {
+			return err
+		}
+		
 		if tuiMode {
-			return monitor.RunWatchTUI(withOverview)
+			return monitor.RunWatchTUI(withOverview, autoIndex, logFile)
 		}
 		
 		// Pass through to TypeScript implementation
-		cmdArgs := []string{"run", "../../src/tools/monitor/cli.ts", "watch"}
+		cmdArgs := []string{"watch"}
 		if withOverview {
 			cmdArgs = append(cmdArgs, "--overview")
 		}
-		
-		execCmd := exec.Command("bun", cmdArgs...)
-		execCmd.Stdout = os.Stdout
-		execCmd.Stderr = os.Stderr
-		execCmd.Stdin = os.Stdin
-		
-		return execCmd.Run()
+		return runPassthrough(cmd, cmdArgs...)
 	},
 }
 
@@ -59,19 +97,19 @@ var overviewCmd = &cobra.Command{
 	Use:   "overview",
 	Short: "Show static codebase overview",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireExecutor(); err != nil {
+			return err
+		}
+		
 		if tuiMode {
 			return monitor.RunOverviewTUI()
 		}
+		if jsonOutput {
+			return fmt.Errorf("overview has no --json output")
+		}
 		
 		// Pass through to TypeScript implementation
-		cmdArgs := []string{"run", "../../src/tools/monitor/cli.ts", "overview"}
-		
-		execCmd := exec.Command("bun", cmdArgs...)
-		execCmd.Stdout = os.Stdout
-		execCmd.Stderr = os.Stderr
-		execCmd.Stdin = os.Stdin
-		
-		return execCmd.Run()
+		return runPassthrough(cmd, "overview")
 	},
 }
 
@@ -79,38 +117,104 @@ var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Check index status and health",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireExecutor(); err != nil {
+			return err
+		}
+		
 		if tuiMode {
-			return monitor.RunStatusTUI()
+			return monitor.RunStatusTUI(statusInterval)
 		}
 		
 		// Pass through to TypeScript implementation
-		cmdArgs := []string{"run", "../../src/tools/monitor/cli.ts", "status"}
-		
-		execCmd := exec.Command("bun", cmdArgs...)
-		execCmd.Stdout = os.Stdout
-		execCmd.Stderr = os.Stderr
-		execCmd.Stdin = os.Stdin
-		
-		return execCmd.Run()
+		return runPassthrough(cmd, "status")
+	},
+}
+
+// runPassthrough runs the TypeScript monitor CLI attached to the terminal,
+// asking it for JSON output when --json is set
+func runPassthrough(cmd *cobra.Command, args ...string) error {
+	if jsonOutput {
+		args = append(args, "--json")
+	}
+	
+	ctx, cancel := config.TimeoutContext(timeout)
+	defer cancel()
+	execCmd := monitor.CommandContext(ctx, args...)
+	execCmd.Stdout = os.Stdout
+	execCmd.Stderr = os.Stderr
+	execCmd.Stdin = os.Stdin
+	
+	err := config.TimeoutError(ctx, timeout, execCmd.Run())
+	return cli.Failure(cmd, err)
+}
+
+var doctorCmd = &cobra.Command{
+	Use:           "doctor",
+	Short:         "Check that the TypeScript CLI and index are set up",
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		results := config.Diagnose(config.GetMonitorPath(), monitor.Command("--version"), projectPath)
+		fmt.Print(style.Checklist(results))
+		if failed := config.FailedChecks(results); failed > 0 {
+			return fmt.Errorf("%d of %d checks failed", failed, len(results))
+		}
+		return nil
+	},
+}
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Show the TUI and TypeScript CLI versions",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		version.Print(os.Stdout, "monitor", monitor.Command("--version"))
 	},
 }
 
 func init() {
 	// Root flags
 	rootCmd.PersistentFlags().BoolVar(&tuiMode, "tui", false, "Launch interactive TUI mode")
+	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Print machine-readable JSON instead of launching a TUI")
+	rootCmd.PersistentFlags().StringVarP(&projectPath, "project", "p", "", "Project path (defaults to current directory)")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "Stop the TypeScript CLI if it runs longer than this, e.g. 60s (0 for no limit)")
+	rootCmd.PersistentFlags().StringVar(&themeName, "theme", config.GetThemeMode(), "Color theme for the terminal background: light, dark, auto")
+	rootCmd.PersistentFlags().BoolVar(&debugMode, "debug", false, "Log spawned commands to stderr (or set CURATOR_DEBUG)")
+	rootCmd.PersistentFlags().StringVar(&debugLog, "log", "", "Append a log of key presses, spawned commands and parse errors to this file")
 	
 	// Watch flags
 	watchCmd.Flags().BoolVar(&withOverview, "overview", false, "Include codebase overview in dashboard")
+	watchCmd.Flags().StringVar(&logFile, "log-file", "", "Append file change events to this file as JSON lines (TUI mode)")
+	watchCmd.Flags().BoolVar(&autoIndex, "auto-index", false, "Rebuild the index with smartgrep --index once changes settle (press u to update manually)")
+	
+	// Status flags
+	statusCmd.Flags().DurationVar(&statusInterval, "interval", 5*time.Second, "How often the status TUI refreshes (0 to disable)")
 	
 	// Add subcommands
 	rootCmd.AddCommand(watchCmd)
 	rootCmd.AddCommand(overviewCmd)
 	rootCmd.AddCommand(statusCmd)
+	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(doctorCmd)
+	
+	// Shell completion: `monitor completion bash|zsh|fish|powershell`
+	rootCmd.CompletionOptions.HiddenDefaultCmd = true
+}
+
+// requireExecutor fails early, with instructions, when the runtime that
+// runs the TypeScript monitor CLI isn't installed
+func requireExecutor() error {
+	return config.RequireExecutor(config.GetMonitorPath())
 }
 
 func main() {
+	style.Init()
+	
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		if !config.CLIReported(err) {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		os.Exit(config.ExitCode(err))
 	}
 }
\ No newline at end of file
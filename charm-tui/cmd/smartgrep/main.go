@@ -3,10 +3,16 @@ package main
 import (
 	"fmt"
 	"os"
-	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/cli"
 	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
 	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/smartgrep"
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/style"
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/version"
 	"github.com/spf13/cobra"
 )
 
@@ -17,6 +23,28 @@ var (
 	sortBy      string
 	compactMode bool
 	rebuildIndex bool
+	serveAddr   string
+	unixSocket  string
+	maxProcs    int
+	debugMode   bool
+	focusPath   string
+	showBlame   bool
+	forLLM      bool
+	tokenBudget int
+	minRelevance float64
+	minUsage    int
+	jsonOutput  bool
+	projectPath string
+	clearHistory bool
+	allProjects bool
+	historyLimit int
+	watchMode   bool
+	timeout     time.Duration
+	themeName   string
+	stagedOnly  bool
+	sinceRef    string
+	selectResult string
+	debugLog    string
 )
 
 var rootCmd = &cobra.Command{
@@ -27,102 +55,265 @@ var rootCmd = &cobra.Command{
 By default, smartgrep runs in CLI mode for maximum Claude productivity.
 Use --tui for an interactive terminal interface.`,
 	Args: cobra.ArbitraryArgs,  // Allow any number of arguments
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if jsonOutput && tuiMode {
+			return fmt.Errorf("--json and --tui cannot be used together")
+		}
+		if jsonOutput && forLLM {
+			return fmt.Errorf("--json and --for-llm cannot be used together")
+		}
+		if watchMode && (jsonOutput || forLLM) {
+			return fmt.Errorf("--watch updates the TUI and cannot be used with --json or --for-llm")
+		}
+		if projectPath != "" {
+			if info, err := os.Stat(projectPath); err != nil || !info.IsDir() {
+				return fmt.Errorf("project path %q is not a directory", projectPath)
+			}
+		}
+		// Flag defaults were read before --project was known; take them
+		// again from the project's config file
+		config.SetProjectDir(projectPath)
+		if !cmd.Flags().Changed("theme") {
+			themeName = config.GetThemeMode()
+		}
+		if !cmd.Flags().Changed("max") {
+			maxResults = config.GetMaxResults(50)
+		}
+		if err := style.SetTheme(themeName); err != nil {
+			return err
+		}
+		smartgrep.SetProjectDir(projectPath)
+		smartgrep.SetMaxConcurrency(maxProcs)
+		config.SetDebug(debugMode)
+		smartgrep.SetDebug(config.Debug())
+		if debugLog != "" {
+			if err := style.OpenLog(debugLog, "smartgrep "); err != nil {
+				return fmt.Errorf("failed to open log file: %w", err)
+			}
+		}
+		return nil
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if tuiMode {
+		if watchMode && len(args) == 0 {
+			return fmt.Errorf("--watch needs a pattern to search for")
+		}
+		if selectResult != "" && (len(args) == 0 || !(tuiMode || watchMode)) {
+			return fmt.Errorf("--select opens a result of a search in the TUI; use it with a pattern and --tui")
+		}
+		if err := requireExecutor(); err != nil {
+			return err
+		}
+		smartgrep.SetThresholds(minRelevance, minUsage)
+		if tuiMode || watchMode {
 			// Launch TUI mode
-			return smartgrep.RunTUI()
+			smartgrep.SetFocus(focusPath)
+			smartgrep.SetBlame(showBlame)
+			return smartgrep.RunTUI(smartgrep.TUIOptions{
+				Query:   strings.Join(args, " "),
+				Type:    typeFilter,
+				Sort:    sortBy,
+				Max:     maxResults,
+				Compact: compactMode,
+				Watch:   watchMode,
+				Select:  selectResult,
+			})
 		}
 
 		// CLI mode - direct passthrough to TypeScript smartgrep
-		return runCLIMode(args)
+		return runCLIMode(cmd, args)
+	},
+}
+
+var doctorCmd = &cobra.Command{
+	Use:           "doctor",
+	Short:         "Check that the TypeScript CLI and index are set up",
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		results := config.Diagnose(config.GetSmartgrepPath(), smartgrep.Command("--version"), projectPath)
+		fmt.Print(style.Checklist(results))
+		if failed := config.FailedChecks(results); failed > 0 {
+			return fmt.Errorf("%d of %d checks failed", failed, len(results))
+		}
+		return nil
+	},
+}
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Show the TUI and TypeScript CLI versions",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		version.Print(os.Stdout, "smartgrep", smartgrep.Command("--version"))
 	},
 }
 
 func init() {
 	rootCmd.Flags().BoolVar(&tuiMode, "tui", false, "Launch interactive TUI mode")
 	rootCmd.Flags().StringVar(&typeFilter, "type", "", "Filter by type (function,class,variable,etc)")
-	rootCmd.Flags().IntVar(&maxResults, "max", 50, "Maximum results to show")
+	rootCmd.Flags().IntVar(&maxResults, "max", config.GetMaxResults(50), "Maximum results to show")
 	rootCmd.Flags().StringVar(&sortBy, "sort", "relevance", "Sort by: relevance, usage, name, file")
 	rootCmd.Flags().BoolVar(&compactMode, "compact", false, "Compact output format")
 	rootCmd.Flags().BoolVar(&rebuildIndex, "index", false, "Rebuild the semantic index")
+	rootCmd.Flags().BoolVar(&forLLM, "for-llm", false, "Emit compact, unstyled results for pasting into an LLM prompt")
+	rootCmd.Flags().IntVar(&tokenBudget, "token-budget", smartgrep.DefaultTokenBudget, "Approximate token limit for --for-llm output")
+	rootCmd.Flags().Float64Var(&minRelevance, "min-relevance", 0, "Drop results with a relevance score below this (0-1)")
+	rootCmd.Flags().IntVar(&minUsage, "min-usage", 0, "Drop results used fewer than this many times")
+	rootCmd.Flags().BoolVar(&showBlame, "blame", false, "Show git blame (last author and date) in the TUI detail view")
+	rootCmd.Flags().BoolVar(&watchMode, "watch", false, "Re-run the search in the TUI whenever project files change")
+	rootCmd.Flags().StringVar(&selectResult, "select", "", "Open the TUI on this result's details: its number in the list, or file:line")
+	rootCmd.Flags().StringVar(&focusPath, "focus", "", "Prioritize results near this file (scopes CLI output to its directory)")
+	rootCmd.PersistentFlags().IntVar(&maxProcs, "max-procs", config.GetMaxBackendProcs(), "Maximum concurrent backend processes")
+	rootCmd.PersistentFlags().StringVarP(&projectPath, "project", "p", "", "Project path (defaults to current directory)")
+	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Print machine-readable JSON instead of launching a TUI")
+	rootCmd.PersistentFlags().BoolVar(&debugMode, "debug", false, "Log spawned commands and backend concurrency to stderr (or set CURATOR_DEBUG)")
+	rootCmd.PersistentFlags().StringVar(&debugLog, "log", "", "Append a log of key presses, spawned commands and parse errors to this file")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "Stop the TypeScript CLI if it runs longer than this, e.g. 60s (0 for no limit)")
+	rootCmd.PersistentFlags().StringVar(&themeName, "theme", config.GetThemeMode(), "Color theme for the terminal background: light, dark, auto")
 }
 
 // Helper to execute CLI commands
-func executeCommand(subcommand string, args []string, flags map[string]interface{}) error {
-	executor := config.GetExecutor()
-	cliPath := config.GetSmartgrepPath()
+func executeCommand(cmd *cobra.Command, subcommand string, args []string, flags map[string]interface{}) error {
+	if jsonOutput {
+		// Every TypeScript subcommand takes --json for machine-readable output
+		flags = withFlag(flags, "json", true)
+	}
 	
-	var cmd *exec.Cmd
 	var cmdArgs []string
-	
-	if executor != "" {
-		// Development mode: bun run path/to/cli.ts
-		cmdArgs = []string{"run", cliPath}
-		if subcommand != "" {
-			cmdArgs = append(cmdArgs, subcommand)
-		}
-		cmdArgs = append(cmdArgs, args...)
-		
-		// Add flags
-		for flag, value := range flags {
-			if boolVal, ok := value.(bool); ok && boolVal {
-				cmdArgs = append(cmdArgs, "--"+flag)
-			} else if strVal, ok := value.(string); ok && strVal != "" {
-				cmdArgs = append(cmdArgs, "--"+flag, strVal)
-			} else if intVal, ok := value.(int); ok && flag == "max" && intVal != 50 {
-				cmdArgs = append(cmdArgs, "--"+flag, fmt.Sprintf("%d", intVal))
-			}
-		}
-		
-		cmd = exec.Command(executor, cmdArgs...)
-	} else {
-		// Production mode: smartgrep binary is in PATH
-		if subcommand != "" {
-			cmdArgs = append(cmdArgs, subcommand)
-		}
-		cmdArgs = append(cmdArgs, args...)
-		
-		// Add flags
-		for flag, value := range flags {
-			if boolVal, ok := value.(bool); ok && boolVal {
-				cmdArgs = append(cmdArgs, "--"+flag)
-			} else if strVal, ok := value.(string); ok && strVal != "" {
-				cmdArgs = append(cmdArgs, "--"+flag, strVal)
-			} else if intVal, ok := value.(int); ok && flag == "max" && intVal != 50 {
-				cmdArgs = append(cmdArgs, "--"+flag, fmt.Sprintf("%d", intVal))
-			}
-		}
-		
-		cmd = exec.Command(cliPath, cmdArgs...)
+	if subcommand != "" {
+		cmdArgs = append(cmdArgs, subcommand)
 	}
+	cmdArgs = append(cmdArgs, args...)
+	cmdArgs = append(cmdArgs, flagArgs(flags)...)
 	
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Stdin = os.Stdin
+	// Runs through the configured executor in development, in --project
+	ctx, cancel := config.TimeoutContext(timeout)
+	defer cancel()
+	execCmd := smartgrep.CommandContext(ctx, cmdArgs...)
+	execCmd.Stdout = os.Stdout
+	execCmd.Stderr = os.Stderr
+	execCmd.Stdin = os.Stdin
 	
-	return cmd.Run()
+	err := config.TimeoutError(ctx, timeout, execCmd.Run())
+	return cli.Failure(cmd, err)
 }
 
-func runCLIMode(args []string) error {
-	// Build flags map
+// flagArgs converts a flags map into CLI arguments, in a stable order. Every
+// int and float in the map is forwarded; callers only add flags that were set.
+func flagArgs(flags map[string]interface{}) []string {
+	names := make([]string, 0, len(flags))
+	for flag := range flags {
+		names = append(names, flag)
+	}
+	sort.Strings(names)
+	
+	var args []string
+	for _, flag := range names {
+		value := flags[flag]
+		if boolVal, ok := value.(bool); ok && boolVal {
+			args = append(args, "--"+flag)
+		} else if strVal, ok := value.(string); ok && strVal != "" {
+			args = append(args, "--"+flag, strVal)
+		} else if intVal, ok := value.(int); ok {
+			args = append(args, "--"+flag, fmt.Sprintf("%d", intVal))
+		} else if floatVal, ok := value.(float64); ok {
+			args = append(args, "--"+flag, strconv.FormatFloat(floatVal, 'f', -1, 64))
+		}
+	}
+	return args
+}
+
+// withFlag returns a copy of flags with name set to value
+func withFlag(flags map[string]interface{}, name string, value interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(flags)+1)
+	for k, v := range flags {
+		out[k] = v
+	}
+	out[name] = value
+	return out
+}
+
+// changedFlags collects the named flags that were set on the command line,
+// keyed by flag name. Flags the command does not define are skipped.
+func changedFlags(cmd *cobra.Command, names ...string) map[string]interface{} {
+	fs := cmd.Flags()
 	flags := make(map[string]interface{})
-	if rebuildIndex {
-		flags["index"] = true
+	for _, name := range names {
+		flag := fs.Lookup(name)
+		if flag == nil || !flag.Changed {
+			continue
+		}
+		switch flag.Value.Type() {
+		case "bool":
+			flags[name], _ = fs.GetBool(name)
+		case "int":
+			flags[name], _ = fs.GetInt(name)
+		case "float64":
+			flags[name], _ = fs.GetFloat64(name)
+		default:
+			flags[name] = flag.Value.String()
+		}
 	}
-	if typeFilter != "" {
-		flags["type"] = typeFilter
+	return flags
+}
+
+// changesOptions reads the --staged and --since flags of the changes
+// command, which select different changes and can't be combined
+func changesOptions(cmd *cobra.Command) (smartgrep.ChangesOptions, error) {
+	staged, _ := cmd.Flags().GetBool("staged")
+	since, _ := cmd.Flags().GetString("since")
+	since = strings.TrimSpace(since)
+	sinceSet := cmd.Flags().Changed("since")
+	
+	switch {
+	case staged && sinceSet:
+		return smartgrep.ChangesOptions{}, fmt.Errorf("--staged and --since cannot be used together")
+	case sinceSet && since == "":
+		return smartgrep.ChangesOptions{}, fmt.Errorf("--since requires a git ref, e.g. --since main")
+	case strings.HasPrefix(since, "-"):
+		// The ref is passed on to git, which would read it as an option
+		return smartgrep.ChangesOptions{}, fmt.Errorf("--since %q is not a git ref", since)
 	}
-	if maxResults != 50 {
+	return smartgrep.ChangesOptions{Staged: staged, Since: since}, nil
+}
+
+func runCLIMode(cmd *cobra.Command, args []string) error {
+	// Forward exactly the flags the user set, even when they match a default
+	// --min-relevance and --min-usage are applied here: the TypeScript CLI
+	// has no such flags
+	flags := changedFlags(cmd, "index", "type", "max", "sort", "compact")
+	if _, ok := flags["max"]; !ok && config.GetMaxResults(0) > 0 {
+		// The configured limit is the flag default, so it never shows as changed
 		flags["max"] = maxResults
 	}
-	if sortBy != "relevance" {
-		flags["sort"] = sortBy
+	if focusPath != "" {
+		if scope := smartgrep.FocusScope(focusPath); scope != "" {
+			flags["file"] = scope
+		}
 	}
-	if compactMode {
-		flags["compact"] = true
+	
+	if forLLM || smartgrep.Thresholded() {
+		ctx, cancel := config.TimeoutContext(timeout)
+		defer cancel()
+		
+		var err error
+		if forLLM {
+			// Compact and index flags only affect the TypeScript display
+			delete(flags, "compact")
+			delete(flags, "index")
+			err = smartgrep.PrintForLLM(ctx, os.Stdout, append(args, flagArgs(flags)...), tokenBudget)
+		} else {
+			// The results are filtered before --max is applied, so ask for all
+			max, _ := flags["max"].(int)
+			delete(flags, "max")
+			delete(flags, "compact")
+			err = smartgrep.PrintThresholded(ctx, os.Stdout, append(args, flagArgs(flags)...), max, jsonOutput)
+		}
+		return cli.Failure(cmd, config.TimeoutError(ctx, timeout, err))
 	}
 	
-	return executeCommand("", args, flags)
+	return executeCommand(cmd, "", args, flags)
 }
 
 // Subcommands
@@ -131,12 +322,16 @@ var groupCmd = &cobra.Command{
 	Short: "Manage concept groups",
 	Long:  "List, search, add, or remove concept groups for semantic search",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireExecutor(); err != nil {
+			return err
+		}
+		
 		if tuiMode {
 			return smartgrep.RunGroupTUI()
 		}
 		
 		// Pass through to TypeScript implementation
-		return executeCommand("group", args, nil)
+		return executeCommand(cmd, "group", args, nil)
 	},
 }
 
@@ -144,8 +339,16 @@ var refsCmd = &cobra.Command{
 	Use:   "refs [symbol]",
 	Short: "Find all references to a symbol",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireExecutor(); err != nil {
+			return err
+		}
+		
 		if tuiMode {
-			return smartgrep.RunRefsTUI()
+			symbol := ""
+			if len(args) > 0 {
+				symbol = args[0]
+			}
+			return smartgrep.RunRefsTUI(symbol)
 		}
 		
 		if len(args) == 0 {
@@ -153,32 +356,139 @@ var refsCmd = &cobra.Command{
 		}
 		
 		// Pass through to TypeScript implementation
-		return executeCommand("refs", args, nil)
+		return executeCommand(cmd, "refs", args, nil)
 	},
 }
 
 var changesCmd = &cobra.Command{
 	Use:   "changes",
 	Short: "Analyze impact of uncommitted changes",
+	Long: `Analyze the impact of changes on the rest of the codebase.
+
+By default every uncommitted change is analyzed. --staged and --since are
+forwarded to the TypeScript CLI under the same names:
+  --staged       only changes staged for commit
+  --since <ref>  changes in the working tree since a commit, tag or branch`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireExecutor(); err != nil {
+			return err
+		}
+		opts, err := changesOptions(cmd)
+		if err != nil {
+			return err
+		}
+		
 		if tuiMode {
-			return smartgrep.RunChangesTUI()
+			return smartgrep.RunChangesTUI(opts)
 		}
 		
 		// Pass through to TypeScript implementation
-		flags := make(map[string]interface{})
-		if compactMode {
-			flags["compact"] = true
+		return executeCommand(cmd, "changes", nil, changedFlags(cmd, "compact", "staged", "since"))
+	},
+}
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve search, refs, and changes over HTTP/JSON",
+	Long: `Start a local HTTP/JSON server for editor integrations.
+
+Endpoints:
+  GET  /search?q=<pattern>[&type=&sort=&max=&file=]
+  GET  /refs?symbol=<name>
+  GET  /changes[?staged=true|since=<ref>]
+  POST /rpc   JSON-RPC 2.0 with methods search, refs, changes
+
+/search answers with a list of the CLI's --json results, {info: {term, type,
+location, ...}, relevanceScore, usageCount, sampleUsages}; /changes with the
+CLI's --json output; /refs with a list of {targetTerm, referenceType,
+fromLocation, context} objects. Invalid parameters get a 400 (JSON-RPC error
+-32602), CLI failures a 500 (-32000).
+
+The server has no authentication and listens on 127.0.0.1 by default.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireExecutor(); err != nil {
+			return err
 		}
-		return executeCommand("changes", nil, flags)
+		
+		return smartgrep.Serve(smartgrep.ServerOptions{
+			Addr:       serveAddr,
+			UnixSocket: unixSocket,
+		})
 	},
 }
 
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List recent searches",
+	Long: `List the searches recorded by the TUIs, newest first, with when they
+ran and how many results they found. Only searches in the current project are
+shown unless --all is given.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if clearHistory {
+			if err := smartgrep.ClearHistory(); err != nil {
+				return err
+			}
+			fmt.Println("Search history cleared")
+			return nil
+		}
+		return smartgrep.PrintHistory(os.Stdout, historyLimit, allProjects, jsonOutput)
+	},
+}
+
+// completeTypes completes --type with the semantic types the indexer emits
+func completeTypes(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return smartgrep.CompleteTypes(toComplete), cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+// completeGroupArgs completes group actions and concept group names
+func completeGroupArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var candidates []string
+	switch {
+	case len(args) == 0:
+		candidates = []string{"list", "add", "remove"}
+	case len(args) == 1 && args[0] == "remove":
+	default:
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	
+	if names, err := smartgrep.GroupNames(); err == nil {
+		candidates = append(candidates, names...)
+	}
+	return candidates, cobra.ShellCompDirectiveNoFileComp
+}
+
+// requireExecutor fails early, with instructions, when the runtime that
+// runs the TypeScript smartgrep CLI isn't installed
+func requireExecutor() error {
+	return config.RequireExecutor(config.GetSmartgrepPath())
+}
+
 func main() {
+	style.Init()
+	
 	// Add subcommands
 	rootCmd.AddCommand(groupCmd)
 	rootCmd.AddCommand(refsCmd)
 	rootCmd.AddCommand(changesCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(doctorCmd)
+	
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "127.0.0.1:7777", "Address to listen on")
+	serveCmd.Flags().StringVar(&unixSocket, "unix-socket", "", "Listen on a unix socket instead of TCP")
+	historyCmd.Flags().BoolVar(&clearHistory, "clear", false, "Delete all recorded searches")
+	historyCmd.Flags().BoolVar(&allProjects, "all", false, "Include searches from every project")
+	historyCmd.Flags().IntVar(&historyLimit, "limit", 20, "Maximum searches to list (0 for all)")
+	changesCmd.Flags().BoolVar(&stagedOnly, "staged", false, "Only analyze changes staged for commit")
+	changesCmd.Flags().StringVar(&sinceRef, "since", "", "Analyze changes since a git ref (commit, tag or branch)")
+	
+	// Shell completion: `smartgrep completion bash|zsh|fish|powershell`
+	rootCmd.CompletionOptions.HiddenDefaultCmd = true
+	rootCmd.RegisterFlagCompletionFunc("type", completeTypes)
+	groupCmd.ValidArgsFunction = completeGroupArgs
 	
 	// Add --tui flag to all subcommands
 	for _, cmd := range []*cobra.Command{groupCmd, refsCmd, changesCmd} {
@@ -186,7 +496,9 @@ func main() {
 	}
 	
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		if !config.CLIReported(err) {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		os.Exit(config.ExitCode(err))
 	}
 }
\ No newline at end of file
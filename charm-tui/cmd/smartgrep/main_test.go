@@ -0,0 +1,98 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/smartgrep"
+	"github.com/spf13/cobra"
+)
+
+func TestFlagArgsForwardsInts(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags map[string]interface{}
+		want  []string
+	}{
+		{"max at old default", map[string]interface{}{"max": 50}, []string{"--max", "50"}},
+		{"other int flags", map[string]interface{}{"min-usage": 3, "depth": 2}, []string{"--depth", "2", "--min-usage", "3"}},
+		{"mixed", map[string]interface{}{"max": 10, "type": "function", "compact": true}, []string{"--compact", "--max", "10", "--type", "function"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := flagArgs(tt.flags); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("flagArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChangedFlagsKeepsExplicitDefaults(t *testing.T) {
+	var (
+		max     int
+		sortBy  string
+		compact bool
+		minRel  float64
+	)
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().IntVar(&max, "max", 50, "")
+	cmd.Flags().StringVar(&sortBy, "sort", "relevance", "")
+	cmd.Flags().BoolVar(&compact, "compact", false, "")
+	cmd.Flags().Float64Var(&minRel, "min-relevance", 0, "")
+	if err := cmd.ParseFlags([]string{"--max", "50", "--sort=relevance", "--min-relevance", "0.25"}); err != nil {
+		t.Fatal(err)
+	}
+	
+	got := changedFlags(cmd, "max", "sort", "compact", "min-relevance", "undefined")
+	want := map[string]interface{}{"max": 50, "sort": "relevance", "min-relevance": 0.25}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("changedFlags() = %v, want %v", got, want)
+	}
+	if args := flagArgs(got); !reflect.DeepEqual(args, []string{"--max", "50", "--min-relevance", "0.25", "--sort", "relevance"}) {
+		t.Errorf("flagArgs() = %v", args)
+	}
+}
+
+func TestWithFlagCopies(t *testing.T) {
+	if got := withFlag(nil, "json", true); !reflect.DeepEqual(flagArgs(got), []string{"--json"}) {
+		t.Errorf("withFlag(nil) args = %v", flagArgs(got))
+	}
+	
+	flags := map[string]interface{}{"compact": true}
+	got := withFlag(flags, "json", true)
+	if _, ok := flags["json"]; ok {
+		t.Error("withFlag modified its input")
+	}
+	if !reflect.DeepEqual(flagArgs(got), []string{"--compact", "--json"}) {
+		t.Errorf("withFlag() args = %v", flagArgs(got))
+	}
+}
+
+func TestChangesOptions(t *testing.T) {
+	tests := []struct {
+		args    []string
+		want    smartgrep.ChangesOptions
+		wantErr bool
+	}{
+		{nil, smartgrep.ChangesOptions{}, false},
+		{[]string{"--staged"}, smartgrep.ChangesOptions{Staged: true}, false},
+		{[]string{"--since", "main"}, smartgrep.ChangesOptions{Since: "main"}, false},
+		{[]string{"--staged", "--since", "main"}, smartgrep.ChangesOptions{}, true},
+		{[]string{"--since="}, smartgrep.ChangesOptions{}, true},
+		{[]string{"--since=--output=/tmp/x"}, smartgrep.ChangesOptions{}, true},
+		{[]string{"--since", " -p"}, smartgrep.ChangesOptions{}, true},
+	}
+	for _, tt := range tests {
+		cmd := &cobra.Command{Use: "changes"}
+		cmd.Flags().Bool("staged", false, "")
+		cmd.Flags().String("since", "", "")
+		if err := cmd.ParseFlags(tt.args); err != nil {
+			t.Fatal(err)
+		}
+		
+		got, err := changesOptions(cmd)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("changesOptions(%v) = %+v, %v, want %+v, error %v", tt.args, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+// Package cli holds the cobra helpers shared by the curator, smartgrep and
+// monitor commands.
+package cli
+
+import (
+	"errors"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// Failure passes on the error of running a TypeScript CLI attached to the
+// terminal. When the CLI itself failed cobra prints neither the error nor
+// usage, and main exits with its status. A timeout is reported, but without
+// usage.
+func Failure(cmd *cobra.Command, err error) error {
+	if config.CLIReported(err) {
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+	}
+	if errors.Is(err, config.ErrTimeout) {
+		cmd.SilenceUsage = true
+	}
+	return err
+}
@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"testing"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func TestFailure(t *testing.T) {
+	// Attached to the terminal the CLI explains its own failure
+	attached := exec.Command("sh", "-c", "exit 2").Run()
+	cmd := &cobra.Command{}
+	if err := Failure(cmd, fmt.Errorf("search: %w", attached)); !errors.Is(err, attached) {
+		t.Fatalf("Failure() = %v", err)
+	}
+	if !cmd.SilenceErrors || !cmd.SilenceUsage {
+		t.Error("a CLI that reported its failure should silence cobra")
+	}
+	
+	cmd = &cobra.Command{}
+	Failure(cmd, fmt.Errorf("%w after 1s", config.ErrTimeout))
+	if cmd.SilenceErrors || !cmd.SilenceUsage {
+		t.Errorf("timeout: SilenceErrors = %v, SilenceUsage = %v, want the error without usage", cmd.SilenceErrors, cmd.SilenceUsage)
+	}
+}
@@ -3,108 +3,168 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"runtime"
+	"strconv"
+	"sync"
 )
 
 // GetSmartgrepPath returns the path to the smartgrep CLI
 func GetSmartgrepPath() string {
-	// Check for environment variable first (for custom installations)
-	if path := os.Getenv("SMARTGREP_CLI_PATH"); path != "" {
-		return path
-	}
-	
-	// Check if we're in development mode (running from charm-tui/build/bin)
-	if execPath, err := os.Executable(); err == nil {
-		// Check if TypeScript version exists relative to executable
-		dir := filepath.Dir(execPath)
-		
-		// Development paths (when running from build/bin/)
-		devPaths := []string{
-			filepath.Join(dir, "..", "..", "..", "..", "src", "tools", "smartgrep", "cli.ts"),
-			filepath.Join(dir, "..", "..", "src", "tools", "smartgrep", "cli.ts"),
-		}
-		
-		for _, path := range devPaths {
-			if _, err := os.Stat(path); err == nil {
-				return path
-			}
-		}
-	}
-	
-	// In production, assume smartgrep is in PATH
-	return "smartgrep"
+	return resolveCLIPath(current().SmartgrepPath, devEntryPoints().smartgrep, runtime.GOOS, "smartgrep")
 }
 
 // GetCuratorPath returns the path to the curator CLI
 func GetCuratorPath() string {
-	// Check for environment variable first
-	if path := os.Getenv("CURATOR_CLI_PATH"); path != "" {
-		return path
+	return resolveCLIPath(current().CuratorPath, devEntryPoints().curator, runtime.GOOS, "curator")
+}
+
+// GetMonitorPath returns the path to the monitor CLI. In production it is the
+// npm package's curator-monitor: the Go dashboard is itself called monitor, so
+// that name would run ourselves.
+func GetMonitorPath() string {
+	return resolveCLIPath(current().MonitorPath, devEntryPoints().monitor, runtime.GOOS, "curator-monitor")
+}
+
+// resolveCLIPath picks the CLI to run: the path from the environment or
+// config file, then the TypeScript entry point found next to the running
+// binary (development), then the installed binary on PATH
+func resolveCLIPath(configured, devPath, goos, binary string) string {
+	if configured != "" {
+		return configured
 	}
-	
-	// Check if we're in development mode
-	if execPath, err := os.Executable(); err == nil {
-		dir := filepath.Dir(execPath)
-		
-		devPaths := []string{
-			filepath.Join(dir, "..", "..", "..", "..", "src", "tools", "curator-cli", "cli.ts"),
-			filepath.Join(dir, "..", "..", "src", "tools", "curator-cli", "cli.ts"),
-		}
-		
-		for _, path := range devPaths {
-			if _, err := os.Stat(path); err == nil {
-				return path
-			}
-		}
+	if devPath != "" {
+		return devPath
 	}
-	
-	// In production, assume curator is in PATH
-	return "curator"
+	return binaryName(binary, goos)
 }
 
-// GetMonitorPath returns the path to the monitor CLI
-func GetMonitorPath() string {
-	// Check for environment variable first
-	if path := os.Getenv("MONITOR_CLI_PATH"); path != "" {
-		return path
+// entryPoints are the TypeScript CLIs found next to the running binary,
+// empty when not running from a source checkout
+type entryPoints struct {
+	smartgrep string
+	curator   string
+	monitor   string
+}
+
+var (
+	entryPointsOnce sync.Once
+	cachedEntries   entryPoints
+)
+
+// devEntryPoints returns the development entry points, looking them up once.
+// Set CURATOR_NO_PATH_CACHE to look them up on every call.
+func devEntryPoints() entryPoints {
+	if os.Getenv("CURATOR_NO_PATH_CACHE") != "" {
+		return findEntryPoints()
 	}
-	
-	// Check if we're in development mode
-	if execPath, err := os.Executable(); err == nil {
-		dir := filepath.Dir(execPath)
-		
-		devPaths := []string{
-			filepath.Join(dir, "..", "..", "..", "..", "src", "tools", "monitor", "cli.ts"),
-			filepath.Join(dir, "..", "..", "src", "tools", "monitor", "cli.ts"),
-		}
-		
-		for _, path := range devPaths {
-			if _, err := os.Stat(path); err == nil {
+	entryPointsOnce.Do(func() {
+		cachedEntries = findEntryPoints()
+	})
+	return cachedEntries
+}
+
+// findEntryPoints looks up every tool's entry point relative to the binary
+func findEntryPoints() entryPoints {
+	execPath, err := os.Executable()
+	if err != nil {
+		return entryPoints{}
+	}
+	dir := filepath.Dir(execPath)
+	return entryPoints{
+		smartgrep: findDevPath(dir, "smartgrep"),
+		curator:   findDevPath(dir, "codebase-curator", "curator-cli"),
+		monitor:   findDevPath(dir, "monitor"),
+	}
+}
+
+// ResetCache forgets the loaded config file and resolved CLI paths, so tests
+// can change the environment between cases
+func ResetCache() {
+	loadedOnce = sync.Once{}
+	loaded = Config{}
+	entryPointsOnce = sync.Once{}
+	cachedEntries = entryPoints{}
+}
+
+// statFile is os.Stat, replaceable to count filesystem lookups
+var statFile = os.Stat
+
+// findDevPath looks for src/tools/<tool>/cli.ts relative to execDir, the
+// directory holding the running binary, and returns the first that exists
+func findDevPath(execDir string, tools ...string) string {
+	// charm-tui/build/bin, plus the layouts older builds were run from
+	parents := [][]string{
+		{"..", "..", ".."},
+		{"..", "..", "..", ".."},
+		{"..", ".."},
+	}
+	for _, tool := range tools {
+		for _, up := range parents {
+			elems := append([]string{execDir}, up...)
+			path := filepath.Join(append(elems, "src", "tools", tool, "cli.ts")...)
+			if _, err := statFile(path); err == nil {
 				return path
 			}
 		}
 	}
-	
-	// In production, assume monitor is in PATH
-	return "monitor"
+	return ""
+}
+
+// binaryName returns the installed file name of a CLI on goos
+func binaryName(name, goos string) string {
+	if goos == "windows" {
+		return name + ".exe"
+	}
+	return name
 }
 
 // IsDevMode returns true if running in development mode (with .ts files)
 func IsDevMode() bool {
-	// Check if any of the TypeScript files exist
-	if execPath, err := os.Executable(); err == nil {
-		dir := filepath.Dir(execPath)
-		devPath := filepath.Join(dir, "..", "..", "..", "..", "src", "tools", "smartgrep", "cli.ts")
-		if _, err := os.Stat(devPath); err == nil {
-			return true
-		}
-	}
-	return false
+	return devEntryPoints().smartgrep != ""
 }
 
 // GetExecutor returns the command executor (bun for dev, direct for prod)
 func GetExecutor() string {
+	if executor := current().Executor; executor != "" {
+		return executor
+	}
 	if IsDevMode() {
 		return "bun"
 	}
 	return ""
+}
+
+// GetDataDir returns the directory where the TUIs persist state such as history
+func GetDataDir() string {
+	if dir := os.Getenv("CURATOR_DATA_DIR"); dir != "" {
+		return dir
+	}
+	if dir, err := os.UserConfigDir(); err == nil {
+		return filepath.Join(dir, "codebase-curator")
+	}
+	return filepath.Join(os.TempDir(), "codebase-curator")
+}
+
+// GetCacheDir returns the directory for disposable state such as chat transcripts
+func GetCacheDir() string {
+	if dir := os.Getenv("CURATOR_CACHE_DIR"); dir != "" {
+		return dir
+	}
+	if dir, err := os.UserCacheDir(); err == nil {
+		return filepath.Join(dir, "codebase-curator")
+	}
+	return filepath.Join(os.TempDir(), "codebase-curator")
+}
+
+// DefaultMaxBackendProcs is the default cap on concurrent backend processes
+const DefaultMaxBackendProcs = 4
+
+// GetMaxBackendProcs returns how many backend CLI processes may run at once
+func GetMaxBackendProcs() int {
+	if value := os.Getenv("CURATOR_MAX_PROCS"); value != "" {
+		if n, err := strconv.Atoi(value); err == nil && n > 0 {
+			return n
+		}
+	}
+	return DefaultMaxBackendProcs
 }
\ No newline at end of file
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBinaryName(t *testing.T) {
+	tests := []struct {
+		goos string
+		want string
+	}{
+		{"linux", "smartgrep"},
+		{"darwin", "smartgrep"},
+		{"windows", "smartgrep.exe"},
+	}
+	for _, tt := range tests {
+		if got := binaryName("smartgrep", tt.goos); got != tt.want {
+			t.Errorf("binaryName(smartgrep, %s) = %q, want %q", tt.goos, got, tt.want)
+		}
+	}
+}
+
+// makeDevTree lays out a repo with the smartgrep entry point and a build
+// directory holding a Windows binary, returning the repo root and bin dir
+func makeDevTree(t *testing.T) (string, string) {
+	t.Helper()
+	root := t.TempDir()
+	toolDir := filepath.Join(root, "src", "tools", "smartgrep")
+	binDir := filepath.Join(root, "charm-tui", "build", "bin")
+	for _, dir := range []string{toolDir, binDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeConfig(t, toolDir, "cli.ts", "")
+	writeConfig(t, binDir, "smartgrep.exe", "")
+	return root, binDir
+}
+
+func TestFindDevPath(t *testing.T) {
+	root, binDir := makeDevTree(t)
+	want := filepath.Join(root, "src", "tools", "smartgrep", "cli.ts")
+
+	if got := findDevPath(binDir, "smartgrep"); got != want {
+		t.Errorf("findDevPath(build/bin) = %q, want %q", got, want)
+	}
+	if got := findDevPath(filepath.Join(root, "charm-tui"), "smartgrep"); got != "" {
+		t.Errorf("findDevPath(charm-tui) = %q, want no match", got)
+	}
+	if got := findDevPath(binDir, "monitor"); got != "" {
+		t.Errorf("findDevPath(monitor) = %q, want no match", got)
+	}
+	if got := findDevPath(binDir, "curator-cli", "smartgrep"); got != want {
+		t.Errorf("findDevPath(fallback tool) = %q, want %q", got, want)
+	}
+}
+
+func TestResolveCLIPath(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured string
+		devPath    string
+		goos       string
+		binary     string
+		want       string
+	}{
+		{"configured wins", `C:\tools\smartgrep.exe`, "/repo/src/tools/smartgrep/cli.ts", "windows", "smartgrep", `C:\tools\smartgrep.exe`},
+		{"dev entry point", "", "/repo/src/tools/smartgrep/cli.ts", "windows", "smartgrep", "/repo/src/tools/smartgrep/cli.ts"},
+		{"windows binary", "", "", "windows", "smartgrep", "smartgrep.exe"},
+		{"unix binary", "", "", "linux", "curator-monitor", "curator-monitor"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveCLIPath(tt.configured, tt.devPath, tt.goos, tt.binary); got != tt.want {
+				t.Errorf("resolveCLIPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResetCache(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("CURATOR_CONFIG", filepath.Join(t.TempDir(), "config.yaml"))
+	t.Cleanup(ResetCache)
+	
+	t.Setenv("SMARTGREP_CLI_PATH", "/first/smartgrep")
+	ResetCache()
+	if got := GetSmartgrepPath(); got != "/first/smartgrep" {
+		t.Fatalf("GetSmartgrepPath() = %q", got)
+	}
+	
+	t.Setenv("SMARTGREP_CLI_PATH", "/second/smartgrep")
+	if got := GetSmartgrepPath(); got != "/first/smartgrep" {
+		t.Errorf("config should stay cached, got %q", got)
+	}
+	ResetCache()
+	if got := GetSmartgrepPath(); got != "/second/smartgrep" {
+		t.Errorf("after ResetCache GetSmartgrepPath() = %q, want /second/smartgrep", got)
+	}
+}
+
+// countStats replaces statFile for the benchmark and reports lookups per op
+func countStats(b *testing.B) *int {
+	b.Helper()
+	stats := 0
+	b.Cleanup(func() { statFile = os.Stat })
+	statFile = func(name string) (os.FileInfo, error) {
+		stats++
+		return os.Stat(name)
+	}
+	return &stats
+}
+
+func benchmarkResolve(b *testing.B, disableCache string) {
+	b.Setenv("CURATOR_NO_PATH_CACHE", disableCache)
+	b.Setenv("SMARTGREP_CLI_PATH", "")
+	ResetCache()
+	b.Cleanup(ResetCache)
+	stats := countStats(b)
+	
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		GetSmartgrepPath()
+		GetExecutor()
+	}
+	b.ReportMetric(float64(*stats)/float64(b.N), "stats/op")
+}
+
+func BenchmarkResolveCached(b *testing.B)   { benchmarkResolve(b, "") }
+func BenchmarkResolveUncached(b *testing.B) { benchmarkResolve(b, "1") }
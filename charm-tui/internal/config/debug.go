@@ -0,0 +1,115 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// DebugEnv turns on debug logging like --debug when set to anything but
+// 0, false or no
+const DebugEnv = "CURATOR_DEBUG"
+
+var (
+	debugEnabled bool
+	debugOutput  io.Writer = os.Stderr
+	logger       *log.Logger // The --log file, once one is open
+	tuiRunning   atomic.Bool
+)
+
+// SetDebug turns logging of spawned commands on or off. CURATOR_DEBUG turns
+// it on regardless.
+func SetDebug(enabled bool) {
+	debugEnabled = enabled
+}
+
+// Debug reports whether spawned commands are logged
+func Debug() bool {
+	if debugEnabled {
+		return true
+	}
+	switch strings.ToLower(os.Getenv(DebugEnv)) {
+	case "", "0", "false", "no":
+		return false
+	}
+	return true
+}
+
+// SetTUIRunning marks whether a TUI owns the terminal. Debug output would
+// draw over it, so while one runs it only goes to the log file.
+func SetTUIRunning(running bool) {
+	tuiRunning.Store(running)
+}
+
+// Debugf writes a debug line to stderr when debugging and no TUI is running
+func Debugf(format string, args ...interface{}) {
+	if Debug() && !tuiRunning.Load() {
+		fmt.Fprintf(debugOutput, "[debug] "+format+"\n", args...)
+	}
+}
+
+// LogCommand writes a command about to be run to stderr when debugging, and
+// to the log file: the executable it resolved to, its full argv and its
+// working directory
+func LogCommand(cmd *exec.Cmd) {
+	if !Debug() && logger == nil {
+		return
+	}
+	
+	executable := cmd.Path
+	if cmd.Err != nil {
+		executable = fmt.Sprintf("%s (%v)", cmd.Path, cmd.Err)
+	}
+	dir := cmd.Dir
+	if dir == "" {
+		dir, _ = os.Getwd()
+	}
+	Logf("event=exec path=%q argv=%q dir=%q", executable, quoteArgs(cmd.Args), dir)
+	Debugf("exec %s\n[debug]   argv: %s\n[debug]   dir:  %s", executable, quoteArgs(cmd.Args), dir)
+}
+
+// SetLogOutput sends log lines, prefixed with tool, to w. The TUIs own the
+// terminal while they run, so this is where key presses, spawned commands
+// and parse errors go instead. A nil w turns logging off.
+func SetLogOutput(w io.Writer, tool string) {
+	if w == nil {
+		logger = nil
+		return
+	}
+	logger = log.New(w, tool, log.LstdFlags)
+	Logf("event=start args=%q", quoteArgs(os.Args))
+}
+
+// Logging reports whether a log file is open
+func Logging() bool {
+	return logger != nil
+}
+
+// Logf writes a line of key=value pairs to the log file, if one is open
+func Logf(format string, args ...interface{}) {
+	if logger != nil {
+		logger.Printf(format, args...)
+	}
+}
+
+// LogParseError records CLI output of source that could not be parsed
+func LogParseError(source string, err error) {
+	Logf("event=parse-error source=%s err=%q", source, err)
+}
+
+// quoteArgs joins argv for display, quoting arguments a shell would split
+func quoteArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n\"'\\$`") {
+			arg = strconv.Quote(arg)
+		}
+		quoted[i] = arg
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestDebugEnv(t *testing.T) {
+	SetDebug(false)
+	for value, want := range map[string]bool{"": false, "0": false, "false": false, "No": false, "1": true, "true": true} {
+		t.Setenv(DebugEnv, value)
+		if got := Debug(); got != want {
+			t.Errorf("Debug() with %s=%q = %v, want %v", DebugEnv, value, got, want)
+		}
+	}
+}
+
+func TestLogCommand(t *testing.T) {
+	var out bytes.Buffer
+	debugOutput = &out
+	t.Cleanup(func() {
+		debugOutput = os.Stderr
+		SetDebug(false)
+	})
+	t.Setenv(DebugEnv, "")
+	
+	cmd := exec.Command("sh", "-c", "echo $HOME", "")
+	cmd.Dir = "/tmp/project"
+	LogCommand(cmd)
+	if out.Len() != 0 {
+		t.Fatalf("logged without --debug: %q", out.String())
+	}
+	
+	SetDebug(true)
+	LogCommand(cmd)
+	for _, want := range []string{"[debug] exec " + cmd.Path, `argv: sh -c "echo $HOME" ""`, "dir:  /tmp/project"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("log missing %q:\n%s", want, out.String())
+		}
+	}
+	
+	out.Reset()
+	LogCommand(exec.Command("no-such-executor-for-curator", "run"))
+	if !strings.Contains(out.String(), "not found") {
+		t.Errorf("unresolved executor not reported:\n%s", out.String())
+	}
+	
+	// A TUI owns the terminal, so nothing goes to stderr while it runs
+	out.Reset()
+	SetTUIRunning(true)
+	LogCommand(cmd)
+	Debugf("backend started")
+	SetTUIRunning(false)
+	if out.Len() != 0 {
+		t.Errorf("logged to stderr while a TUI was running: %q", out.String())
+	}
+	Debugf("backend finished")
+	if got := out.String(); got != "[debug] backend finished\n" {
+		t.Errorf("Debugf() wrote %q", got)
+	}
+}
+
+func TestSetLogOutput(t *testing.T) {
+	t.Cleanup(func() { SetLogOutput(nil, "") })
+	
+	Logf("event=ignored")
+	if Logging() {
+		t.Fatal("logging before a log output was set")
+	}
+	
+	var out bytes.Buffer
+	SetLogOutput(&out, "smartgrep ")
+	LogCommand(exec.Command("sh", "-c", "true"))
+	LogParseError("refs", errors.New("unexpected end of JSON input"))
+	
+	for _, want := range []string{"smartgrep ", "event=start", "event=exec", `argv="sh -c true"`, `event=parse-error source=refs err="unexpected end of JSON input"`} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("log missing %q:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "event=ignored") {
+		t.Errorf("line logged before the output was set:\n%s", out.String())
+	}
+}
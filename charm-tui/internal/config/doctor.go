@@ -0,0 +1,149 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CheckResult is one line of the `doctor` checklist
+type CheckResult struct {
+	Name   string
+	OK     bool
+	Detail string
+}
+
+// versionTimeout bounds how long the CLI gets to answer --version
+const versionTimeout = 10 * time.Second
+
+// CheckExecutor verifies the executor used in dev mode (bun) is on PATH
+func CheckExecutor(executor string) CheckResult {
+	result := CheckResult{Name: "Executor"}
+	if executor == "" {
+		result.OK = true
+		result.Detail = "not needed, the CLI is invoked directly"
+		return result
+	}
+	path, err := exec.LookPath(executor)
+	if err != nil {
+		result.Detail = fmt.Sprintf("%s not found on PATH", executor)
+		return result
+	}
+	result.OK = true
+	result.Detail = path
+	return result
+}
+
+// CheckCLIPath verifies the resolved CLI exists and can be run. A .ts entry
+// point only needs to exist, since the executor runs it.
+func CheckCLIPath(cliPath string) CheckResult {
+	result := CheckResult{Name: "CLI path"}
+	if !strings.ContainsRune(cliPath, filepath.Separator) {
+		path, err := exec.LookPath(cliPath)
+		if err != nil {
+			result.Detail = fmt.Sprintf("%s not found on PATH", cliPath)
+			return result
+		}
+		result.OK = true
+		result.Detail = path
+		return result
+	}
+
+	info, err := os.Stat(cliPath)
+	switch {
+	case err != nil:
+		result.Detail = fmt.Sprintf("%s does not exist", cliPath)
+	case info.IsDir():
+		result.Detail = fmt.Sprintf("%s is a directory", cliPath)
+	case !strings.HasSuffix(cliPath, ".ts") && info.Mode()&0111 == 0:
+		result.Detail = fmt.Sprintf("%s is not executable", cliPath)
+	default:
+		result.OK = true
+		result.Detail = cliPath
+	}
+	return result
+}
+
+// CheckCLIVersion runs cmd, the CLI invoked with --version, and verifies it
+// answers with a version
+func CheckCLIVersion(cmd *exec.Cmd) CheckResult {
+	result := CheckResult{Name: "CLI responds"}
+
+	var output strings.Builder
+	cmd.Stdout = &output
+	if err := cmd.Start(); err != nil {
+		result.Detail = err.Error()
+		return result
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+	select {
+	case err := <-done:
+		if err != nil {
+			result.Detail = fmt.Sprintf("--version failed: %v", err)
+			return result
+		}
+	case <-time.After(versionTimeout):
+		cmd.Process.Kill()
+		result.Detail = fmt.Sprintf("--version did not answer within %s", versionTimeout)
+		return result
+	}
+
+	version, _, _ := strings.Cut(strings.TrimSpace(output.String()), "\n")
+	if version == "" {
+		result.Detail = "--version printed nothing"
+		return result
+	}
+	result.OK = true
+	result.Detail = version
+	return result
+}
+
+// CheckIndexDir verifies the project has a .curator directory with a
+// semantic index
+func CheckIndexDir(projectDir string) CheckResult {
+	result := CheckResult{Name: "Index"}
+	if projectDir == "" {
+		projectDir = "."
+	}
+	dir := filepath.Join(projectDir, ".curator")
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		result.Detail = fmt.Sprintf("%s not found, run `smartgrep --index`", dir)
+		return result
+	}
+
+	index := filepath.Join(dir, "semantic-index.json")
+	if _, err := os.Stat(index); err != nil {
+		result.Detail = fmt.Sprintf("%s not found, run `smartgrep --index`", index)
+		return result
+	}
+	result.OK = true
+	result.Detail = index
+	return result
+}
+
+// Diagnose runs every environment check for a tool whose CLI lives at
+// cliPath. versionCmd is the CLI invoked with --version.
+func Diagnose(cliPath string, versionCmd *exec.Cmd, projectDir string) []CheckResult {
+	return []CheckResult{
+		CheckExecutor(GetExecutor()),
+		CheckCLIPath(cliPath),
+		CheckCLIVersion(versionCmd),
+		CheckIndexDir(projectDir),
+	}
+}
+
+// FailedChecks counts the results that did not pass
+func FailedChecks(results []CheckResult) int {
+	failed := 0
+	for _, r := range results {
+		if !r.OK {
+			failed++
+		}
+	}
+	return failed
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckExecutor(t *testing.T) {
+	if r := CheckExecutor(""); !r.OK {
+		t.Errorf("no executor should pass, got %+v", r)
+	}
+	if r := CheckExecutor("sh"); !r.OK {
+		t.Errorf("sh should be on PATH, got %+v", r)
+	}
+	if r := CheckExecutor("no-such-executor-xyz"); r.OK {
+		t.Errorf("missing executor should fail, got %+v", r)
+	}
+}
+
+func TestCheckCLIPath(t *testing.T) {
+	dir := t.TempDir()
+	script := writeConfig(t, dir, "cli", "#!/bin/sh\n")
+	if err := os.Chmod(script, 0755); err != nil {
+		t.Fatal(err)
+	}
+	plain := writeConfig(t, dir, "plain", "")
+	entry := writeConfig(t, dir, "cli.ts", "")
+
+	tests := []struct {
+		name string
+		path string
+		ok   bool
+	}{
+		{"executable", script, true},
+		{"not executable", plain, false},
+		{"ts entry point", entry, true},
+		{"missing", filepath.Join(dir, "missing"), false},
+		{"directory", dir, false},
+		{"on PATH", "sh", true},
+		{"not on PATH", "no-such-cli-xyz", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if r := CheckCLIPath(tt.path); r.OK != tt.ok {
+				t.Errorf("CheckCLIPath(%q) = %+v, want OK=%v", tt.path, r, tt.ok)
+			}
+		})
+	}
+}
+
+func TestCheckCLIVersion(t *testing.T) {
+	r := CheckCLIVersion(exec.Command("sh", "-c", "echo 1.2.3; echo extra"))
+	if !r.OK || r.Detail != "1.2.3" {
+		t.Errorf("got %+v, want OK with 1.2.3", r)
+	}
+	if r := CheckCLIVersion(exec.Command("sh", "-c", "exit 1")); r.OK {
+		t.Errorf("failing CLI should fail, got %+v", r)
+	}
+	if r := CheckCLIVersion(exec.Command("sh", "-c", "true")); r.OK {
+		t.Errorf("silent CLI should fail, got %+v", r)
+	}
+	if r := CheckCLIVersion(exec.Command("no-such-cli-xyz", "--version")); r.OK {
+		t.Errorf("missing CLI should fail, got %+v", r)
+	}
+}
+
+func TestCheckIndexDir(t *testing.T) {
+	dir := t.TempDir()
+	if r := CheckIndexDir(dir); r.OK {
+		t.Errorf("missing .curator should fail, got %+v", r)
+	}
+
+	if err := os.Mkdir(filepath.Join(dir, ".curator"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if r := CheckIndexDir(dir); r.OK {
+		t.Errorf("missing index file should fail, got %+v", r)
+	}
+
+	writeConfig(t, filepath.Join(dir, ".curator"), "semantic-index.json", "{}")
+	if r := CheckIndexDir(dir); !r.OK {
+		t.Errorf("present index should pass, got %+v", r)
+	}
+}
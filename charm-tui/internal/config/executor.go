@@ -0,0 +1,133 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultExecutor runs the TypeScript entry points when no executor is configured
+const DefaultExecutor = "bun"
+
+// denoPermissions lists the Deno permissions the CLIs need: they read the
+// project, write the index under .curator, read env vars and spawn git
+var denoPermissions = []string{"--allow-read", "--allow-write", "--allow-env", "--allow-run"}
+
+// CLICommandLine returns the program and arguments that run the CLI at
+// cliPath with args. Script entry points run through the configured
+// executor (bun, node or deno); anything else is a binary run directly.
+func CLICommandLine(cliPath string, args ...string) []string {
+	if !isScript(cliPath) {
+		return append([]string{cliPath}, args...)
+	}
+	return executorArgs(scriptExecutor(), cliPath, args)
+}
+
+// scriptExecutor returns the executor that runs script entry points
+func scriptExecutor() string {
+	if executor := GetExecutor(); executor != "" {
+		return executor
+	}
+	return DefaultExecutor
+}
+
+// RequireExecutor checks, before any command is started, that the executor
+// needed to run the CLI at cliPath is installed. Binaries need none.
+func RequireExecutor(cliPath string) error {
+	if !isScript(cliPath) {
+		return nil
+	}
+	executor := scriptExecutor()
+	if _, err := exec.LookPath(executor); err != nil {
+		name := runtimeName(executor)
+		return fmt.Errorf("%s not found — install %s or set CURATOR_EXECUTOR", executor, name)
+	}
+	return nil
+}
+
+// isScript reports whether cliPath is a JavaScript or TypeScript entry point
+func isScript(cliPath string) bool {
+	switch filepath.Ext(cliPath) {
+	case ".ts", ".js", ".mjs", ".cjs":
+		return true
+	}
+	return false
+}
+
+// executorArgs builds the command line that runs script with executor,
+// following each runtime's conventions
+func executorArgs(executor, script string, args []string) []string {
+	var line []string
+	switch runtimeName(executor) {
+	case "node":
+		line = []string{executor}
+		if filepath.Ext(script) == ".ts" {
+			// Node 22.6+ runs TypeScript by stripping the type annotations
+			line = append(line, "--experimental-strip-types")
+		}
+		line = append(line, script)
+	case "deno":
+		line = append([]string{executor, "run"}, denoPermissions...)
+		line = append(line, script)
+	default:
+		// bun, and custom executors that follow its `run` convention
+		line = []string{executor, "run", script}
+	}
+	return append(line, args...)
+}
+
+// runtimeName returns the runtime an executor path refers to, such as
+// "node" for /usr/local/bin/node or node.exe
+func runtimeName(executor string) string {
+	return strings.TrimSuffix(filepath.Base(executor), ".exe")
+}
+
+// TimeoutExitCode is the status a wrapper exits with when --timeout kills
+// the CLI, as timeout(1) does
+const TimeoutExitCode = 124
+
+// ErrTimeout is wrapped by the error of a CLI killed by --timeout
+var ErrTimeout = errors.New("timed out")
+
+// TimeoutContext bounds a CLI run by timeout; zero means no limit
+func TimeoutContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+// TimeoutError explains err when the CLI run under ctx was killed because
+// its timeout expired, and returns err unchanged otherwise
+func TimeoutError(ctx context.Context, timeout time.Duration, err error) error {
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %s: the CLI was stopped (raise --timeout to allow longer runs)", ErrTimeout, timeout)
+	}
+	return err
+}
+
+// ExitCode returns the status a wrapper should exit with after err: the
+// TypeScript CLI's own status when err is its failed exit, otherwise 1
+func ExitCode(err error) int {
+	if errors.Is(err, ErrTimeout) {
+		return TimeoutExitCode
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+		return exitErr.ExitCode()
+	}
+	return 1
+}
+
+// CLIReported reports whether err is a failed exit of a CLI that ran
+// attached to the terminal. It has already explained why on stderr, so
+// printing err as well adds nothing. Stderr captured into the error was
+// never shown.
+func CLIReported(err error) bool {
+	var exitErr *exec.ExitError
+	return errors.As(err, &exitErr) && len(exitErr.Stderr) == 0
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExecutorArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		executor string
+		script   string
+		want     []string
+	}{
+		{"bun", "bun", "cli.ts", []string{"bun", "run", "cli.ts", "--json"}},
+		{"bun path", "/opt/bun/bin/bun", "cli.ts", []string{"/opt/bun/bin/bun", "run", "cli.ts", "--json"}},
+		{"node ts", "node", "cli.ts", []string{"node", "--experimental-strip-types", "cli.ts", "--json"}},
+		{"node js", "node.exe", "cli.js", []string{"node.exe", "cli.js", "--json"}},
+		{"deno", "/usr/local/bin/deno", "cli.ts", []string{"/usr/local/bin/deno", "run", "--allow-read", "--allow-write", "--allow-env", "--allow-run", "cli.ts", "--json"}},
+		{"custom", "tsx-runner", "cli.ts", []string{"tsx-runner", "run", "cli.ts", "--json"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := executorArgs(tt.executor, tt.script, []string{"--json"}); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("executorArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCLICommandLine(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("CURATOR_CONFIG", t.TempDir()+"/config.yaml")
+	t.Cleanup(ResetCache)
+	
+	ResetCache()
+	if got := CLICommandLine("smartgrep", "--index"); !reflect.DeepEqual(got, []string{"smartgrep", "--index"}) {
+		t.Errorf("binary: got %v", got)
+	}
+	
+	t.Setenv("CURATOR_EXECUTOR", "deno")
+	ResetCache()
+	want := []string{"deno", "run", "--allow-read", "--allow-write", "--allow-env", "--allow-run", "/repo/cli.ts", "status"}
+	if got := CLICommandLine("/repo/cli.ts", "status"); !reflect.DeepEqual(got, want) {
+		t.Errorf("deno: got %v, want %v", got, want)
+	}
+}
+
+func TestRequireExecutor(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("CURATOR_CONFIG", t.TempDir()+"/config.yaml")
+	t.Cleanup(ResetCache)
+	
+	ResetCache()
+	if err := RequireExecutor("smartgrep"); err != nil {
+		t.Errorf("binaries need no executor, got %v", err)
+	}
+	
+	t.Setenv("CURATOR_EXECUTOR", "sh")
+	ResetCache()
+	if err := RequireExecutor("cli.ts"); err != nil {
+		t.Errorf("sh is on PATH, got %v", err)
+	}
+	
+	t.Setenv("CURATOR_EXECUTOR", "no-such-bun-xyz")
+	ResetCache()
+	err := RequireExecutor("cli.ts")
+	if err == nil || !strings.Contains(err.Error(), "install no-such-bun-xyz or set CURATOR_EXECUTOR") {
+		t.Errorf("missing executor error = %v", err)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	err := exec.Command("sh", "-c", "exit 3").Run()
+	if got := ExitCode(err); got != 3 {
+		t.Errorf("ExitCode(exit 3) = %d, want 3", got)
+	}
+	if got := ExitCode(fmt.Errorf("failed to run smartgrep: %w", err)); got != 3 {
+		t.Errorf("ExitCode(wrapped exit 3) = %d, want 3", got)
+	}
+	if got := ExitCode(fmt.Errorf("symbol name required")); got != 1 {
+		t.Errorf("ExitCode(other error) = %d, want 1", got)
+	}
+}
+
+func TestCLIReported(t *testing.T) {
+	// Attached to the terminal the CLI explains its own failure
+	attached := exec.Command("sh", "-c", "exit 2").Run()
+	if !CLIReported(fmt.Errorf("search: %w", attached)) {
+		t.Error("CLIReported() of an attached CLI's exit = false")
+	}
+	
+	// Captured stderr was never shown, so the error is printed
+	_, captured := exec.Command("sh", "-c", "echo boom >&2; exit 2").Output()
+	if CLIReported(captured) {
+		t.Error("CLIReported() with captured stderr = true")
+	}
+	if CLIReported(errors.New("symbol name required")) {
+		t.Error("CLIReported() of a wrapper error = true")
+	}
+}
+
+func TestTimeoutError(t *testing.T) {
+	ctx, cancel := TimeoutContext(50 * time.Millisecond)
+	defer cancel()
+	err := TimeoutError(ctx, 50*time.Millisecond, exec.CommandContext(ctx, "sleep", "5").Run())
+	if !errors.Is(err, ErrTimeout) || !strings.Contains(err.Error(), "50ms") {
+		t.Fatalf("killed CLI error = %v", err)
+	}
+	if got := ExitCode(err); got != TimeoutExitCode {
+		t.Errorf("ExitCode(timeout) = %d, want %d", got, TimeoutExitCode)
+	}
+	
+	// Without a timeout the CLI's own failure is passed on
+	ctx, cancel = TimeoutContext(0)
+	defer cancel()
+	err = TimeoutError(ctx, 0, exec.CommandContext(ctx, "sh", "-c", "exit 3").Run())
+	if got := ExitCode(err); got != 3 {
+		t.Errorf("ExitCode(exit 3) = %d, want 3", got)
+	}
+}
@@ -0,0 +1,346 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds the settings that can be given in a config file
+type Config struct {
+	SmartgrepPath string `yaml:"smartgrepPath"`
+	CuratorPath   string `yaml:"curatorPath"`
+	MonitorPath   string `yaml:"monitorPath"`
+	Executor      string `yaml:"executor"`
+	
+	// Default flag values
+	MaxResults int `yaml:"maxResults"`
+	WordWrap   int `yaml:"wordWrap"`
+	
+	// Claude batch mode: suffixes appended to the topic, and a bound on
+	// the number of searches run
+	BatchSuffixes    []string `yaml:"batchSuffixes"`
+	MaxBatchSearches int      `yaml:"maxBatchSearches"`
+	
+	// Curator TUI retries of transient CLI failures (rate limits, network
+	// errors): how many, and the delay before the first, doubling after
+	// each. A negative maxRetries turns retrying off.
+	MaxRetries int           `yaml:"maxRetries"`
+	RetryDelay time.Duration `yaml:"retryDelay"`
+	
+	// Background the TUI colors are chosen for: light, dark, or auto
+	ThemeMode string `yaml:"themeMode"`
+	
+	// TUI colors by role name (title, accent, added, ...), as ANSI color
+	// numbers or hex values
+	Theme map[string]string `yaml:"theme"`
+	
+	// How result paths are shown: relative to the project root, or
+	// absolute. Toggled with p in the smartgrep TUI.
+	PathDisplay string `yaml:"pathDisplay"`
+}
+
+// ProjectConfigFile is the name of the project-local config file
+const ProjectConfigFile = ".curator.yaml"
+
+// UserConfigPath returns the location of the user-wide config file
+func UserConfigPath() string {
+	if path := os.Getenv("CURATOR_CONFIG"); path != "" {
+		return path
+	}
+	if dir, err := os.UserConfigDir(); err == nil {
+		return filepath.Join(dir, "codebase-curator", "config.yaml")
+	}
+	return ""
+}
+
+// projectDir is the directory the project-local config file is read from;
+// empty means the current directory
+var projectDir string
+
+// SetProjectDir reads the project-local config file from dir instead of the
+// current directory, reloading the config if it was already read
+func SetProjectDir(dir string) {
+	if dir == projectDir {
+		return
+	}
+	projectDir = dir
+	loadedOnce = sync.Once{}
+	loaded = Config{}
+}
+
+// Load reads the user config and then the project-local .curator.yaml in the
+// project directory, with later files overriding earlier ones. Environment
+// variables take precedence over both.
+func Load() (*Config, error) {
+	return load(UserConfigPath(), filepath.Join(projectDir, ProjectConfigFile))
+}
+
+func load(userPath, projectPath string) (*Config, error) {
+	cfg := &Config{}
+	if userPath != "" {
+		file, err := readFile(userPath)
+		if err != nil {
+			return nil, err
+		}
+		cfg.merge(file)
+	}
+	if projectPath != "" {
+		file, err := readFile(projectPath)
+		if err != nil {
+			return nil, err
+		}
+		// A project file comes with the repository, so it must not pick the
+		// programs the tools start
+		if file.SmartgrepPath != "" || file.CuratorPath != "" || file.MonitorPath != "" || file.Executor != "" {
+			fmt.Fprintf(os.Stderr, "⚠️  ignoring CLI paths and executor in %s: set them in %s or the environment\n", projectPath, UserConfigPath())
+		}
+		file.SmartgrepPath, file.CuratorPath, file.MonitorPath, file.Executor = "", "", "", ""
+		cfg.merge(file)
+	}
+	cfg.applyEnv()
+	return cfg, nil
+}
+
+// readFile parses a YAML config file. A missing file is an empty config.
+func readFile(path string) (Config, error) {
+	var file Config
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return file, nil
+		}
+		return file, err
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return file, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	return file, nil
+}
+
+// merge overlays the non-zero fields of other
+func (c *Config) merge(other Config) {
+	if other.SmartgrepPath != "" {
+		c.SmartgrepPath = other.SmartgrepPath
+	}
+	if other.CuratorPath != "" {
+		c.CuratorPath = other.CuratorPath
+	}
+	if other.MonitorPath != "" {
+		c.MonitorPath = other.MonitorPath
+	}
+	if other.Executor != "" {
+		c.Executor = other.Executor
+	}
+	if other.MaxResults > 0 {
+		c.MaxResults = other.MaxResults
+	}
+	if other.WordWrap > 0 {
+		c.WordWrap = other.WordWrap
+	}
+	if len(other.BatchSuffixes) > 0 {
+		c.BatchSuffixes = other.BatchSuffixes
+	}
+	if other.MaxBatchSearches > 0 {
+		c.MaxBatchSearches = other.MaxBatchSearches
+	}
+	if other.MaxRetries != 0 {
+		c.MaxRetries = other.MaxRetries
+	}
+	if other.RetryDelay > 0 {
+		c.RetryDelay = other.RetryDelay
+	}
+	if other.ThemeMode != "" {
+		c.ThemeMode = other.ThemeMode
+	}
+	if other.PathDisplay != "" {
+		c.PathDisplay = other.PathDisplay
+	}
+	// Colors merge one role at a time, so a project file can adjust a
+	// single color of the user's theme
+	for role, color := range other.Theme {
+		if c.Theme == nil {
+			c.Theme = map[string]string{}
+		}
+		c.Theme[role] = color
+	}
+}
+
+// applyEnv overrides file values with environment variables
+func (c *Config) applyEnv() {
+	env := Config{
+		SmartgrepPath: os.Getenv("SMARTGREP_CLI_PATH"),
+		CuratorPath:   os.Getenv("CURATOR_CLI_PATH"),
+		MonitorPath:   os.Getenv("MONITOR_CLI_PATH"),
+		Executor:      os.Getenv("CURATOR_EXECUTOR"),
+		ThemeMode:     os.Getenv("CURATOR_THEME"),
+	}
+	env.MaxResults, _ = strconv.Atoi(os.Getenv("SMARTGREP_MAX_RESULTS"))
+	env.WordWrap, _ = strconv.Atoi(os.Getenv("CURATOR_WORD_WRAP"))
+	env.MaxBatchSearches, _ = strconv.Atoi(os.Getenv("SMARTGREP_MAX_BATCH_SEARCHES"))
+	env.MaxRetries, _ = strconv.Atoi(os.Getenv("CURATOR_MAX_RETRIES"))
+	env.RetryDelay, _ = time.ParseDuration(os.Getenv("CURATOR_RETRY_DELAY"))
+	c.merge(env)
+}
+
+var (
+	loadedOnce sync.Once
+	loaded     Config
+)
+
+// current returns the loaded config, falling back to environment variables
+// alone if a config file can't be read
+func current() Config {
+	loadedOnce.Do(func() {
+		cfg, err := Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+			cfg = &Config{}
+			cfg.applyEnv()
+		}
+		loaded = *cfg
+	})
+	return loaded
+}
+
+// GetMaxResults returns the configured default result limit, or fallback
+func GetMaxResults(fallback int) int {
+	if n := current().MaxResults; n > 0 {
+		return n
+	}
+	return fallback
+}
+
+// GetWordWrap returns the configured markdown wrap width, or 0 if unset
+func GetWordWrap() int {
+	return current().WordWrap
+}
+
+// GetBatchSuffixes returns the configured batch mode suffixes, or fallback
+func GetBatchSuffixes(fallback []string) []string {
+	if suffixes := current().BatchSuffixes; len(suffixes) > 0 {
+		return suffixes
+	}
+	return fallback
+}
+
+// GetMaxBatchSearches returns the configured bound on batch searches, or fallback
+func GetMaxBatchSearches(fallback int) int {
+	if n := current().MaxBatchSearches; n > 0 {
+		return n
+	}
+	return fallback
+}
+
+// GetMaxRetries returns the configured number of retries, or fallback. A
+// negative setting means no retries.
+func GetMaxRetries(fallback int) int {
+	switch n := current().MaxRetries; {
+	case n < 0:
+		return 0
+	case n > 0:
+		return n
+	}
+	return fallback
+}
+
+// GetRetryDelay returns the configured delay before the first retry, or fallback
+func GetRetryDelay(fallback time.Duration) time.Duration {
+	if d := current().RetryDelay; d > 0 {
+		return d
+	}
+	return fallback
+}
+
+// GetThemeMode returns the configured theme background, or auto if unset
+func GetThemeMode() string {
+	if mode := current().ThemeMode; mode != "" {
+		return mode
+	}
+	return "auto"
+}
+
+// GetTheme returns the configured colors by role name, or nil if unset
+func GetTheme() map[string]string {
+	return current().Theme
+}
+
+// Path display modes
+const (
+	PathsRelative = "relative"
+	PathsAbsolute = "absolute"
+)
+
+// GetPathDisplay returns how result paths are shown, relative unless the
+// config asks for absolute
+func GetPathDisplay() string {
+	if current().PathDisplay == PathsAbsolute {
+		return PathsAbsolute
+	}
+	return PathsRelative
+}
+
+// SetPathDisplay remembers how result paths are shown in the user config file
+func SetPathDisplay(mode string) error {
+	path := UserConfigPath()
+	if path == "" {
+		return fmt.Errorf("no user config directory")
+	}
+	if err := setValue(path, "pathDisplay", mode); err != nil {
+		return err
+	}
+	current()
+	loaded.PathDisplay = mode
+	return nil
+}
+
+// setValue sets a top-level key of the YAML file at path, creating the file
+// if needed. The rest of the file, comments included, is kept.
+func setValue(path, key, value string) error {
+	var doc yaml.Node
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("invalid config %s: not a mapping", path)
+	}
+	
+	set := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == key {
+			root.Content[i+1].SetString(value)
+			set = true
+		}
+	}
+	if !set {
+		k, v := &yaml.Node{}, &yaml.Node{}
+		k.SetString(key)
+		v.SetString(value)
+		root.Content = append(root.Content, k, v)
+	}
+	
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
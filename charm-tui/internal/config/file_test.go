@@ -0,0 +1,184 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func clearEnv(t *testing.T) {
+	for _, name := range []string{
+		"SMARTGREP_CLI_PATH", "CURATOR_CLI_PATH", "MONITOR_CLI_PATH",
+		"CURATOR_EXECUTOR", "SMARTGREP_MAX_RESULTS", "CURATOR_WORD_WRAP",
+		"SMARTGREP_MAX_BATCH_SEARCHES", "CURATOR_THEME",
+		"CURATOR_MAX_RETRIES", "CURATOR_RETRY_DELAY",
+	} {
+		t.Setenv(name, "")
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	clearEnv(t)
+	dir := t.TempDir()
+	path := writeConfig(t, dir, "config.yaml", `
+smartgrepPath: /opt/smartgrep
+curatorPath: /opt/curator
+monitorPath: /opt/monitor
+executor: node
+maxResults: 100
+wordWrap: 120
+batchSuffixes: [Controller, Repo]
+maxBatchSearches: 4
+themeMode: light
+maxRetries: 5
+retryDelay: 500ms
+`)
+	
+	cfg, err := load(path, "")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	want := Config{
+		SmartgrepPath: "/opt/smartgrep",
+		CuratorPath:   "/opt/curator",
+		MonitorPath:   "/opt/monitor",
+		Executor:      "node",
+		MaxResults:    100,
+		WordWrap:      120,
+	
+		BatchSuffixes:    []string{"Controller", "Repo"},
+		MaxBatchSearches: 4,
+		ThemeMode:        "light",
+		MaxRetries:       5,
+		RetryDelay:       500 * time.Millisecond,
+	}
+	if !reflect.DeepEqual(*cfg, want) {
+		t.Errorf("load() = %+v, want %+v", *cfg, want)
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	clearEnv(t)
+	dir := t.TempDir()
+	user := writeConfig(t, dir, "config.yaml", "smartgrepPath: /user/smartgrep\nmaxResults: 100\nwordWrap: 90\ntheme:\n  title: \"99\"\n  accent: \"39\"\n")
+	project := writeConfig(t, dir, ".curator.yaml", "maxResults: 20\ntheme:\n  accent: \"#00afff\"\n")
+	
+	t.Setenv("SMARTGREP_CLI_PATH", "/env/smartgrep")
+	
+	cfg, err := load(user, project)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.SmartgrepPath != "/env/smartgrep" {
+		t.Errorf("env should win: SmartgrepPath = %q", cfg.SmartgrepPath)
+	}
+	if cfg.MaxResults != 20 {
+		t.Errorf("project file should override user file: MaxResults = %d", cfg.MaxResults)
+	}
+	if cfg.WordWrap != 90 {
+		t.Errorf("unset values should fall through: WordWrap = %d", cfg.WordWrap)
+	}
+	if want := map[string]string{"title": "99", "accent": "#00afff"}; !reflect.DeepEqual(cfg.Theme, want) {
+		t.Errorf("theme colors should merge by role: Theme = %v, want %v", cfg.Theme, want)
+	}
+}
+
+func TestLoadMissingAndInvalid(t *testing.T) {
+	clearEnv(t)
+	dir := t.TempDir()
+	
+	cfg, err := load(filepath.Join(dir, "missing.yaml"), "")
+	if err != nil {
+		t.Fatalf("missing files should be skipped, got %v", err)
+	}
+	if !reflect.DeepEqual(*cfg, Config{}) {
+		t.Errorf("expected empty config, got %+v", *cfg)
+	}
+	
+	bad := writeConfig(t, dir, "bad.yaml", "maxResults: [not a number\n")
+	if _, err := load(bad, ""); err == nil {
+		t.Error("expected an error for invalid YAML")
+	}
+}
+
+func TestSetValue(t *testing.T) {
+	clearEnv(t)
+	dir := t.TempDir()
+	path := writeConfig(t, dir, "config.yaml", "# My settings\nmaxResults: 100 # plenty\ntheme:\n  title: \"99\"\n")
+	
+	if err := setValue(path, "pathDisplay", PathsAbsolute); err != nil {
+		t.Fatalf("setValue: %v", err)
+	}
+	if err := setValue(path, "pathDisplay", PathsRelative); err != nil {
+		t.Fatalf("setValue: %v", err)
+	}
+	cfg, err := load(path, "")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.PathDisplay != PathsRelative || cfg.MaxResults != 100 || cfg.Theme["title"] != "99" {
+		t.Errorf("load() after setValue = %+v", *cfg)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "# My settings") || !strings.Contains(string(data), "# plenty") {
+		t.Errorf("comments were dropped:\n%s", data)
+	}
+	
+	created := filepath.Join(dir, "new", "config.yaml")
+	if err := setValue(created, "pathDisplay", PathsAbsolute); err != nil {
+		t.Fatalf("setValue on a missing file: %v", err)
+	}
+	if cfg, err := load(created, ""); err != nil || cfg.PathDisplay != PathsAbsolute {
+		t.Errorf("load() of a created file = %+v, %v", cfg, err)
+	}
+	
+	bad := writeConfig(t, dir, "list.yaml", "- a\n- b\n")
+	if err := setValue(bad, "pathDisplay", PathsAbsolute); err == nil {
+		t.Error("expected an error for a config that is not a mapping")
+	}
+}
+
+func TestProjectFileCannotSetCommands(t *testing.T) {
+	clearEnv(t)
+	dir := t.TempDir()
+	user := writeConfig(t, dir, "config.yaml", "curatorPath: /user/curator\n")
+	project := writeConfig(t, dir, ".curator.yaml", "curatorPath: /repo/evil\nsmartgrepPath: /repo/evil\nmonitorPath: /repo/evil\nexecutor: /repo/evil\nmaxResults: 20\n")
+	
+	cfg, err := load(user, project)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	want := Config{CuratorPath: "/user/curator", MaxResults: 20}
+	if !reflect.DeepEqual(*cfg, want) {
+		t.Errorf("load() = %+v, want %+v", *cfg, want)
+	}
+}
+
+func TestSetProjectDir(t *testing.T) {
+	clearEnv(t)
+	dir := t.TempDir()
+	t.Setenv("CURATOR_CONFIG", filepath.Join(dir, "missing.yaml"))
+	writeConfig(t, dir, ProjectConfigFile, "maxResults: 7\n")
+	ResetCache()
+	t.Cleanup(func() {
+		SetProjectDir("")
+		ResetCache()
+	})
+	
+	SetProjectDir(dir)
+	if got := GetMaxResults(50); got != 7 {
+		t.Errorf("GetMaxResults() = %d, want 7 from the project directory's %s", got, ProjectConfigFile)
+	}
+}
@@ -0,0 +1,45 @@
+package curator
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+)
+
+// Command builds the command that invokes the TypeScript curator CLI. In
+// development the .ts entry point is run through the configured executor; an
+// installed curator binary is invoked directly. When projectPath is set the
+// command runs there and relative CLI paths are resolved against it.
+func Command(projectPath string, args ...string) *exec.Cmd {
+	return CommandContext(context.Background(), projectPath, args...)
+}
+
+// CommandContext is like Command but kills the CLI when ctx is cancelled.
+// The model chosen with SetModel is passed on in the environment.
+func CommandContext(ctx context.Context, projectPath string, args ...string) *exec.Cmd {
+	cliPath := resolveCLIPath(config.GetCuratorPath(), projectPath)
+	line := config.CLICommandLine(cliPath, args...)
+	cmd := exec.CommandContext(ctx, line[0], line[1:]...)
+	
+	if projectPath != "" {
+		cmd.Dir = projectPath
+	}
+	if selectedModel != "" {
+		cmd.Env = append(os.Environ(), ModelEnv+"="+selectedModel)
+	}
+	config.LogCommand(cmd)
+	return cmd
+}
+
+// resolveCLIPath anchors relative CLI paths (but not bare command names that
+// should be looked up in PATH) to the project directory
+func resolveCLIPath(cliPath, projectPath string) string {
+	if projectPath == "" || filepath.IsAbs(cliPath) || !strings.ContainsAny(cliPath, `/\`) {
+		return cliPath
+	}
+	return filepath.Join(projectPath, cliPath)
+}
@@ -0,0 +1,46 @@
+package curator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// formatMarkdown renders a conversation as Markdown. Curator replies are
+// already markdown, so they are written out unchanged.
+func formatMarkdown(messages []message, now time.Time) string {
+	var b strings.Builder
+	b.WriteString("# Curator Conversation\n\n")
+	b.WriteString(fmt.Sprintf("_Exported %s_\n\n", now.Format("2006-01-02 15:04")))
+	
+	for _, msg := range messages {
+		if msg.transient {
+			continue
+		}
+		switch msg.role {
+		case "user":
+			b.WriteString("## You\n\n")
+			b.WriteString(strings.TrimSpace(msg.content) + "\n\n")
+		case "curator":
+			b.WriteString("## Curator\n\n")
+			b.WriteString(strings.TrimSpace(msg.content) + "\n\n")
+		default:
+			b.WriteString("_" + strings.TrimSpace(msg.content) + "_\n\n")
+		}
+	}
+	
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// exportMarkdown writes the conversation to a timestamped file in dir and
+// returns its path
+func exportMarkdown(dir string, messages []message, now time.Time) (string, error) {
+	name := fmt.Sprintf("curator-chat-%s.md", now.Format("20060102-150405"))
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(formatMarkdown(messages, now)), 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,63 @@
+package curator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var exportTime = time.Date(2024, 3, 9, 14, 5, 30, 0, time.UTC)
+
+func TestFormatMarkdown(t *testing.T) {
+	messages := []message{
+		{role: "curator", content: "# Welcome", transient: true},
+		{role: "user", content: "Where is auth handled?"},
+		{role: "curator", content: "In `auth.go`:\n\n```go\nfunc Login() {}\n```\n"},
+		{role: "user", content: "And sessions?"},
+		{role: "system", content: "(cancelled)"},
+	}
+	
+	want := "# Curator Conversation\n\n" +
+		"_Exported 2024-03-09 14:05_\n\n" +
+		"## You\n\nWhere is auth handled?\n\n" +
+		"## Curator\n\nIn `auth.go`:\n\n```go\nfunc Login() {}\n```\n\n" +
+		"## You\n\nAnd sessions?\n\n" +
+		"_(cancelled)_\n"
+	
+	if got := formatMarkdown(messages, exportTime); got != want {
+		t.Errorf("formatMarkdown() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestExportMarkdown(t *testing.T) {
+	dir := t.TempDir()
+	messages := []message{{role: "user", content: "hi"}}
+	
+	path, err := exportMarkdown(dir, messages, exportTime)
+	if err != nil {
+		t.Fatalf("exportMarkdown: %v", err)
+	}
+	if want := filepath.Join(dir, "curator-chat-20240309-140530.md"); path != want {
+		t.Errorf("path = %q, want %q", path, want)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != formatMarkdown(messages, exportTime) {
+		t.Errorf("file content does not match formatMarkdown output")
+	}
+}
+
+func TestExportMarkdownUnwritable(t *testing.T) {
+	// A regular file can't be used as a directory, even when running as root
+	file := filepath.Join(t.TempDir(), "not-a-dir")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	
+	if _, err := exportMarkdown(file, nil, exportTime); err == nil {
+		t.Error("expected an error exporting into a non-directory")
+	}
+}
@@ -0,0 +1,171 @@
+package curator
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// keyMap holds the bindings of the curator TUI
+type keyMap struct {
+	Send      key.Binding
+	Newline   key.Binding
+	Scroll    key.Binding
+	Copy      key.Binding
+	Cancel    key.Binding
+	Export    key.Binding
+	Search    key.Binding
+	Palette   key.Binding
+	NextMatch key.Binding
+	PrevMatch key.Binding
+	Help      key.Binding
+	Exit      key.Binding
+	Quit      key.Binding
+}
+
+var keys = keyMap{
+	Send: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "send"),
+	),
+	Newline: key.NewBinding(
+		key.WithKeys("alt+enter", "ctrl+j"),
+		key.WithHelp("alt/shift+enter", "newline"),
+	),
+	Scroll: key.NewBinding(
+		key.WithKeys("up", "down", "pgup", "pgdown"),
+		key.WithHelp("↑/↓", "scroll"),
+	),
+	Copy: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "copy to clipboard"),
+	),
+	Cancel: key.NewBinding(
+		key.WithKeys("ctrl+x"),
+		key.WithHelp("ctrl+x/esc", "cancel request"),
+	),
+	Export: key.NewBinding(
+		key.WithKeys("ctrl+s"),
+		key.WithHelp("ctrl+s", "export markdown"),
+	),
+	Search: key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "search output"),
+	),
+	Palette: key.NewBinding(
+		key.WithKeys("ctrl+p"),
+		key.WithHelp("ctrl+p", "commands"),
+	),
+	NextMatch: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "next match"),
+	),
+	PrevMatch: key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "previous match"),
+	),
+	Help: key.NewBinding(
+		key.WithKeys("?"),
+		key.WithHelp("?", "more keys"),
+	),
+	Exit: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "quit"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("ctrl+c"),
+		key.WithHelp("ctrl+c", "quit"),
+	),
+}
+
+// clearSearchKey documents Esc while a search is active
+var clearSearchKey = key.NewBinding(
+	key.WithKeys("esc"),
+	key.WithHelp("esc", "clear search"),
+)
+
+// modeHelp adapts the bindings of one mode to the bubbles help component
+type modeHelp struct {
+	short []key.Binding
+	full  [][]key.Binding
+}
+
+func (h modeHelp) ShortHelp() []key.Binding  { return h.short }
+func (h modeHelp) FullHelp() [][]key.Binding { return h.full }
+
+// helpKeys returns the bindings that apply to the current mode
+func (m model) helpKeys() modeHelp {
+	if m.searchTerm != "" {
+		bindings := []key.Binding{keys.NextMatch, keys.PrevMatch, keys.Search, keys.Scroll, clearSearchKey}
+		return modeHelp{short: bindings, full: [][]key.Binding{bindings}}
+	}
+	if m.isLoading {
+		bindings := []key.Binding{keys.Cancel, keys.Scroll, keys.Quit}
+		return modeHelp{short: bindings, full: [][]key.Binding{bindings}}
+	}
+	
+	switch m.mode {
+	case "chat":
+		// '?' is ordinary input while chatting, so there is no full help here
+		bindings := []key.Binding{keys.Send, keys.Newline, keys.Exit, keys.Scroll, keys.Search, keys.Palette, keys.Export}
+		return modeHelp{short: bindings, full: [][]key.Binding{bindings}}
+	case "pr":
+		return modeHelp{
+			short: []key.Binding{keys.Copy, keys.Scroll, keys.Help, keys.Quit},
+			full:  [][]key.Binding{{keys.Copy, keys.Scroll, keys.Search, keys.Export}, {keys.Palette, keys.Help, keys.Quit}},
+		}
+	default:
+		return modeHelp{
+			short: []key.Binding{keys.Scroll, keys.Help, keys.Quit},
+			full:  [][]key.Binding{{keys.Scroll, keys.Search, keys.Export}, {keys.Palette, keys.Help, keys.Quit}},
+		}
+	}
+}
+
+// memoryKeyMap holds the bindings of the memory browser
+type memoryKeyMap struct {
+	Up        key.Binding
+	Down      key.Binding
+	Toggle    key.Binding
+	ToggleAll key.Binding
+	Scroll    key.Binding
+	Refresh   key.Binding
+	Quit      key.Binding
+}
+
+var memoryKeys = memoryKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("up", "k"),
+		key.WithHelp("↑/k", "up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "j"),
+		key.WithHelp("↓/j", "down"),
+	),
+	Toggle: key.NewBinding(
+		key.WithKeys("enter", " "),
+		key.WithHelp("enter", "expand/collapse"),
+	),
+	ToggleAll: key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "expand/collapse all"),
+	),
+	Scroll: key.NewBinding(
+		key.WithKeys("pgup", "pgdown"),
+		key.WithHelp("pgup/pgdn", "scroll"),
+	),
+	Refresh: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "reload"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("q", "esc", "ctrl+c"),
+		key.WithHelp("q", "quit"),
+	),
+}
+
+func (k memoryKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Toggle, keys.Help, k.Quit}
+}
+
+func (k memoryKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Up, k.Down, k.Toggle}, {k.ToggleAll, k.Scroll, k.Refresh}, {keys.Help, k.Quit}}
+}
@@ -0,0 +1,460 @@
+package curator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/style"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Categories memory sections are grouped into, in display order
+var memoryCategories = []string{"Architecture", "Components", "Q&A", "Notes"}
+
+// memoryKeywords sort a section into a category by words in its heading.
+// Headings phrased as questions always go to Q&A.
+var memoryKeywords = map[string][]string{
+	"Architecture": {"architecture", "overview", "structure", "design", "pattern", "flow", "stack", "convention"},
+	"Components":   {"component", "module", "service", "package", "class", "api", "file", "director"},
+	"Q&A":          {"question", "q&a", "faq", "answer", "asked"},
+}
+
+// memorySection is one headed part of the curator's memory
+type memorySection struct {
+	category string
+	title    string
+	body     string // Markdown below the heading
+}
+
+// noMemory is what the CLI prints for a project without memory
+const noMemory = "No curator memory found for this project."
+
+var headingPattern = regexp.MustCompile(`^(#{1,6})\s+(.+?)\s*#*\s*$`)
+
+// memoryMarkdown extracts the memory from `memory --json` output. Output
+// that isn't the JSON envelope is taken to be the markdown itself.
+func memoryMarkdown(output []byte) string {
+	var envelope struct {
+		Output *string `json:"output"`
+	}
+	trimmed := bytes.TrimSpace(output)
+	if start := bytes.IndexByte(trimmed, '{'); start >= 0 {
+		err := json.Unmarshal(trimmed[start:], &envelope)
+		if err == nil && envelope.Output != nil {
+			return strings.TrimSpace(*envelope.Output)
+		}
+		config.LogParseError("memory", fmt.Errorf("not a JSON envelope, showing it as markdown: %v", err))
+	}
+	return string(trimmed)
+}
+
+// heading is a markdown heading found outside code blocks
+type heading struct {
+	line  int
+	level int
+	title string
+}
+
+func findHeadings(lines []string) []heading {
+	var headings []heading
+	inFence := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if match := headingPattern.FindStringSubmatch(line); match != nil {
+			headings = append(headings, heading{line: i, level: len(match[1]), title: match[2]})
+		}
+	}
+	return headings
+}
+
+// parseMemory splits memory markdown into sections at its top heading
+// level, ignoring a lone document title. It returns nil when the memory has
+// no headings to structure it by.
+func parseMemory(markdown string) []memorySection {
+	lines := strings.Split(markdown, "\n")
+	headings := findHeadings(lines)
+	start := 0
+	if len(headings) > 0 && headings[0].level == 1 {
+		titles := 0
+		for _, h := range headings {
+			if h.level == 1 {
+				titles++
+			}
+		}
+		if titles == 1 {
+			start = headings[0].line + 1
+			headings = headings[1:]
+		}
+	}
+	if len(headings) == 0 {
+		return nil
+	}
+	
+	level := headings[0].level
+	for _, h := range headings {
+		level = min(level, h.level)
+	}
+	
+	var top []heading
+	for _, h := range headings {
+		if h.level == level {
+			top = append(top, h)
+		}
+	}
+	
+	// Text between the title and the first section introduces the project
+	var sections []memorySection
+	if summary := strings.TrimSpace(strings.Join(lines[start:top[0].line], "\n")); summary != "" {
+		sections = append(sections, memorySection{category: "Architecture", title: "Overview", body: summary})
+	}
+	for i, h := range top {
+		end := len(lines)
+		if i+1 < len(top) {
+			end = top[i+1].line
+		}
+		sections = append(sections, memorySection{
+			category: categorize(h.title),
+			title:    h.title,
+			body:     strings.TrimSpace(strings.Join(lines[h.line+1:end], "\n")),
+		})
+	}
+	return groupSections(sections)
+}
+
+// categorize picks the category of a section from its heading
+func categorize(title string) string {
+	lower := strings.ToLower(title)
+	if strings.HasSuffix(lower, "?") || strings.HasPrefix(lower, "q:") {
+		return "Q&A"
+	}
+	for _, category := range memoryCategories {
+		for _, word := range memoryKeywords[category] {
+			if strings.Contains(lower, word) {
+				return category
+			}
+		}
+	}
+	return "Notes"
+}
+
+// groupSections orders sections by category, keeping their order within one
+func groupSections(sections []memorySection) []memorySection {
+	grouped := make([]memorySection, 0, len(sections))
+	for _, category := range memoryCategories {
+		for _, s := range sections {
+			if s.category == category {
+				grouped = append(grouped, s)
+			}
+		}
+	}
+	return grouped
+}
+
+var (
+	categoryStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(style.Colors().Accent)
+			
+	sectionStyle = lipgloss.NewStyle().
+			Foreground(style.Colors().Title)
+			
+	cursorStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(style.Colors().Selection).
+			Background(style.Colors().Selected)
+)
+
+// memoryMsg delivers the memory read by fetchMemory
+type memoryMsg struct {
+	markdown string
+	err      error
+}
+
+// fetchMemory asks the CLI for the project's memory as JSON
+func fetchMemory(projectPath string) tea.Cmd {
+	return func() tea.Msg {
+		var stderr bytes.Buffer
+		cmd := CommandContext(context.Background(), projectPath, "memory", projectPath, "--json")
+		cmd.Stderr = &stderr
+		output, err := cmd.Output()
+		if err != nil {
+			return memoryMsg{err: fmt.Errorf("%v\n%s", err, strings.TrimSpace(stderr.String()))}
+		}
+		return memoryMsg{markdown: memoryMarkdown(output)}
+	}
+}
+
+// memoryModel browses the curator's memory section by section. Memory
+// without headings is shown as rendered markdown instead.
+type memoryModel struct {
+	projectPath string
+	markdown    string
+	sections    []memorySection
+	expanded    []bool
+	cursor      int
+	offsets     []int // Viewport line of each section's heading
+	loading     bool
+	err         error
+	viewport    viewport.Model
+	spinner     spinner.Model
+	renderer    *glamour.TermRenderer
+	wrapWidth   int
+	help        help.Model
+	width       int
+	height      int
+}
+
+func newMemoryModel(projectPath string) memoryModel {
+	// The CLI runs from the project directory, so make sure the path still
+	// means the same thing from there
+	if abs, err := filepath.Abs(projectPath); err == nil {
+		projectPath = abs
+	}
+	
+	renderer, _ := glamour.NewTermRenderer(style.GlamourOptions(style.WordWrap())...)
+	return memoryModel{
+		projectPath: projectPath,
+		loading:     true,
+		viewport:    viewport.New(80, 20),
+		spinner:     style.NewSpinner(style.Colors().Title),
+		renderer:    renderer,
+		wrapWidth:   style.WordWrap(),
+		help:        help.New(),
+	}
+}
+
+func (m memoryModel) Init() tea.Cmd {
+	return tea.Batch(style.SpinnerTick(m.spinner), fetchMemory(m.projectPath))
+}
+
+// setMemory replaces the browsed memory, starting with every section
+// collapsed so the outline fits on screen
+func (m *memoryModel) setMemory(markdown string) {
+	m.markdown = markdown
+	m.sections = parseMemory(markdown)
+	m.expanded = make([]bool, len(m.sections))
+	m.cursor = min(m.cursor, max(len(m.sections)-1, 0))
+	m.render()
+}
+
+// toggleAll expands every section, or collapses them all if all are open
+func (m *memoryModel) toggleAll() {
+	open := true
+	for _, e := range m.expanded {
+		open = open && e
+	}
+	for i := range m.expanded {
+		m.expanded[i] = !open
+	}
+}
+
+func (m memoryModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.help.Width = msg.Width
+		m.viewport.Width = msg.Width - 4
+		m.viewport.Height = max(msg.Height-chromeHeight(m.help.ShowAll), 3)
+		if wrap := style.MarkdownWidth(m.viewport.Width - 4); wrap != m.wrapWidth {
+			if renderer, err := glamour.NewTermRenderer(style.GlamourOptions(wrap)...); err == nil {
+				m.renderer = renderer
+				m.wrapWidth = wrap
+			}
+		}
+		m.render()
+		return m, nil
+		
+	case memoryMsg:
+		m.loading = false
+		m.err = msg.err
+		if msg.err == nil {
+			m.setMemory(msg.markdown)
+		}
+		return m, nil
+		
+	case spinner.TickMsg:
+		if !m.loading {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+		
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, memoryKeys.Quit):
+			return m, tea.Quit
+		case key.Matches(msg, keys.Help):
+			m.help.ShowAll = !m.help.ShowAll
+			m.viewport.Height = max(m.height-chromeHeight(m.help.ShowAll), 3)
+			return m, nil
+		case m.loading:
+			return m, nil
+		case key.Matches(msg, memoryKeys.Refresh):
+			m.loading = true
+			m.err = nil
+			return m, tea.Batch(style.SpinnerTick(m.spinner), fetchMemory(m.projectPath))
+		}
+		
+		if len(m.sections) > 0 {
+			switch {
+			case key.Matches(msg, memoryKeys.Up):
+				m.cursor = max(m.cursor-1, 0)
+			case key.Matches(msg, memoryKeys.Down):
+				m.cursor = min(m.cursor+1, len(m.sections)-1)
+			case key.Matches(msg, memoryKeys.Toggle):
+				m.expanded[m.cursor] = !m.expanded[m.cursor]
+			case key.Matches(msg, memoryKeys.ToggleAll):
+				m.toggleAll()
+			default:
+				var cmd tea.Cmd
+				m.viewport, cmd = m.viewport.Update(msg)
+				return m, cmd
+			}
+			m.render()
+			m.scrollToCursor()
+			return m, nil
+		}
+	}
+	
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// chromeHeight is the number of lines around the viewport: the title, the
+// border and the help, which takes an extra line when expanded
+func chromeHeight(showAll bool) int {
+	if showAll {
+		return 11
+	}
+	return 10
+}
+
+// renderMarkdown renders markdown, falling back to the text itself
+func (m memoryModel) renderMarkdown(markdown string) string {
+	if m.renderer == nil {
+		return markdown + "\n"
+	}
+	rendered, err := m.renderer.Render(markdown)
+	if err != nil {
+		return markdown + "\n"
+	}
+	return rendered
+}
+
+// render lays out the sections under their categories, recording where
+// each heading lands so the cursor can be kept in view
+func (m *memoryModel) render() {
+	if len(m.sections) == 0 {
+		if strings.TrimSpace(m.markdown) == "" || m.markdown == noMemory {
+			m.viewport.SetContent(systemStyle.Render(noMemory))
+			return
+		}
+		m.viewport.SetContent(m.renderMarkdown(m.markdown))
+		return
+	}
+	
+	var content strings.Builder
+	lines := 0
+	write := func(s string) {
+		content.WriteString(s)
+		lines += strings.Count(s, "\n")
+	}
+	
+	m.offsets = make([]int, len(m.sections))
+	category := ""
+	for i, section := range m.sections {
+		if section.category != category {
+			if category != "" {
+				write("\n")
+			}
+			category = section.category
+			write(categoryStyle.Render(category) + "\n")
+		}
+		
+		marker := "▸"
+		if m.expanded[i] {
+			marker = "▾"
+		}
+		heading := fmt.Sprintf("%s %s", marker, section.title)
+		if i == m.cursor {
+			heading = cursorStyle.Render(heading)
+		} else {
+			heading = sectionStyle.Render(heading)
+		}
+		m.offsets[i] = lines
+		write("  " + heading + "\n")
+		
+		if m.expanded[i] && section.body != "" {
+			write(m.renderMarkdown(section.body))
+		}
+	}
+	m.viewport.SetContent(content.String())
+}
+
+// scrollToCursor scrolls the viewport just enough to show the heading under
+// the cursor
+func (m *memoryModel) scrollToCursor() {
+	if m.cursor >= len(m.offsets) {
+		return
+	}
+	line := m.offsets[m.cursor]
+	switch {
+	case line < m.viewport.YOffset:
+		m.viewport.SetYOffset(line)
+	case line >= m.viewport.YOffset+m.viewport.Height:
+		m.viewport.SetYOffset(line - m.viewport.Height + 1)
+	}
+}
+
+func (m memoryModel) View() string {
+	title := titleStyle.Render("🧠 Curator Memory")
+	
+	var body string
+	switch {
+	case m.loading:
+		body = style.Loading(m.spinner, "Reading memory...")
+	case m.err != nil:
+		body = errorStyle.Render("❌ Error:") + "\n" + errorBodyStyle.Render(m.err.Error())
+	default:
+		body = m.viewport.View()
+	}
+	
+	mode := modeStyle.Render("memory")
+	if n := len(m.sections); n == 1 {
+		mode += projectStyle.Render(" • 1 section")
+	} else if n > 1 {
+		mode += projectStyle.Render(fmt.Sprintf(" • %d sections", n))
+	}
+	home, _ := os.UserHomeDir()
+	width := max(m.width-lipgloss.Width(mode)-8, 20)
+	footer := projectStyle.Render("📁 "+projectLabel(m.projectPath, home, width)+" • ") + mode
+	
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		chatStyle.Render(body),
+		footer+helpStyle.Render(m.help.View(memoryKeys)),
+	)
+}
@@ -0,0 +1,165 @@
+package curator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const sampleMemory = `# Curator Memory
+
+A TUI over the curator CLI.
+
+## Architecture Overview
+Commands shell out to the CLI.
+
+` + "```sh\n# not a heading\n```" + `
+
+### Layers
+cmd, internal, style.
+
+## Key Components
+- tui.go
+
+## How are sessions resumed?
+With --resume.
+
+## Gotchas
+Run offline.
+`
+
+func TestMemoryMarkdown(t *testing.T) {
+	tests := []struct {
+		name, output, want string
+	}{
+		{"envelope", `{"command":"memory","output":"# Notes\n","timestamp":"now"}`, "# Notes"},
+		{"log before envelope", "[CuratorProcess] ready\n{\"output\":\"text\"}", "text"},
+		{"plain markdown", "## Notes\nbody\n", "## Notes\nbody"},
+		{"other json", `{"sections":[]}`, `{"sections":[]}`},
+	}
+	for _, tt := range tests {
+		if got := memoryMarkdown([]byte(tt.output)); got != tt.want {
+			t.Errorf("%s: memoryMarkdown() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseMemory(t *testing.T) {
+	sections := parseMemory(sampleMemory)
+	
+	var got []string
+	for _, s := range sections {
+		got = append(got, s.category+"/"+s.title)
+	}
+	want := []string{
+		"Architecture/Overview",
+		"Architecture/Architecture Overview",
+		"Components/Key Components",
+		"Q&A/How are sessions resumed?",
+		"Notes/Gotchas",
+	}
+	if strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Fatalf("sections = %v, want %v", got, want)
+	}
+	
+	arch := sections[1].body
+	if !strings.Contains(arch, "# not a heading") || !strings.Contains(arch, "### Layers") {
+		t.Errorf("architecture body lost its code block or subsection:\n%s", arch)
+	}
+}
+
+func TestParseMemoryWithoutHeadings(t *testing.T) {
+	for _, markdown := range []string{"", noMemory, "# Memory\n\njust notes", "- a\n- b"} {
+		if sections := parseMemory(markdown); sections != nil {
+			t.Errorf("parseMemory(%q) = %v, want nil", markdown, sections)
+		}
+	}
+}
+
+func TestCategorize(t *testing.T) {
+	tests := map[string]string{
+		"System Design":        "Architecture",
+		"Services":             "Components",
+		"Q: where is auth?":    "Q&A",
+		"Why does it use Bun?": "Q&A",
+		"Frequently asked":     "Q&A",
+		"Things to remember":   "Notes",
+	}
+	for title, want := range tests {
+		if got := categorize(title); got != want {
+			t.Errorf("categorize(%q) = %q, want %q", title, got, want)
+		}
+	}
+}
+
+func TestMemoryBrowserNavigation(t *testing.T) {
+	m := newMemoryModel("/tmp/project")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 30})
+	updated, _ = updated.Update(memoryMsg{markdown: sampleMemory})
+	m = updated.(memoryModel)
+	
+	send := func(keys ...string) {
+		for _, k := range keys {
+			msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+			if k == "enter" {
+				msg = tea.KeyMsg{Type: tea.KeyEnter}
+			}
+			updated, _ := m.Update(msg)
+			m = updated.(memoryModel)
+		}
+	}
+	
+	if strings.Contains(m.viewport.View(), "Commands shell out") {
+		t.Fatal("sections should start collapsed")
+	}
+	
+	send("j", "enter")
+	if m.cursor != 1 || !m.expanded[1] {
+		t.Fatalf("cursor = %d, expanded = %v, want section 1 expanded", m.cursor, m.expanded)
+	}
+	if !strings.Contains(m.viewport.View(), "Commands shell out") {
+		t.Errorf("expanded section body not shown:\n%s", m.viewport.View())
+	}
+	
+	send("k", "k")
+	if m.cursor != 0 {
+		t.Errorf("cursor = %d, want it to stop at 0", m.cursor)
+	}
+	
+	send("a")
+	for i, e := range m.expanded {
+		if !e {
+			t.Errorf("section %d still collapsed after expanding all", i)
+		}
+	}
+	send("a")
+	for i, e := range m.expanded {
+		if e {
+			t.Errorf("section %d still expanded after collapsing all", i)
+		}
+	}
+}
+
+func TestMemoryBrowserFallback(t *testing.T) {
+	m := newMemoryModel("/tmp/project")
+	updated, _ := m.Update(memoryMsg{markdown: noMemory})
+	if view := updated.(memoryModel).viewport.View(); !strings.Contains(view, noMemory) {
+		t.Errorf("empty memory not reported:\n%s", view)
+	}
+	
+	updated, _ = m.Update(memoryMsg{markdown: "- remembers one thing"})
+	if view := updated.(memoryModel).viewport.View(); !strings.Contains(view, "remembers one thing") {
+		t.Errorf("unstructured memory not rendered:\n%s", view)
+	}
+}
+
+func TestNewMemoryModelAbsolutePath(t *testing.T) {
+	// fetchMemory runs the CLI from the project, so a relative path would
+	// be resolved twice
+	m := newMemoryModel("sub")
+	if want, _ := filepath.Abs("sub"); m.projectPath != want {
+		t.Errorf("projectPath = %q, want %q", m.projectPath, want)
+	}
+}
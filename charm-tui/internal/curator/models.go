@@ -0,0 +1,49 @@
+package curator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// KnownModels are the model aliases the Claude CLI accepts. The TypeScript
+// curator has no way to list models, so the aliases are kept here; full
+// model names such as claude-sonnet-4-5 are accepted as well.
+var KnownModels = []string{"sonnet", "opus", "haiku"}
+
+// selectedModel is the model the TypeScript curator asks Claude to use; empty means
+// the Claude CLI's default
+var selectedModel string
+
+// ModelEnv passes the model to the TypeScript curator, which adds it to the
+// Claude CLI command line
+const ModelEnv = "CURATOR_MODEL"
+
+// ValidateModel reports whether name is a known alias or a full model name
+func ValidateModel(name string) error {
+	for _, known := range KnownModels {
+		if name == known {
+			return nil
+		}
+	}
+	if strings.HasPrefix(name, "claude-") && len(name) > len("claude-") {
+		return nil
+	}
+	return fmt.Errorf("unknown model %q (expected %s, or a full claude-... model name)", name, strings.Join(KnownModels, ", "))
+}
+
+// SetModel selects the model curator commands use. An empty name keeps the
+// Claude CLI's default.
+func SetModel(name string) error {
+	if name != "" {
+		if err := ValidateModel(name); err != nil {
+			return err
+		}
+	}
+	selectedModel = name
+	return nil
+}
+
+// Model returns the selected model, or "" for the Claude CLI's default
+func Model() string {
+	return selectedModel
+}
@@ -0,0 +1,28 @@
+package curator
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestSetModel(t *testing.T) {
+	defer SetModel("")
+	
+	for _, name := range []string{"sonnet", "opus", "haiku", "claude-sonnet-4-5", ""} {
+		if err := SetModel(name); err != nil {
+			t.Errorf("SetModel(%q): %v", name, err)
+		}
+	}
+	for _, name := range []string{"gpt-4", "claude-", "Sonnet"} {
+		if err := SetModel(name); err == nil {
+			t.Errorf("SetModel(%q) should fail", name)
+		}
+	}
+	
+	SetModel("opus")
+	cmd := CommandContext(context.Background(), "", "ask", "why?")
+	if !slices.Contains(cmd.Env, ModelEnv+"=opus") {
+		t.Errorf("the model should be passed to the CLI, got env %v", cmd.Env)
+	}
+}
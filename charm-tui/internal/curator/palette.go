@@ -0,0 +1,147 @@
+package curator
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/style"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// paletteAction is an entry of the command palette
+type paletteAction struct {
+	id    string
+	title string
+	desc  string
+}
+
+func (a paletteAction) Title() string       { return a.title }
+func (a paletteAction) Description() string { return a.desc }
+func (a paletteAction) FilterValue() string { return a.title }
+
+var paletteActions = []paletteAction{
+	{"overview", "📋 Overview", "Summarize the project's architecture"},
+	{"memory", "🧠 Memory", "Show what the curator remembers about the project"},
+	{"chat", "💬 Chat", "Ask your own questions"},
+	{"new-session", "✨ New session", "Start a fresh analysis session with an overview"},
+	{"clear", "🧹 Clear", "Clear the curator's memory and start fresh"},
+	{"export", "💾 Export", "Save the conversation as markdown"},
+}
+
+// Largest size of the palette box
+const (
+	paletteWidth  = 56
+	paletteHeight = 16
+)
+
+var paletteStyle = lipgloss.NewStyle().
+	BorderStyle(lipgloss.RoundedBorder()).
+	BorderForeground(style.Colors().Title).
+	Padding(0, 1)
+
+func newPalette() list.Model {
+	items := make([]list.Item, len(paletteActions))
+	for i, a := range paletteActions {
+		items[i] = a
+	}
+	l := list.New(items, list.NewDefaultDelegate(), paletteWidth, paletteHeight)
+	l.Title = "Commands"
+	l.SetShowStatusBar(false)
+	l.SetShowHelp(false)
+	// Esc and ctrl+c are handled by the palette itself
+	l.KeyMap.Quit.SetEnabled(false)
+	l.KeyMap.ForceQuit.SetEnabled(false)
+	return l
+}
+
+// openPalette shows the command palette with the filter ready for typing
+func (m *model) openPalette() tea.Cmd {
+	m.paletteOpen = true
+	m.palette.ResetFilter()
+	m.palette.ResetSelected()
+	m.palette.SetSize(min(paletteWidth, max(m.viewport.Width-4, 20)), min(paletteHeight, max(m.viewport.Height-2, 6)))
+	
+	var cmd tea.Cmd
+	m.palette, cmd = m.palette.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	return cmd
+}
+
+// updatePalette handles a key while the palette is open. Enter runs the
+// highlighted action even while filtering.
+func (m *model) updatePalette(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, keys.Quit):
+		return tea.Quit
+	case key.Matches(msg, keys.Palette), msg.Type == tea.KeyEsc:
+		m.paletteOpen = false
+		return nil
+	case msg.Type == tea.KeyEnter:
+		m.paletteOpen = false
+		if action, ok := m.palette.SelectedItem().(paletteAction); ok {
+			return m.runAction(action.id)
+		}
+		return nil
+	}
+	
+	var cmd tea.Cmd
+	m.palette, cmd = m.palette.Update(msg)
+	return cmd
+}
+
+// runAction carries out a palette action, switching mode where it has one
+func (m *model) runAction(id string) tea.Cmd {
+	switch id {
+	case "overview":
+		m.newSession = false
+		return m.switchMode("overview", "Project overview")
+	case "new-session":
+		m.newSession = true
+		return m.switchMode("overview", "Project overview (new session)")
+	case "memory":
+		return m.switchMode("memory", "Curator memory")
+	case "chat":
+		m.mode = "chat"
+		m.textarea.Focus()
+		m.fitInput()
+		return nil
+	case "clear":
+		m.mode = "chat"
+		m.textarea.Focus()
+		m.isLoading = true
+		ctx := m.newRequest()
+		m.addMessage(message{role: "system", content: "Clearing the curator's memory…", transient: true})
+		m.updateViewport()
+		return tea.Batch(style.SpinnerTick(m.spinner), m.runCuratorCommand(ctx, "clear", m.projectPath))
+	case "export":
+		if path, err := exportMarkdown(m.projectPath, m.messages, time.Now()); err != nil {
+			m.status = fmt.Sprintf("Export failed: %v", err)
+		} else {
+			m.status = "💾 Exported to " + path
+		}
+	}
+	return nil
+}
+
+// switchMode moves the session to a one-shot mode and runs its command,
+// recording request as the user's turn
+func (m *model) switchMode(mode, request string) tea.Cmd {
+	m.mode = mode
+	m.question = ""
+	m.textarea.Blur()
+	m.isLoading = true
+	ctx := m.newRequest()
+	m.addMessage(message{role: "user", content: request})
+	m.updateViewport()
+	
+	args := m.initialCommandArgs()
+	return tea.Batch(style.SpinnerTick(m.spinner), m.runCuratorCommand(ctx, args[0], args[1:]...))
+}
+
+// paletteView draws the palette centred over the conversation
+func (m model) paletteView() string {
+	return lipgloss.Place(m.viewport.Width, m.viewport.Height, lipgloss.Center, lipgloss.Center,
+		paletteStyle.Render(m.palette.View()))
+}
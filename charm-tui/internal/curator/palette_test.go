@@ -0,0 +1,76 @@
+package curator
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// press sends a key to the model. While the palette is open the list's
+// filter results are fed back, as the program would.
+func press(m model, msg tea.KeyMsg) (model, tea.Cmd) {
+	updated, cmd := m.Update(msg)
+	m = updated.(model)
+	if !m.paletteOpen {
+		return m, cmd
+	}
+	for _, result := range runCmd(cmd) {
+		if matches, ok := result.(list.FilterMatchesMsg); ok {
+			updated, _ = m.Update(matches)
+			m = updated.(model)
+		}
+	}
+	return m, nil
+}
+
+// runCmd runs a command and any batch it returns, skipping timers
+func runCmd(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		var msgs []tea.Msg
+		for _, c := range batch {
+			msgs = append(msgs, runCmd(c)...)
+		}
+		return msgs
+	}
+	return []tea.Msg{msg}
+}
+
+func TestPaletteSwitchesMode(t *testing.T) {
+	m := initialModel("chat", "/tmp/project")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	m = updated.(model)
+	
+	m, _ = press(m, tea.KeyMsg{Type: tea.KeyCtrlP})
+	if !m.paletteOpen {
+		t.Fatal("ctrl+p should open the palette")
+	}
+	m, _ = press(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("memory")})
+	m, cmd := press(m, tea.KeyMsg{Type: tea.KeyEnter})
+	
+	if m.paletteOpen {
+		t.Error("running an action should close the palette")
+	}
+	if m.mode != "memory" || !m.isLoading || cmd == nil {
+		t.Errorf("expected the memory command to start, got mode %q loading %v", m.mode, m.isLoading)
+	}
+	if got := m.initialCommandArgs(); len(got) == 0 || got[0] != "memory" {
+		t.Errorf("initialCommandArgs() = %v, want the memory command", got)
+	}
+}
+
+func TestPaletteEscCloses(t *testing.T) {
+	m := initialModel("overview", "/tmp/project")
+	m, _ = press(m, tea.KeyMsg{Type: tea.KeyCtrlP})
+	m, cmd := press(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.paletteOpen || cmd != nil {
+		t.Errorf("esc should close the palette without quitting, open %v cmd %v", m.paletteOpen, cmd != nil)
+	}
+	if m.mode != "overview" {
+		t.Errorf("closing the palette should keep the mode, got %q", m.mode)
+	}
+}
@@ -0,0 +1,51 @@
+package curator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/style"
+	"github.com/charmbracelet/glamour"
+)
+
+// AskPlain asks the curator a question and writes the answer, rendered as
+// markdown, to w. Unlike RunAskTUI nothing takes over the screen, so the
+// answer stays in the scrollback or can be piped. The CLI's progress and
+// errors go to stderr; a failed CLI is returned as its *exec.ExitError.
+func AskPlain(ctx context.Context, w io.Writer, projectPath, question string) error {
+	if projectPath == "" {
+		projectPath, _ = os.Getwd()
+	}
+	// The CLI runs from the project directory, so make sure the path still
+	// means the same thing from there
+	if abs, err := filepath.Abs(projectPath); err == nil {
+		projectPath = abs
+	}
+	
+	cmd := CommandContext(ctx, projectPath, "ask", projectPath, question)
+	cmd.Stderr = os.Stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return err
+	}
+	
+	rendered, err := renderPlain(string(output), style.WordWrap())
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(w, rendered)
+	return err
+}
+
+// renderPlain renders markdown for printing, without color when the output
+// isn't a terminal or NO_COLOR is set
+func renderPlain(markdown string, width int) (string, error) {
+	renderer, err := glamour.NewTermRenderer(style.GlamourOptions(width)...)
+	if err != nil {
+		return "", fmt.Errorf("failed to create markdown renderer: %w", err)
+	}
+	return renderer.Render(markdown)
+}
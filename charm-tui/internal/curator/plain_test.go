@@ -0,0 +1,69 @@
+package curator
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+)
+
+func TestRenderPlain(t *testing.T) {
+	// Tests don't run on a terminal, so the output has no escape codes
+	out, err := renderPlain("# Auth\n\nTokens are checked in `middleware.ts`.", 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out, "\x1b[") {
+		t.Errorf("non-terminal output should be plain, got %q", out)
+	}
+	if !strings.Contains(out, "Auth") || !strings.Contains(out, "middleware.ts") {
+		t.Errorf("rendered answer lost its text: %q", out)
+	}
+}
+
+func TestAskPlainRelativeProject(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the CLI")
+	}
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	
+	// The fake CLI answers only when the project path it gets exists
+	dir := t.TempDir()
+	cli := filepath.Join(dir, "curator")
+	script := "#!/bin/sh\nif [ ! -d \"$2\" ]; then echo \"Path $2 does not exist\" >&2; exit 1; fi\necho 'Answer'\n"
+	if err := os.WriteFile(cli, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CURATOR_CONFIG", filepath.Join(dir, "config.yaml"))
+	t.Setenv("CURATOR_CLI_PATH", cli)
+	config.ResetCache()
+	t.Cleanup(config.ResetCache)
+	
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+	
+	var out bytes.Buffer
+	if err := AskPlain(context.Background(), &out, "sub", "how?"); err != nil {
+		t.Fatalf("AskPlain with a relative project: %v", err)
+	}
+	if !strings.Contains(out.String(), "Answer") {
+		t.Errorf("output = %q", out.String())
+	}
+}
@@ -0,0 +1,92 @@
+package curator
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+	"github.com/atotto/clipboard"
+)
+
+// maxDiffChars keeps the prompt within argument-length limits
+const maxDiffChars = 20000
+
+// GatherDiff returns the diff to describe: the working tree against HEAD, or
+// the current branch against base when base is set
+func GatherDiff(projectPath, base string) (string, error) {
+	if strings.HasPrefix(base, "-") {
+		// The ref is passed on to git, which would read it as an option
+		return "", fmt.Errorf("--base %q is not a git ref", base)
+	}
+	
+	args := []string{"-C", projectPath, "diff"}
+	if base != "" {
+		args = append(args, base+"...HEAD")
+	} else {
+		args = append(args, "HEAD")
+	}
+	
+	cmd := exec.Command("git", args...)
+	config.LogCommand(cmd)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git diff failed: %w\n%s", err, string(output))
+	}
+	
+	diff := string(output)
+	if strings.TrimSpace(diff) == "" {
+		return "", fmt.Errorf("no changes found to describe")
+	}
+	return diff, nil
+}
+
+// BuildPRPrompt builds the curator question that asks for a PR description
+func BuildPRPrompt(diff string) string {
+	truncated := ""
+	if len(diff) > maxDiffChars {
+		// Back off to a rune boundary so the prompt stays valid UTF-8
+		cut := maxDiffChars
+		for cut > 0 && !utf8.RuneStart(diff[cut]) {
+			cut--
+		}
+		truncated = fmt.Sprintf("\n(diff truncated: only the first %d of %d bytes are shown, so say the description may be incomplete)", cut, len(diff))
+		diff = diff[:cut]
+	}
+	
+	return "Write a pull request description for the following changes. " +
+		"Respond in Markdown with exactly these sections:\n" +
+		"# <concise PR title>\n" +
+		"## Summary\n(what changed and why, as short bullet points)\n" +
+		"## Testing\n(how the change was or should be verified)\n\n" +
+		"```diff\n" + diff + "\n```" + truncated
+}
+
+// stripCuratorBanner removes the progress banner the CLI prints before its answer
+func stripCuratorBanner(output string) string {
+	lines := strings.Split(output, "\n")
+	for len(lines) > 0 {
+		trimmed := strings.TrimSpace(lines[0])
+		if trimmed == "" || strings.HasPrefix(trimmed, "🔍 Analyzing") {
+			lines = lines[1:]
+			continue
+		}
+		break
+	}
+	return strings.Join(lines, "\n")
+}
+
+// copyToClipboard copies text to the system clipboard
+func copyToClipboard(text string) error {
+	return clipboard.WriteAll(text)
+}
+
+// writeOutputFile saves content to path if one was given
+func writeOutputFile(path, content string) error {
+	if path == "" {
+		return nil
+	}
+	return os.WriteFile(path, []byte(content), 0644)
+}
@@ -0,0 +1,39 @@
+package curator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestBuildPRPromptTruncatesOnRuneBoundary(t *testing.T) {
+	short := "+ café\n"
+	if prompt := BuildPRPrompt(short); !strings.Contains(prompt, short) || strings.Contains(prompt, "truncated") {
+		t.Errorf("short diff changed:\n%s", prompt)
+	}
+	
+	// "é" is two bytes; put one across the cut
+	diff := strings.Repeat("a", maxDiffChars-1) + "é" + strings.Repeat("b", 100)
+	prompt := BuildPRPrompt(diff)
+	if !utf8.ValidString(prompt) {
+		t.Fatal("prompt split a multi-byte character")
+	}
+	if !strings.Contains(prompt, strings.Repeat("a", maxDiffChars-1)+"\n```") {
+		t.Error("diff not cut just before the split character")
+	}
+	if !strings.Contains(prompt, "first 19999 of 20101 bytes") {
+		t.Errorf("no truncation note:\n%s", prompt[len(prompt)-200:])
+	}
+}
+
+func TestGatherDiffRejectsOptionLikeBase(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "x")
+	if _, err := GatherDiff(t.TempDir(), "--output="+out); err == nil {
+		t.Error("expected an error for a base starting with -")
+	}
+	if _, err := os.Stat(out); !os.IsNotExist(err) {
+		t.Errorf("git wrote %s", out)
+	}
+}
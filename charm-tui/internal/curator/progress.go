@@ -0,0 +1,111 @@
+package curator
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/style"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// progressPattern matches progress markers the CLI prints on stderr, such as
+// "scanning 120/900 files": a word, then done/total and an optional unit.
+// Log lines like "[CuratorProcess] ..." don't start with a word and are
+// never taken for progress.
+var progressPattern = regexp.MustCompile(`^([A-Za-z][\w -]*?)\s+(\d+)\s*/\s*(\d+)\b`)
+
+// parseProgress reads a progress marker, reporting false for other lines
+func parseProgress(line string) (done, total int, ok bool) {
+	match := progressPattern.FindStringSubmatch(strings.TrimSpace(style.StripANSI(line)))
+	if match == nil {
+		return 0, 0, false
+	}
+	done, _ = strconv.Atoi(match[2])
+	total, _ = strconv.Atoi(match[3])
+	if total == 0 || done > total {
+		return 0, 0, false
+	}
+	return done, total, true
+}
+
+// progressMsg reports progress the CLI printed for request id. It carries
+// the channel the rest of the request arrives on.
+type progressMsg struct {
+	id    int
+	done  int
+	total int
+	label string
+	next  <-chan tea.Msg
+}
+
+// outputCollector gathers a command's stdout and stderr into one buffer, as
+// CombinedOutput would, and reports the progress markers seen on stderr
+type outputCollector struct {
+	mu     sync.Mutex
+	buf    bytes.Buffer
+	line   []byte // stderr since the last line break
+	report func(done, total int, label string)
+}
+
+func (c *outputCollector) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.buf.Write(p)
+}
+
+// stderr returns the writer for the command's stderr
+func (c *outputCollector) stderr() *progressWriter {
+	return &progressWriter{c}
+}
+
+func (c *outputCollector) Bytes() []byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.buf.Bytes()
+}
+
+// progressWriter is the stderr side of an outputCollector. Lines end at
+// \n or \r, since progress is often redrawn in place.
+type progressWriter struct {
+	c *outputCollector
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	n, err := w.c.Write(p)
+	
+	w.c.line = append(w.c.line, p...)
+	for {
+		i := bytes.IndexAny(w.c.line, "\r\n")
+		if i < 0 {
+			break
+		}
+		line := string(w.c.line[:i])
+		w.c.line = w.c.line[i+1:]
+		if done, total, ok := parseProgress(line); ok {
+			w.c.report(done, total, strings.TrimSpace(style.StripANSI(line)))
+		}
+	}
+	return n, err
+}
+
+// waitForCurator delivers the next message of a running curator command
+func waitForCurator(ch <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return nil
+		}
+		switch msg := msg.(type) {
+		case progressMsg:
+			msg.next = ch
+			return msg
+		case retryMsg:
+			msg.next = ch
+			return msg
+		}
+		return msg
+	}
+}
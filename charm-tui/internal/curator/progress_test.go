@@ -0,0 +1,75 @@
+package curator
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParseProgress(t *testing.T) {
+	tests := []struct {
+		line        string
+		done, total int
+		ok          bool
+	}{
+		{"scanning 120/900 files", 120, 900, true},
+		{"  Analyzing files 3 / 4", 3, 4, true},
+		{"\x1b[36mindexing 10/10\x1b[0m", 10, 10, true},
+		{"[CuratorProcess] Session 1/2", 0, 0, false},
+		{"scanning 12/0 files", 0, 0, false},
+		{"scanning 901/900 files", 0, 0, false},
+		{"See docs/1/2 for details", 0, 0, false},
+		{"# Overview", 0, 0, false},
+	}
+	for _, tt := range tests {
+		done, total, ok := parseProgress(tt.line)
+		if done != tt.done || total != tt.total || ok != tt.ok {
+			t.Errorf("parseProgress(%q) = %d, %d, %v, want %d, %d, %v", tt.line, done, total, ok, tt.done, tt.total, tt.ok)
+		}
+	}
+}
+
+func TestOutputCollector(t *testing.T) {
+	var reported []string
+	c := &outputCollector{report: func(done, total int, label string) {
+		reported = append(reported, fmt.Sprintf("%d/%d %s", done, total, label))
+	}}
+	stderr := c.stderr()
+	
+	fmt.Fprint(c, "# Overview\n")
+	fmt.Fprint(stderr, "scanning 1/3 files\rscanning 2/3")
+	fmt.Fprint(stderr, " files\r")
+	fmt.Fprint(c, "done\n")
+	
+	if got := string(c.Bytes()); got != "# Overview\nscanning 1/3 files\rscanning 2/3 files\rdone\n" {
+		t.Errorf("combined output = %q", got)
+	}
+	want := []string{"1/3 scanning 1/3 files", "2/3 scanning 2/3 files"}
+	if fmt.Sprint(reported) != fmt.Sprint(want) {
+		t.Errorf("reported %q, want %q", reported, want)
+	}
+}
+
+func TestProgressReplacesSpinner(t *testing.T) {
+	m := initialModel("overview", "/tmp/project")
+	m.isLoading = true
+	m.newRequest()
+	if m.progressTotal != 0 {
+		t.Fatal("a new request should start without progress")
+	}
+	
+	next := make(chan tea.Msg)
+	close(next)
+	updated, _ := m.Update(progressMsg{id: m.requestID, done: 30, total: 120, label: "scanning 30/120 files", next: next})
+	m = updated.(model)
+	if view := m.loadingView(); !strings.Contains(view, "scanning 30/120 files") {
+		t.Errorf("loading view should show the progress label, got %q", view)
+	}
+	
+	updated, _ = m.Update(progressMsg{id: m.requestID - 1, done: 1, total: 2, next: next})
+	if updated.(model).progressDone != 30 {
+		t.Error("progress from a cancelled request should be ignored")
+	}
+}
@@ -0,0 +1,97 @@
+package curator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os/exec"
+	"time"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Retry defaults, used when the config sets none
+const (
+	defaultMaxRetries = 2
+	defaultRetryDelay = 2 * time.Second
+	maxRetryDelay     = time.Minute
+)
+
+// exitTempFail is the sysexits.h status for a temporary failure that is
+// worth trying again
+const exitTempFail = 75
+
+// retryMsg reports that request id failed transiently and is being run
+// again as attempt of attempts. It carries the channel the rest of the
+// request arrives on.
+type retryMsg struct {
+	id       int
+	attempt  int
+	attempts int
+	next     <-chan tea.Msg
+}
+
+// transientPatterns are output fragments of failures that usually go away
+// on their own: rate limits, overloaded servers and network errors
+var transientPatterns = [][]byte{
+	[]byte("rate limit"),
+	[]byte("rate_limit"),
+	[]byte("too many requests"),
+	[]byte("overloaded"),
+	[]byte("503 service unavailable"),
+	[]byte("econnreset"),
+	[]byte("econnrefused"),
+	[]byte("etimedout"),
+	[]byte("eai_again"),
+	[]byte("enotfound"),
+	[]byte("socket hang up"),
+	[]byte("network error"),
+}
+
+// isTransient reports whether a failed run of the CLI is worth retrying.
+// Runs stopped by cancellation or --timeout never are.
+func isTransient(ctx context.Context, output []byte, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == exitTempFail {
+		return true
+	}
+	
+	output = bytes.ToLower(output)
+	for _, pattern := range transientPatterns {
+		if bytes.Contains(output, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryDelay returns how long to wait before retry number attempt (1 for
+// the first), doubling from base up to a minute
+func retryDelay(base time.Duration, attempt int) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryDelay)
+}
+
+// retryPolicy returns the configured number of retries and first delay
+func retryPolicy() (int, time.Duration) {
+	return config.GetMaxRetries(defaultMaxRetries), config.GetRetryDelay(defaultRetryDelay)
+}
+
+// sleepContext waits for d, returning false if ctx is cancelled first
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
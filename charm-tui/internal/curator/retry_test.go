@@ -0,0 +1,87 @@
+package curator
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+)
+
+func TestIsTransient(t *testing.T) {
+	failed := errors.New("exit status 1")
+	tests := []struct {
+		name   string
+		output string
+		err    error
+		want   bool
+	}{
+		{"rate limit", "Error: Rate limit exceeded, try again later", failed, true},
+		{"overloaded", `{"type":"overloaded_error"}`, failed, true},
+		{"network", "Error: read ECONNRESET", failed, true},
+		{"ordinary failure", "Error: project not found", failed, false},
+		{"success", "rate limit docs", nil, false},
+	}
+	for _, tt := range tests {
+		if got := isTransient(context.Background(), []byte(tt.output), tt.err); got != tt.want {
+			t.Errorf("%s: isTransient() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if isTransient(ctx, []byte("rate limit"), failed) {
+		t.Error("a cancelled run should not be retried")
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	base := 2 * time.Second
+	for attempt, want := range map[int]time.Duration{1: 2 * time.Second, 2: 4 * time.Second, 3: 8 * time.Second, 10: time.Minute} {
+		if got := retryDelay(base, attempt); got != want {
+			t.Errorf("retryDelay(%v, %d) = %v, want %v", base, attempt, got, want)
+		}
+	}
+}
+
+func TestRunCuratorCommandRetries(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the CLI")
+	}
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	
+	// The fake CLI is rate limited on its first run only
+	dir := t.TempDir()
+	cli := filepath.Join(dir, "curator")
+	script := "#!/bin/sh\nif [ ! -e \"$0.ran\" ]; then touch \"$0.ran\"; echo 'Error: rate limit exceeded' >&2; exit 1; fi\necho '# Answer'\n"
+	if err := os.WriteFile(cli, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CURATOR_CONFIG", filepath.Join(dir, "config.yaml"))
+	t.Setenv("CURATOR_CLI_PATH", cli)
+	t.Setenv("CURATOR_RETRY_DELAY", "1ms")
+	config.ResetCache()
+	t.Cleanup(config.ResetCache)
+	
+	m := initialModel("ask", dir)
+	msg := m.runCuratorCommand(context.Background(), "ask", "why?")()
+	retry, ok := msg.(retryMsg)
+	if !ok {
+		t.Fatalf("expected a retry after the rate limit, got %#v", msg)
+	}
+	if retry.attempt != 2 || retry.attempts != defaultMaxRetries+1 {
+		t.Errorf("retryMsg = attempt %d of %d", retry.attempt, retry.attempts)
+	}
+	
+	msg = waitForCurator(retry.next)()
+	if resp, ok := msg.(responseMsg); !ok || resp.isError || resp.content != "# Answer\n" {
+		t.Errorf("expected the retried answer, got %#v", msg)
+	}
+}
@@ -0,0 +1,177 @@
+package curator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/style"
+)
+
+var (
+	matchStyle = lipgloss.NewStyle().
+			Background(style.Colors().Match).
+			Foreground(style.Colors().Text)
+			
+	currentMatchStyle = lipgloss.NewStyle().
+				Background(style.Colors().Modified).
+				Foreground(style.Colors().Surface).
+				Bold(true)
+)
+
+// highlightMatches marks case-insensitive occurrences of term in rendered
+// content and returns the indices of the lines that contain one. Matching
+// lines lose their original styling so the highlight is not broken up by
+// escape sequences from the renderer.
+func highlightMatches(content, term string, current int) (string, []int) {
+	if term == "" {
+		return content, nil
+	}
+	
+	lines := strings.Split(content, "\n")
+	var matches []int
+	for i, line := range lines {
+		plain := style.StripANSI(line)
+		spans := findMatches(plain, term)
+		if len(spans) == 0 {
+			continue
+		}
+		
+		hl := matchStyle
+		if len(matches) == current {
+			hl = currentMatchStyle
+		}
+		matches = append(matches, i)
+		
+		var b strings.Builder
+		last := 0
+		for _, span := range spans {
+			b.WriteString(plain[last:span[0]])
+			b.WriteString(hl.Render(plain[span[0]:span[1]]))
+			last = span[1]
+		}
+		b.WriteString(plain[last:])
+		lines[i] = b.String()
+	}
+	
+	return strings.Join(lines, "\n"), matches
+}
+
+// findMatches returns the byte ranges of term in s, ignoring case where
+// lowercasing keeps byte offsets intact
+func findMatches(s, term string) [][2]int {
+	haystack, needle := s, term
+	if lower := strings.ToLower(s); len(lower) == len(s) {
+		haystack, needle = lower, strings.ToLower(term)
+	}
+	
+	var spans [][2]int
+	for offset := 0; ; {
+		i := strings.Index(haystack[offset:], needle)
+		if i < 0 {
+			break
+		}
+		start := offset + i
+		spans = append(spans, [2]int{start, start + len(needle)})
+		offset = start + len(needle)
+	}
+	return spans
+}
+
+// startSearch opens the search prompt
+func (m *model) startSearch() tea.Cmd {
+	m.searchMode = true
+	m.textarea.Blur()
+	m.searchInput.SetValue("")
+	return m.searchInput.Focus()
+}
+
+// clearSearch drops the current search and returns focus to the chat input
+func (m *model) clearSearch() {
+	m.searchMode = false
+	m.searchInput.Blur()
+	m.searchTerm = ""
+	m.matches = nil
+	m.matchIndex = 0
+	m.updateViewport()
+	if m.mode == "chat" {
+		m.textarea.Focus()
+	}
+}
+
+// jumpToMatch highlights match i (wrapping around) and scrolls to it
+func (m *model) jumpToMatch(i int) {
+	if len(m.matches) == 0 {
+		return
+	}
+	m.matchIndex = (i%len(m.matches) + len(m.matches)) % len(m.matches)
+	m.updateViewport()
+	m.viewport.SetYOffset(m.matches[m.matchIndex])
+}
+
+// updateSearch handles keys while the search prompt is open or a search is
+// active. It reports whether the key was consumed.
+func (m *model) updateSearch(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if m.searchMode {
+		switch msg.Type {
+		case tea.KeyCtrlC:
+			return tea.Quit, true
+		case tea.KeyEsc:
+			m.clearSearch()
+		case tea.KeyEnter:
+			m.searchMode = false
+			m.searchInput.Blur()
+			m.searchTerm = strings.TrimSpace(m.searchInput.Value())
+			if m.searchTerm == "" {
+				m.clearSearch()
+				return nil, true
+			}
+			m.jumpToMatch(0)
+		default:
+			var cmd tea.Cmd
+			m.searchInput, cmd = m.searchInput.Update(msg)
+			return cmd, true
+		}
+		return nil, true
+	}
+	
+	if m.searchTerm != "" {
+		switch {
+		case key.Matches(msg, keys.NextMatch):
+			m.jumpToMatch(m.matchIndex + 1)
+		case key.Matches(msg, keys.PrevMatch):
+			m.jumpToMatch(m.matchIndex - 1)
+		case key.Matches(msg, keys.Search):
+			return m.startSearch(), true
+		case msg.Type == tea.KeyEsc:
+			m.clearSearch()
+		case msg.Type == tea.KeyRunes || msg.Type == tea.KeyEnter:
+			// Keep keystrokes out of the chat input while browsing matches
+		default:
+			return nil, false
+		}
+		return nil, true
+	}
+	
+	// In chat, '/' only starts a search before anything has been typed
+	if key.Matches(msg, keys.Search) && (m.mode != "chat" || m.textarea.Value() == "") {
+		return m.startSearch(), true
+	}
+	return nil, false
+}
+
+// searchStatus describes the active search for the footer
+func (m model) searchStatus() string {
+	if m.searchMode {
+		return m.searchInput.View()
+	}
+	if m.searchTerm == "" {
+		return ""
+	}
+	if len(m.matches) == 0 {
+		return fmt.Sprintf("🔍 No matches for %q", m.searchTerm)
+	}
+	return fmt.Sprintf("🔍 %q • match %d/%d", m.searchTerm, m.matchIndex+1, len(m.matches))
+}
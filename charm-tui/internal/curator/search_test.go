@@ -0,0 +1,31 @@
+package curator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/style"
+)
+
+func TestHighlightMatchesLines(t *testing.T) {
+	content := "intro\n\x1b[1mAuthService\x1b[0m handles login\nnothing here\nsee authservice.go"
+	
+	out, matches := highlightMatches(content, "authService", 0)
+	if want := []int{1, 3}; !reflect.DeepEqual(matches, want) {
+		t.Errorf("matches = %v, want %v", matches, want)
+	}
+	if got := style.StripANSI(out); got != style.StripANSI(content) {
+		t.Errorf("highlighting changed the text:\n%q\nwant\n%q", got, style.StripANSI(content))
+	}
+}
+
+func TestFindMatches(t *testing.T) {
+	got := findMatches("Foo foo FOO", "foo")
+	want := [][2]int{{0, 3}, {4, 7}, {8, 11}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findMatches = %v, want %v", got, want)
+	}
+	if got := findMatches("bar", "foo"); got != nil {
+		t.Errorf("expected no matches, got %v", got)
+	}
+}
@@ -0,0 +1,74 @@
+package curator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+)
+
+// transcriptMessage is the on-disk form of a chat message
+type transcriptMessage struct {
+	Role    string    `json:"role"`
+	Content string    `json:"content"`
+	Time    time.Time `json:"time"`
+}
+
+// transcriptPath returns where the chat transcript for a project is stored
+func transcriptPath(projectPath string) string {
+	if abs, err := filepath.Abs(projectPath); err == nil {
+		projectPath = abs
+	}
+	sum := sha256.Sum256([]byte(projectPath))
+	return filepath.Join(config.GetCacheDir(), "chat", hex.EncodeToString(sum[:8])+".json")
+}
+
+// saveTranscript writes the conversation for a project, skipping transient
+// messages such as the welcome banner
+func saveTranscript(projectPath string, messages []message) error {
+	var saved []transcriptMessage
+	for _, msg := range messages {
+		if msg.transient {
+			continue
+		}
+		saved = append(saved, transcriptMessage{Role: msg.role, Content: msg.content, Time: msg.time})
+	}
+	
+	data, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return err
+	}
+	
+	path := transcriptPath(projectPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// loadTranscript reads the saved conversation for a project, if any
+func loadTranscript(projectPath string) ([]message, error) {
+	data, err := os.ReadFile(transcriptPath(projectPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	
+	var saved []transcriptMessage
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("failed to parse transcript: %w", err)
+	}
+	
+	messages := make([]message, 0, len(saved))
+	for _, msg := range saved {
+		messages = append(messages, message{role: msg.Role, content: msg.Content, time: msg.Time})
+	}
+	return messages, nil
+}
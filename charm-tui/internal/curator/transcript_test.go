@@ -0,0 +1,55 @@
+package curator
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTranscriptRoundTrip(t *testing.T) {
+	t.Setenv("CURATOR_CACHE_DIR", t.TempDir())
+	
+	project := t.TempDir()
+	messages := []message{
+		{role: "curator", content: "# Welcome", transient: true},
+		{role: "user", content: "How does auth work?", time: time.Date(2026, 3, 14, 9, 26, 0, 0, time.UTC)},
+		{role: "curator", content: "It uses **JWT** tokens.\n\n```go\nfunc Auth() {}\n```"},
+	}
+	
+	if err := saveTranscript(project, messages); err != nil {
+		t.Fatalf("saveTranscript: %v", err)
+	}
+	
+	got, err := loadTranscript(project)
+	if err != nil {
+		t.Fatalf("loadTranscript: %v", err)
+	}
+	
+	want := messages[1:]
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("loadTranscript = %#v, want %#v", got, want)
+	}
+}
+
+func TestTranscriptMissing(t *testing.T) {
+	t.Setenv("CURATOR_CACHE_DIR", t.TempDir())
+	
+	got, err := loadTranscript(t.TempDir())
+	if err != nil {
+		t.Fatalf("loadTranscript: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("expected no messages, got %d", len(got))
+	}
+}
+
+func TestTranscriptPathPerProject(t *testing.T) {
+	t.Setenv("CURATOR_CACHE_DIR", t.TempDir())
+	
+	if transcriptPath("/project/a") == transcriptPath("/project/b") {
+		t.Error("different projects should not share a transcript")
+	}
+	if transcriptPath("/project/a") != transcriptPath("/project/a/") {
+		t.Error("equivalent paths should share a transcript")
+	}
+}
@@ -1,49 +1,84 @@
 package curator
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"os/exec"
+	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/style"
 )
 
 // Styles
 var (
 	titleStyle = lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("212")).
+			Foreground(style.Colors().Title).
 			BorderStyle(lipgloss.DoubleBorder()).
-			BorderForeground(lipgloss.Color("212")).
+			BorderForeground(style.Colors().Title).
 			Padding(1, 3).
 			MarginBottom(1)
 			
 	chatStyle = lipgloss.NewStyle().
 			BorderStyle(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("33")).
+			BorderForeground(style.Colors().Accent).
 			Padding(1, 2)
 			
 	userStyle = lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("120"))
+			Foreground(style.Colors().Added)
 			
 	curatorStyle = lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("212"))
+			Foreground(style.Colors().Title)
+			
+	errorStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(style.Colors().Deleted)
+			
+	errorBodyStyle = lipgloss.NewStyle().
+			BorderStyle(lipgloss.ThickBorder()).
+			BorderLeft(true).
+			BorderForeground(style.Colors().Deleted).
+			Foreground(style.Colors().Deleted)
+			
+	systemStyle = lipgloss.NewStyle().
+			Faint(true).
+			Italic(true)
 			
 	helpStyle = lipgloss.NewStyle().
 			Faint(true).
 			MarginTop(1)
+			
+	projectStyle = lipgloss.NewStyle().
+			Foreground(style.Colors().Meta)
+			
+	timestampStyle = lipgloss.NewStyle().
+			Faint(true).
+			Foreground(style.Colors().Meta)
+			
+	modeStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(style.Colors().Accent)
 )
 
 // Messages
 type responseMsg struct {
+	id      int // Request the response belongs to
 	content string
 	isError bool
 }
@@ -56,25 +91,88 @@ type model struct {
 	viewport    viewport.Model
 	textarea    textarea.Model
 	spinner     spinner.Model
+	progress    progress.Model
 	messages    []message
 	isLoading   bool
 	width       int
 	height      int
-	err         error
 	renderer    *glamour.TermRenderer
+	wrapWidth   int // Width the renderer currently wraps at
+	help        help.Model
+	outputFile  string // Where to save the response (pr mode)
+	status      string // Transient status line, e.g. "Copied to clipboard"
+	newSession  bool   // Start a fresh analysis session (overview mode)
+	requestID   int    // Incremented per request so late responses can be ignored
+	requestCtx  context.Context
+	cancel      context.CancelFunc
+	
+	// Progress the CLI reported for the running request; total is 0 until
+	// it reports any, and the spinner is shown instead
+	progressDone  int
+	progressTotal int
+	progressLabel string
+	retryStatus   string // "retrying (2/3)…" once a transient failure is retried
+	
+	// Command palette (ctrl+p)
+	palette     list.Model
+	paletteOpen bool
+	
+	// Scrollback search
+	searchMode  bool // Typing a search term
+	searchInput textinput.Model
+	searchTerm  string
+	matches     []int // Viewport lines containing the term
+	matchIndex  int
 }
 
 type message struct {
-	role    string // "user" or "curator"
-	content string
+	role      string // "user" or "curator"
+	content   string
+	transient bool      // Not saved to the chat transcript (e.g. the welcome banner)
+	time      time.Time // When the message was added; zero for old transcripts
+}
+
+// addMessage appends a message to the conversation, stamped with the time
+func (m *model) addMessage(msg message) {
+	if msg.time.IsZero() {
+		msg.time = time.Now()
+	}
+	m.messages = append(m.messages, msg)
+}
+
+// turns counts the questions asked in the conversation
+func (m model) turns() int {
+	n := 0
+	for _, msg := range m.messages {
+		if msg.role == "user" {
+			n++
+		}
+	}
+	return n
+}
+
+// formatTimestamp shows the time of a message, with the date if it wasn't
+// sent today
+func formatTimestamp(t, now time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	if y, m, d := t.Date(); y == now.Year() && m == now.Month() && d == now.Day() {
+		return t.Format("15:04")
+	}
+	return t.Format("Jan 2 15:04")
 }
 
 func initialModel(mode, projectPath string) model {
+	// Commands run from the project directory, so make sure the path still
+	// means the same thing from there
+	if abs, err := filepath.Abs(projectPath); err == nil {
+		projectPath = abs
+	}
+	
 	// Create markdown renderer
-	renderer, _ := glamour.NewTermRenderer(
-		glamour.WithAutoStyle(),
-		glamour.WithWordWrap(80),
-	)
+	wrap := style.WordWrap()
+	renderer, _ := glamour.NewTermRenderer(style.GlamourOptions(wrap)...)
 	
 	// Create components
 	vp := viewport.New(80, 20)
@@ -82,13 +180,18 @@ func initialModel(mode, projectPath string) model {
 	ta.Placeholder = "Type your message..."
 	ta.CharLimit = 500
 	ta.SetWidth(80)
-	ta.SetHeight(4)
+	ta.SetHeight(minInputHeight)
 	ta.FocusedStyle.CursorLine = lipgloss.NewStyle()
 	ta.ShowLineNumbers = false
+	// Enter sends the message, so newlines need a modifier
+	ta.KeyMap.InsertNewline = keys.Newline
+	
+	sp := style.NewSpinner(style.Colors().Title)
 	
-	sp := spinner.New()
-	sp.Spinner = spinner.Dot
-	sp.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("212"))
+	si := textinput.New()
+	si.Prompt = "/"
+	si.Placeholder = "search output..."
+	si.CharLimit = 100
 	
 	return model{
 		mode:        mode,
@@ -96,56 +199,138 @@ func initialModel(mode, projectPath string) model {
 		viewport:    vp,
 		textarea:    ta,
 		spinner:     sp,
+		progress:    progress.New(progress.WithDefaultGradient(), progress.WithWidth(40)),
 		messages:    []message{},
 		renderer:    renderer,
+		wrapWidth:   wrap,
+		help:        help.New(),
+		searchInput: si,
+		palette:     newPalette(),
 	}
 }
 
 func (m model) Init() tea.Cmd {
 	return tea.Batch(
-		m.spinner.Tick,
+		style.SpinnerTick(m.spinner),
 		textarea.Blink,
 		m.executeInitialCommand(),
 	)
 }
 
+// executeInitialCommand starts the command for one-shot modes. The Run*TUI
+// functions set isLoading and seed the user's message before the program
+// starts, since changes made here on the value receiver would be lost.
 func (m model) executeInitialCommand() tea.Cmd {
+	args := m.initialCommandArgs()
+	if len(args) == 0 {
+		return nil
+	}
+	ctx := m.requestCtx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return m.runCuratorCommand(ctx, args[0], args[1:]...)
+}
+
+// initialCommandArgs returns the curator CLI arguments for one-shot modes,
+// or nil when the mode waits for user input
+func (m model) initialCommandArgs() []string {
 	switch m.mode {
 	case "overview":
-		m.isLoading = true
-		return m.runCuratorCommand("overview", m.projectPath)
+		args := []string{"overview", m.projectPath}
+		if m.newSession {
+			args = append(args, "--new-session")
+		}
+		return args
 	case "ask":
 		if m.question != "" {
-			m.isLoading = true
-			m.messages = append(m.messages, message{
-				role:    "user",
-				content: m.question,
-			})
-			return m.runCuratorCommand("ask", m.projectPath, m.question)
+			return []string{"ask", m.projectPath, m.question}
 		}
+	case "feature":
+		return []string{"feature", m.projectPath, m.question}
+	case "change":
+		return []string{"change", m.projectPath, m.question}
+	case "memory":
+		return []string{"memory", m.projectPath}
+	case "pr":
+		return []string{"ask", m.projectPath, m.question}
 	}
 	return nil
 }
 
-func (m model) runCuratorCommand(command string, args ...string) tea.Cmd {
+// newRequest starts a cancellable request, abandoning any previous one
+func (m *model) newRequest() context.Context {
+	if m.cancel != nil {
+		m.cancel()
+	}
+	m.requestID++
+	m.requestCtx, m.cancel = context.WithCancel(context.Background())
+	m.progressDone, m.progressTotal, m.progressLabel = 0, 0, ""
+	m.retryStatus = ""
+	return m.requestCtx
+}
+
+// cancelRequest kills the in-flight curator command
+func (m *model) cancelRequest() {
+	if m.cancel != nil {
+		m.cancel()
+		m.cancel = nil
+	}
+	// Any response still on its way belongs to the cancelled request
+	m.requestID++
+	m.isLoading = false
+	m.addMessage(message{
+		role:    "system",
+		content: "(cancelled)",
+	})
+	m.updateViewport()
+}
+
+// runCuratorCommand runs the CLI in the background, delivering a
+// progressMsg for each progress marker it prints and then a responseMsg.
+// Transient failures are retried with exponential backoff, announced by a
+// retryMsg.
+func (m model) runCuratorCommand(ctx context.Context, command string, args ...string) tea.Cmd {
+	id := m.requestID
 	return func() tea.Msg {
-		cmdArgs := []string{"run", "../../src/tools/curator-cli/cli.ts", command}
-		cmdArgs = append(cmdArgs, args...)
-		
-		cmd := exec.Command("bun", cmdArgs...)
-		output, err := cmd.CombinedOutput()
-		
-		if err != nil {
-			return responseMsg{
-				content: fmt.Sprintf("Error: %v\n%s", err, string(output)),
-				isError: true,
+		ch := make(chan tea.Msg)
+		go func() {
+			defer close(ch)
+			retries, delay := retryPolicy()
+			
+			for attempt := 1; ; attempt++ {
+				output := &outputCollector{report: func(done, total int, label string) {
+					ch <- progressMsg{id: id, done: done, total: total, label: label}
+				}}
+				cmd := CommandContext(ctx, m.projectPath, append([]string{command}, args...)...)
+				cmd.Stdout = output
+				cmd.Stderr = output.stderr()
+				
+				err := cmd.Run()
+				if err == nil {
+					ch <- responseMsg{
+						id:      id,
+						content: string(output.Bytes()),
+						isError: false,
+					}
+					return
+				}
+				
+				if attempt <= retries && isTransient(ctx, output.Bytes(), err) {
+					ch <- retryMsg{id: id, attempt: attempt + 1, attempts: retries + 1}
+					if sleepContext(ctx, retryDelay(delay, attempt)) {
+						continue
+					}
+				}
+				ch <- responseMsg{
+					id:      id,
+					content: fmt.Sprintf("%s\n\n_Command failed: %v_", strings.TrimSpace(string(output.Bytes())), err),
+					isError: true,
+				}
+				return
 			}
-		}
-		
-		return responseMsg{
-			content: string(output),
-			isError: false,
-		}
+		}()
+		return waitForCurator(ch)()
 	}
 }
 
@@ -155,58 +340,162 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		
-		// Update viewport size
-		headerHeight := 8
-		footerHeight := 8
-		m.viewport.Width = msg.Width - 4
-		m.viewport.Height = msg.Height - headerHeight - footerHeight
-		
-		// Update textarea width
+		// Update textarea width; the draft may now wrap differently
 		m.textarea.SetWidth(msg.Width - 4)
+		m.progress.Width = min(max(msg.Width-12, 10), 60)
+		m.textarea.SetHeight(inputHeight(m.textarea.Value(), m.textarea.Width()))
+		m.help.Width = msg.Width
+		m.layout()
+		
+		// Re-wrap markdown for the new width
+		if wrap := style.MarkdownWidth(m.viewport.Width); wrap != m.wrapWidth {
+			if renderer, err := glamour.NewTermRenderer(style.GlamourOptions(wrap)...); err == nil {
+				m.renderer = renderer
+				m.wrapWidth = wrap
+				m.updateViewport()
+			}
+		}
 		
 		return m, nil
 		
 	case tea.KeyMsg:
+		if m.paletteOpen {
+			return m, m.updatePalette(msg)
+		}
+		if key.Matches(msg, keys.Palette) && !m.searchMode {
+			return m, m.openPalette()
+		}
+		if cmd, handled := m.updateSearch(msg); handled {
+			return m, cmd
+		}
+		
 		switch msg.Type {
 		case tea.KeyCtrlC:
 			return m, tea.Quit
+		case tea.KeyCtrlX:
+			if m.isLoading {
+				m.cancelRequest()
+				return m, nil
+			}
+		case tea.KeyCtrlS:
+			if path, err := exportMarkdown(m.projectPath, m.messages, time.Now()); err != nil {
+				m.status = fmt.Sprintf("Export failed: %v", err)
+			} else {
+				m.status = "💾 Exported to " + path
+			}
+			return m, nil
 		case tea.KeyEsc:
-			if m.mode == "chat" && !m.isLoading {
+			if m.isLoading {
+				m.cancelRequest()
+				return m, nil
+			}
+			if m.mode == "chat" {
 				return m, tea.Quit
 			}
+		case tea.KeyRunes:
+			if m.mode != "chat" && key.Matches(msg, keys.Help) {
+				m.help.ShowAll = !m.help.ShowAll
+				return m, nil
+			}
+			if m.mode == "pr" && !m.isLoading && key.Matches(msg, keys.Copy) {
+				if len(m.messages) == 0 {
+					return m, nil
+				}
+				if err := copyToClipboard(m.messages[len(m.messages)-1].content); err != nil {
+					m.status = fmt.Sprintf("Copy failed: %v", err)
+				} else {
+					m.status = "✓ Copied to clipboard"
+				}
+				return m, nil
+			}
 		case tea.KeyEnter:
-			if m.mode == "chat" && !m.isLoading && m.textarea.Value() != "" {
+			if msg.Alt {
+				// Alt+Enter (what most terminals send for Shift+Enter) is
+				// handled by the textarea as a newline
+				break
+			}
+			if m.mode == "chat" && !m.isLoading {
+				userMsg := strings.TrimSpace(m.textarea.Value())
+				if userMsg == "" {
+					m.textarea.Reset()
+					m.fitInput()
+					return m, nil
+				}
+				
 				// Send message
-				userMsg := m.textarea.Value()
-				m.messages = append(m.messages, message{
+				m.addMessage(message{
 					role:    "user",
 					content: userMsg,
 				})
 				m.textarea.Reset()
+				m.fitInput()
 				m.isLoading = true
-				return m, m.runCuratorCommand("ask", m.projectPath, userMsg)
+				m.saveTranscript()
+				ctx := m.newRequest()
+				return m, m.runCuratorCommand(ctx, "ask", m.projectPath, userMsg)
 			}
 		}
 		
 	case responseMsg:
+		if msg.id != m.requestID {
+			// Response to a cancelled request
+			return m, nil
+		}
 		m.isLoading = false
+		m.cancel = nil
 		
 		if msg.isError {
-			m.err = fmt.Errorf(msg.content)
+			// Keep the error in the conversation so the user can carry on
+			m.addMessage(message{
+				role:      "error",
+				content:   msg.content,
+				transient: true,
+			})
+			m.updateViewport()
 			return m, nil
 		}
 		
+		content := msg.content
+		if m.mode == "pr" {
+			content = stripCuratorBanner(content)
+			if err := writeOutputFile(m.outputFile, content); err != nil {
+				m.status = fmt.Sprintf("Failed to write %s: %v", m.outputFile, err)
+			} else if m.outputFile != "" {
+				m.status = "✓ Saved to " + m.outputFile
+			}
+		}
+		
 		// Add curator response
-		m.messages = append(m.messages, message{
+		m.addMessage(message{
 			role:    "curator",
-			content: msg.content,
+			content: content,
 		})
+		m.saveTranscript()
 		
 		// Update viewport
 		m.updateViewport()
 		
 		return m, nil
 		
+	case progressMsg:
+		// Keep reading even for a cancelled request so the command can finish
+		if msg.id == m.requestID && m.isLoading {
+			m.progressDone, m.progressTotal, m.progressLabel = msg.done, msg.total, msg.label
+		}
+		return m, waitForCurator(msg.next)
+		
+	case retryMsg:
+		if msg.id == m.requestID && m.isLoading {
+			m.retryStatus = fmt.Sprintf("retrying (%d/%d)…", msg.attempt, msg.attempts)
+			m.progressDone, m.progressTotal, m.progressLabel = 0, 0, ""
+		}
+		return m, waitForCurator(msg.next)
+		
+	case list.FilterMatchesMsg:
+		var cmd tea.Cmd
+		m.palette, cmd = m.palette.Update(msg)
+		return m, cmd
+		
 	case spinner.TickMsg:
 		if m.isLoading {
 			var cmd tea.Cmd
@@ -218,10 +507,11 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Update components
 	var cmds []tea.Cmd
 	
-	if m.mode == "chat" && !m.isLoading {
+	if m.mode == "chat" && !m.isLoading && m.searchTerm == "" && !m.searchMode {
 		var cmd tea.Cmd
 		m.textarea, cmd = m.textarea.Update(msg)
 		cmds = append(cmds, cmd)
+		m.fitInput()
 	}
 	
 	var cmd tea.Cmd
@@ -231,16 +521,100 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// loadingView shows the progress the CLI reported, or the spinner while it
+// hasn't reported any
+func (m model) loadingView() string {
+	if m.progressTotal == 0 {
+		label := "Thinking..."
+		if m.retryStatus != "" {
+			label = "Thinking... " + m.retryStatus
+		}
+		return style.Loading(m.spinner, label)
+	}
+	percent := float64(m.progressDone) / float64(m.progressTotal)
+	return m.progress.ViewAs(percent) + "\n" + systemStyle.Render(m.progressLabel)
+}
+
+// Chat input height bounds: the textarea grows with the draft up to
+// maxInputHeight lines, then scrolls
+const (
+	minInputHeight = 4
+	maxInputHeight = 10
+)
+
+// inputHeight returns the textarea height for a draft, counting lines that
+// wrap at width as the rows they take up
+func inputHeight(value string, width int) int {
+	rows := 0
+	for _, line := range strings.Split(value, "\n") {
+		rows++
+		if w := runewidth.StringWidth(line); width > 0 && w > width {
+			rows += (w - 1) / width
+		}
+	}
+	return min(max(rows, minInputHeight), maxInputHeight)
+}
+
+// layout gives the viewport the height the title, input and help leave.
+// The footer grows with the chat input.
+func (m *model) layout() {
+	if m.width == 0 {
+		return
+	}
+	headerHeight := 8
+	footerHeight := 9 + m.textarea.Height() - minInputHeight
+	
+	atBottom := m.viewport.AtBottom()
+	m.viewport.Width = m.width - 4
+	m.viewport.Height = m.height - headerHeight - footerHeight
+	if atBottom {
+		m.viewport.GotoBottom()
+	}
+}
+
+// fitInput resizes the chat input to its draft, rebalancing the layout
+func (m *model) fitInput() {
+	if h := inputHeight(m.textarea.Value(), m.textarea.Width()); h != m.textarea.Height() {
+		m.textarea.SetHeight(h)
+		m.layout()
+	}
+}
+
+// saveTranscript persists the chat conversation so it survives restarts
+func (m *model) saveTranscript() {
+	if m.mode != "chat" {
+		return
+	}
+	if err := saveTranscript(m.projectPath, m.messages); err != nil {
+		m.status = fmt.Sprintf("Failed to save transcript: %v", err)
+	}
+}
+
 func (m *model) updateViewport() {
 	var content strings.Builder
+	now := time.Now()
 	
 	for _, msg := range m.messages {
+		stamp := ""
+		if ts := formatTimestamp(msg.time, now); ts != "" {
+			stamp = " " + timestampStyle.Render(ts)
+		}
+		
 		switch msg.role {
 		case "user":
-			content.WriteString(userStyle.Render("🧑 You:") + "\n")
+			content.WriteString(userStyle.Render("🧑 You:") + stamp + "\n")
 			content.WriteString(msg.content + "\n\n")
+		case "error":
+			content.WriteString(errorStyle.Render("❌ Error:") + stamp + "\n")
+			rendered, err := m.renderer.Render(msg.content)
+			if err != nil {
+				rendered = msg.content + "\n"
+			}
+			content.WriteString(errorBodyStyle.Render(strings.TrimRight(rendered, "\n")) + "\n\n")
+		case "system":
+			content.WriteString(systemStyle.Render(msg.content) + "\n\n")
 		case "curator":
-			content.WriteString(curatorStyle.Render("🤖 Curator:") + "\n")
+			content.WriteString(curatorStyle.Render("🤖 Curator:") + stamp + "\n")
 			// Render markdown
 			rendered, err := m.renderer.Render(msg.content)
 			if err != nil {
@@ -251,24 +625,30 @@ func (m *model) updateViewport() {
 		}
 	}
 	
+	if m.searchTerm != "" {
+		// Keep the scroll position so new output doesn't yank the view
+		// away from the match being read
+		highlighted, matches := highlightMatches(content.String(), m.searchTerm, m.matchIndex)
+		m.matches = matches
+		m.viewport.SetContent(highlighted)
+		return
+	}
+	
 	m.viewport.SetContent(content.String())
 	m.viewport.GotoBottom()
 }
 
 func (m model) View() string {
-	if m.err != nil {
-		return fmt.Sprintf("Error: %v\n\nPress Ctrl+C to quit.", m.err)
-	}
-	
 	// Title
 	title := titleStyle.Render("🤖 Curator - AI Codebase Assistant")
 	
 	// Main content area
 	var mainContent string
-	if m.isLoading {
+	if m.paletteOpen {
+		mainContent = chatStyle.Render(m.paletteView())
+	} else if m.isLoading {
 		mainContent = chatStyle.Render(
-			m.viewport.View() + "\n\n" +
-				m.spinner.View() + " Thinking...",
+			m.viewport.View() + "\n\n" + m.loadingView(),
 		)
 	} else {
 		mainContent = chatStyle.Render(m.viewport.View())
@@ -281,12 +661,14 @@ func (m model) View() string {
 	}
 	
 	// Help
-	var help string
-	switch m.mode {
-	case "chat":
-		help = helpStyle.Render("Enter: send • Esc: quit • ↑/↓: scroll")
-	default:
-		help = helpStyle.Render("↑/↓: scroll • Ctrl+C: quit")
+	help := helpStyle.Render(m.help.View(m.helpKeys()))
+	help = m.projectLine() + help
+	
+	if m.status != "" {
+		help = helpStyle.Render(m.status) + "\n" + help
+	}
+	if search := m.searchStatus(); search != "" {
+		help = helpStyle.Render(search) + "\n" + help
 	}
 	
 	// Compose layout
@@ -299,13 +681,41 @@ func (m model) View() string {
 	)
 }
 
+// projectLabel abbreviates a project path to width columns for the footer,
+// writing the home directory as ~
+func projectLabel(path, home string, width int) string {
+	if home != "" {
+		if rel, err := filepath.Rel(home, path); err == nil && rel != ".." && !strings.HasPrefix(rel, "../") {
+			path = filepath.Join("~", rel)
+		}
+	}
+	return style.TruncatePath(path, width)
+}
+
+// projectLine shows which project and mode the session is bound to, so
+// several curator sessions can be told apart, and how many turns it has had
+func (m model) projectLine() string {
+	mode := modeStyle.Render(m.mode)
+	if name := Model(); name != "" {
+		mode += projectStyle.Render(" • model " + name)
+	}
+	if turns := m.turns(); turns == 1 {
+		mode += projectStyle.Render(" • 1 turn")
+	} else if turns > 1 {
+		mode += projectStyle.Render(fmt.Sprintf(" • %d turns", turns))
+	}
+	home, _ := os.UserHomeDir()
+	width := max(m.width-lipgloss.Width(mode)-8, 20)
+	return projectStyle.Render("📁 "+projectLabel(m.projectPath, home, width)+" • ") + mode
+}
+
 // Public functions for different modes
 
 func RunTUI(projectPath string) error {
 	if projectPath == "" {
 		projectPath, _ = os.Getwd()
 	}
-	return RunChatTUI(projectPath)
+	return RunChatTUI(projectPath, false)
 }
 
 func RunOverviewTUI(projectPath string, newSession bool) error {
@@ -314,8 +724,10 @@ func RunOverviewTUI(projectPath string, newSession bool) error {
 	}
 	
 	m := initialModel("overview", projectPath)
-	p := tea.NewProgram(m, tea.WithAltScreen())
-	_, err := p.Run()
+	m.newSession = newSession
+	m.isLoading = true
+	m.newRequest()
+	_, err := style.Run(m)
 	return err
 }
 
@@ -326,12 +738,21 @@ func RunAskTUI(projectPath, question string) error {
 	
 	m := initialModel("ask", projectPath)
 	m.question = question
-	p := tea.NewProgram(m, tea.WithAltScreen())
-	_, err := p.Run()
+	if question != "" {
+		m.isLoading = true
+		m.newRequest()
+		m.addMessage(message{
+			role:    "user",
+			content: question,
+		})
+	}
+	_, err := style.Run(m)
 	return err
 }
 
-func RunChatTUI(projectPath string) error {
+// RunChatTUI starts an interactive chat. Unless fresh is set, the previous
+// conversation for the project is restored first.
+func RunChatTUI(projectPath string, fresh bool) error {
 	if projectPath == "" {
 		projectPath, _ = os.Getwd()
 	}
@@ -339,15 +760,23 @@ func RunChatTUI(projectPath string) error {
 	m := initialModel("chat", projectPath)
 	m.textarea.Focus()
 	
+	if !fresh {
+		history, err := loadTranscript(m.projectPath)
+		if err != nil {
+			m.status = fmt.Sprintf("Failed to load transcript: %v", err)
+		}
+		m.messages = append(m.messages, history...)
+	}
+	
 	// Add welcome message
-	m.messages = append(m.messages, message{
+	m.addMessage(message{
+		transient: true,
 		role:    "curator",
 		content: "# Welcome to Curator Chat! 🤖\n\nI'm here to help you understand your codebase. Ask me anything about:\n\n- Code structure and architecture\n- Implementation details\n- How to add new features\n- Impact of changes\n- Best practices in your project\n\nWhat would you like to know?",
 	})
 	m.updateViewport()
 	
-	p := tea.NewProgram(m, tea.WithAltScreen())
-	_, err := p.Run()
+	_, err := style.Run(m)
 	return err
 }
 
@@ -359,15 +788,15 @@ func RunFeatureTUI(projectPath, description string) error {
 	m := initialModel("feature", projectPath)
 	m.question = description
 	m.isLoading = true
+	m.newRequest()
 	
 	// Add user's feature request
-	m.messages = append(m.messages, message{
+	m.addMessage(message{
 		role:    "user",
 		content: fmt.Sprintf("Feature Request: %s", description),
 	})
 	
-	p := tea.NewProgram(m, tea.WithAltScreen())
-	_, err := p.Run()
+	_, err := style.Run(m)
 	return err
 }
 
@@ -379,15 +808,15 @@ func RunChangeTUI(projectPath, description string) error {
 	m := initialModel("change", projectPath)
 	m.question = description
 	m.isLoading = true
+	m.newRequest()
 	
 	// Add user's change request
-	m.messages = append(m.messages, message{
+	m.addMessage(message{
 		role:    "user",
 		content: fmt.Sprintf("Change Analysis: %s", description),
 	})
 	
-	p := tea.NewProgram(m, tea.WithAltScreen())
-	_, err := p.Run()
+	_, err := style.Run(m)
 	return err
 }
 
@@ -396,10 +825,35 @@ func RunMemoryTUI(projectPath string) error {
 		projectPath, _ = os.Getwd()
 	}
 	
-	m := initialModel("memory", projectPath)
+	_, err := style.Run(newMemoryModel(projectPath))
+	return err
+}
+
+func RunPRDescriptionTUI(projectPath, base, outputFile string) error {
+	if projectPath == "" {
+		projectPath, _ = os.Getwd()
+	}
+	
+	diff, err := GatherDiff(projectPath, base)
+	if err != nil {
+		return err
+	}
+	
+	m := initialModel("pr", projectPath)
+	m.question = BuildPRPrompt(diff)
+	m.outputFile = outputFile
 	m.isLoading = true
+	m.newRequest()
+	
+	request := "PR description for uncommitted changes"
+	if base != "" {
+		request = fmt.Sprintf("PR description for changes since %s", base)
+	}
+	m.addMessage(message{
+		role:    "user",
+		content: request,
+	})
 	
-	p := tea.NewProgram(m, tea.WithAltScreen())
-	_, err := p.Run()
+	_, err = style.Run(m)
 	return err
 }
\ No newline at end of file
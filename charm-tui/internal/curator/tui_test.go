@@ -0,0 +1,124 @@
+package curator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestOverviewArgsNewSession(t *testing.T) {
+	m := initialModel("overview", "/tmp/project")
+	
+	want := []string{"overview", "/tmp/project"}
+	if got := m.initialCommandArgs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("initialCommandArgs() = %v, want %v", got, want)
+	}
+	
+	m.newSession = true
+	want = []string{"overview", "/tmp/project", "--new-session"}
+	if got := m.initialCommandArgs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("initialCommandArgs() with newSession = %v, want %v", got, want)
+	}
+}
+
+func TestChatHasNoInitialCommand(t *testing.T) {
+	m := initialModel("chat", "/tmp/project")
+	m.newSession = true
+	if got := m.initialCommandArgs(); got != nil {
+		t.Errorf("chat mode should not run a command on start, got %v", got)
+	}
+}
+
+func TestInputHeight(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int
+	}{
+		{"", minInputHeight},
+		{"one\ntwo\nthree\nfour\nfive", 5},
+		{strings.Repeat("x", 45), minInputHeight},
+		{"a\nb\nc\n" + strings.Repeat("x", 45), 6},
+		{strings.Repeat("line\n", 30), maxInputHeight},
+	}
+	for _, tt := range tests {
+		if got := inputHeight(tt.value, 20); got != tt.want {
+			t.Errorf("inputHeight(%q) = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestChatInputGrowsWithDraft(t *testing.T) {
+	m := initialModel("chat", "/tmp/project")
+	m.textarea.Focus()
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	m = updated.(model)
+	viewportHeight := m.viewport.Height
+	
+	m.textarea.SetValue(strings.Repeat("line\n", 7) + "line")
+	m.fitInput()
+	if m.textarea.Height() != 8 {
+		t.Errorf("textarea height = %d, want 8", m.textarea.Height())
+	}
+	if want := viewportHeight - (8 - minInputHeight); m.viewport.Height != want {
+		t.Errorf("viewport height = %d, want %d", m.viewport.Height, want)
+	}
+	
+	m.textarea.Reset()
+	m.fitInput()
+	if m.textarea.Height() != minInputHeight || m.viewport.Height != viewportHeight {
+		t.Errorf("empty draft should restore the layout: textarea %d, viewport %d", m.textarea.Height(), m.viewport.Height)
+	}
+}
+
+func TestProjectLabel(t *testing.T) {
+	tests := []struct {
+		path, home string
+		width      int
+		want       string
+	}{
+		{"/home/ada/code/app", "/home/ada", 40, "~/code/app"},
+		{"/home/ada", "/home/ada", 40, "~"},
+		{"/home/adam/app", "/home/ada", 40, "/home/adam/app"},
+		{"/srv/projects/app", "", 40, "/srv/projects/app"},
+		{"/home/ada/work/clients/acme/platform/api", "/home/ada", 16, "~/.../api"},
+	}
+	for _, tt := range tests {
+		if got := projectLabel(tt.path, tt.home, tt.width); got != tt.want {
+			t.Errorf("projectLabel(%q, %q, %d) = %q, want %q", tt.path, tt.home, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	now := time.Date(2026, 3, 14, 18, 0, 0, 0, time.UTC)
+	tests := []struct {
+		t    time.Time
+		want string
+	}{
+		{time.Date(2026, 3, 14, 9, 5, 0, 0, time.UTC), "09:05"},
+		{time.Date(2026, 3, 13, 23, 59, 0, 0, time.UTC), "Mar 13 23:59"},
+		{time.Time{}, ""},
+	}
+	for _, tt := range tests {
+		if got := formatTimestamp(tt.t, now); got != tt.want {
+			t.Errorf("formatTimestamp(%v) = %q, want %q", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestAddMessageStampsTime(t *testing.T) {
+	m := initialModel("chat", "/tmp/project")
+	m.addMessage(message{role: "user", content: "first"})
+	m.addMessage(message{role: "curator", content: "answer"})
+	m.addMessage(message{role: "user", content: "second"})
+	
+	if m.messages[0].time.IsZero() {
+		t.Error("addMessage should stamp the message with the current time")
+	}
+	if got := m.turns(); got != 2 {
+		t.Errorf("turns() = %d, want 2", got)
+	}
+}
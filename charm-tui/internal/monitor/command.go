@@ -0,0 +1,25 @@
+package monitor
+
+import (
+	"context"
+	"os/exec"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+)
+
+// Command builds the command that invokes the TypeScript monitor CLI. In
+// development the .ts entry point is run through the configured executor; an
+// installed monitor binary, or one set via MONITOR_CLI_PATH, is invoked
+// directly. It runs in the project directory set with SetProjectDir.
+func Command(args ...string) *exec.Cmd {
+	return CommandContext(context.Background(), args...)
+}
+
+// CommandContext is like Command but kills the CLI when ctx is cancelled
+func CommandContext(ctx context.Context, args ...string) *exec.Cmd {
+	line := config.CLICommandLine(config.GetMonitorPath(), args...)
+	cmd := exec.CommandContext(ctx, line[0], line[1:]...)
+	cmd.Dir = projectDir
+	config.LogCommand(cmd)
+	return cmd
+}
@@ -0,0 +1,64 @@
+package monitor
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"time"
+)
+
+// eventLog appends structured file events as JSON lines
+type eventLog struct {
+	w      *bufio.Writer
+	closer io.Closer
+}
+
+// logEntry is the JSON form of a logged event
+type logEntry struct {
+	Path      string    `json:"path"`
+	Kind      string    `json:"kind"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// openEventLog opens path for appending, creating it if needed
+func openEventLog(path string) (*eventLog, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &eventLog{w: bufio.NewWriter(f), closer: f}, nil
+}
+
+// newEventLog writes events to w
+func newEventLog(w io.Writer) *eventLog {
+	return &eventLog{w: bufio.NewWriter(w)}
+}
+
+// write buffers one event as a line of JSON
+func (l *eventLog) write(event fileEvent) error {
+	data, err := json.Marshal(logEntry{Path: event.path, Kind: event.kind, Timestamp: event.timestamp})
+	if err != nil {
+		return err
+	}
+	if _, err := l.w.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return nil
+}
+
+// flush writes buffered events through to the file
+func (l *eventLog) flush() error {
+	return l.w.Flush()
+}
+
+// close flushes and closes the log
+func (l *eventLog) close() error {
+	err := l.w.Flush()
+	if l.closer != nil {
+		if closeErr := l.closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
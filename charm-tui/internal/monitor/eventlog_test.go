@@ -0,0 +1,58 @@
+package monitor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEventLogWritesJSONLines(t *testing.T) {
+	var b strings.Builder
+	log := newEventLog(&b)
+	stamp := time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC)
+	
+	log.write(fileEvent{path: "src/a.ts", kind: "added", timestamp: stamp})
+	log.write(fileEvent{path: "src/b.ts", kind: "deleted", timestamp: stamp})
+	if b.Len() != 0 {
+		t.Errorf("events written before flush: %q", b.String())
+	}
+	if err := log.close(); err != nil {
+		t.Fatal(err)
+	}
+	
+	want := `{"path":"src/a.ts","kind":"added","timestamp":"2024-03-05T14:30:00Z"}` + "\n" +
+		`{"path":"src/b.ts","kind":"deleted","timestamp":"2024-03-05T14:30:00Z"}` + "\n"
+	if b.String() != want {
+		t.Errorf("log =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestOpenEventLogAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	for i := 0; i < 2; i++ {
+		log, err := openEventLog(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		log.write(fileEvent{path: "src/a.ts", kind: "modified"})
+		if err := log.close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 2 {
+		t.Errorf("log has %d lines, want 2:\n%s", lines, data)
+	}
+}
+
+func TestOpenEventLogError(t *testing.T) {
+	if _, err := openEventLog(filepath.Join(t.TempDir(), "missing", "events.jsonl")); err == nil {
+		t.Error("expected an error for a missing directory")
+	}
+}
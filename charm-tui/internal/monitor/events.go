@@ -0,0 +1,82 @@
+package monitor
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+)
+
+// maxEvents is how many file events the dashboard keeps
+const maxEvents = 100
+
+// fileEvent is one line of `monitor watch --json` output. Lines that aren't
+// JSON, such as startup messages, become events of kind "log", and the
+// watcher exiting is an "error" event.
+type fileEvent struct {
+	path      string
+	kind      string // "added", "modified", "deleted", "log" or "error"
+	timestamp time.Time
+}
+
+// changesFiles reports whether the event is a file being added, modified or
+// deleted, which leaves the index out of date
+func (e fileEvent) changesFiles() bool {
+	switch e.kind {
+	case "added", "modified", "deleted":
+		return true
+	}
+	return false
+}
+
+// watchLine mirrors the JSON objects emitted by the TypeScript monitor
+type watchLine struct {
+	Type         string    `json:"type"` // "change" or "status"
+	Path         string    `json:"path"`
+	Kind         string    `json:"kind"`
+	TotalFiles   int       `json:"totalFiles"`
+	IndexedFiles int       `json:"indexedFiles"`
+	LastIndexed  time.Time `json:"lastIndexed"`
+	IndexExists  *bool     `json:"indexExists"`
+	IndexSize    int64     `json:"indexSize"`
+	Consistent   *bool     `json:"consistent"`
+	Issues       []string  `json:"issues"`
+	Watching     *bool     `json:"watching"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// parseWatchLine turns a line of monitor output into either a file event or
+// a status update
+func parseWatchLine(line string, now time.Time) (*fileEvent, *statusMsg) {
+	var wl watchLine
+	if !strings.HasPrefix(line, "{") {
+		return &fileEvent{path: line, kind: "log", timestamp: now}, nil
+	}
+	if err := json.Unmarshal([]byte(line), &wl); err != nil {
+		config.LogParseError("watch", err)
+		return &fileEvent{path: line, kind: "log", timestamp: now}, nil
+	}
+	if wl.Timestamp.IsZero() {
+		wl.Timestamp = now
+	}
+	
+	switch wl.Type {
+	case "status":
+		status := wl.toStatus()
+		return nil, &status
+	case "change":
+		return &fileEvent{path: wl.Path, kind: wl.Kind, timestamp: wl.Timestamp}, nil
+	default:
+		return &fileEvent{path: line, kind: "log", timestamp: now}, nil
+	}
+}
+
+// appendEvent adds an event to the rolling buffer, dropping the oldest
+func appendEvent(events []fileEvent, event fileEvent) []fileEvent {
+	events = append(events, event)
+	if len(events) > maxEvents {
+		events = events[len(events)-maxEvents:]
+	}
+	return events
+}
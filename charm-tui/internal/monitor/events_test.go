@@ -0,0 +1,46 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWatchLine(t *testing.T) {
+	now := time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC)
+	
+	event, status := parseWatchLine(`{"type":"change","path":"src/a.ts","kind":"modified","timestamp":"2024-03-05T14:29:58Z"}`, now)
+	if status != nil || event == nil {
+		t.Fatalf("change line parsed as %v, %v", event, status)
+	}
+	if event.path != "src/a.ts" || event.kind != "modified" || !event.timestamp.Equal(now.Add(-2*time.Second)) {
+		t.Errorf("event = %+v", *event)
+	}
+	
+	event, status = parseWatchLine(`{"type":"status","totalFiles":120,"indexedFiles":120,"watching":true}`, now)
+	if event != nil || status == nil {
+		t.Fatalf("status line parsed as %v, %v", event, status)
+	}
+	if status.filesIndexed != 120 || !status.healthy || !status.lastUpdate.Equal(now) {
+		t.Errorf("status = %+v", *status)
+	}
+	
+	for _, line := range []string{"👀 Monitoring file changes...", "{not json", `{"type":"unknown"}`} {
+		event, status = parseWatchLine(line, now)
+		if status != nil || event == nil || event.kind != "log" || event.path != line {
+			t.Errorf("parseWatchLine(%q) = %v, %v", line, event, status)
+		}
+	}
+}
+
+func TestAppendEventKeepsLastEvents(t *testing.T) {
+	var events []fileEvent
+	for i := 0; i < maxEvents+5; i++ {
+		events = appendEvent(events, fileEvent{path: string(rune('a' + i%26))})
+	}
+	if len(events) != maxEvents {
+		t.Fatalf("len = %d, want %d", len(events), maxEvents)
+	}
+	if events[0].path != string(rune('a'+5)) {
+		t.Errorf("oldest event = %q", events[0].path)
+	}
+}
@@ -0,0 +1,82 @@
+package monitor
+
+import (
+	"bufio"
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+)
+
+// indexQuietPeriod is how long file activity must settle before auto-indexing
+const indexQuietPeriod = 5 * time.Second
+
+// indexEvent is a line of index progress, or the final result when done is set
+type indexEvent struct {
+	line string
+	done bool
+	err  error
+}
+
+type indexProgressMsg struct {
+	event  indexEvent
+	events <-chan indexEvent
+}
+
+// startIndexUpdate runs `smartgrep --index` and streams its output. The
+// rebuild is killed when ctx is cancelled.
+func startIndexUpdate(ctx context.Context) tea.Cmd {
+	return func() tea.Msg {
+		events := make(chan indexEvent)
+		
+		line := config.CLICommandLine(config.GetSmartgrepPath(), "--index")
+		cmd := exec.CommandContext(ctx, line[0], line[1:]...)
+		cmd.Dir = projectDir
+		config.LogCommand(cmd)
+		
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			return indexProgressMsg{event: indexEvent{done: true, err: err}}
+		}
+		cmd.Stderr = cmd.Stdout
+		if err := cmd.Start(); err != nil {
+			return indexProgressMsg{event: indexEvent{done: true, err: err}}
+		}
+		
+		go func() {
+			defer close(events)
+			scanner := bufio.NewScanner(stdout)
+			for scanner.Scan() {
+				if line := strings.TrimSpace(scanner.Text()); line != "" {
+					select {
+					case events <- indexEvent{line: line}:
+					case <-ctx.Done():
+						// Nobody is listening any more; drain until the
+						// killed process closes its output
+					}
+				}
+			}
+			err := cmd.Wait()
+			select {
+			case events <- indexEvent{done: true, err: err}:
+			case <-ctx.Done():
+			}
+		}()
+		
+		return waitForIndexEvent(events)()
+	}
+}
+
+// waitForIndexEvent delivers the next index event to the model
+func waitForIndexEvent(events <-chan indexEvent) tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-events
+		if !ok {
+			return nil
+		}
+		return indexProgressMsg{event: event, events: events}
+	}
+}
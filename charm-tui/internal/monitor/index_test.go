@@ -0,0 +1,50 @@
+package monitor
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+)
+
+func TestIndexUpdateStopsWithContext(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the CLI")
+	}
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	dir := t.TempDir()
+	cli := filepath.Join(dir, "smartgrep")
+	if err := os.WriteFile(cli, []byte("#!/bin/sh\necho 'Indexing...'\nexec sleep 30\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CURATOR_CONFIG", filepath.Join(dir, "config.yaml"))
+	t.Setenv("SMARTGREP_CLI_PATH", cli)
+	config.ResetCache()
+	t.Cleanup(config.ResetCache)
+	
+	ctx, cancel := context.WithCancel(context.Background())
+	msg, ok := startIndexUpdate(ctx)().(indexProgressMsg)
+	if !ok || msg.event.line != "Indexing..." {
+		t.Fatalf("first message = %+v", msg)
+	}
+	
+	cancel()
+	deadline := time.After(5 * time.Second)
+	for {
+		select {
+		case _, open := <-msg.events:
+			if !open {
+				return
+			}
+		case <-deadline:
+			t.Fatal("index update still running after its context was cancelled")
+		}
+	}
+}
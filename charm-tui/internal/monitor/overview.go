@@ -0,0 +1,54 @@
+package monitor
+
+import (
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/style"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// overviewPaneStyle frames the static overview above the event stream
+var overviewPaneStyle = lipgloss.NewStyle().
+	BorderStyle(lipgloss.RoundedBorder()).
+	BorderForeground(style.Colors().Title).
+	Padding(0, 1)
+
+// overviewLoadedMsg carries the output of `monitor overview`
+type overviewLoadedMsg struct {
+	content string
+	err     error
+}
+
+// loadOverview runs the static codebase overview
+func loadOverview() (string, error) {
+	output, err := Command("overview").CombinedOutput()
+	return string(output), err
+}
+
+// fetchOverview loads the overview once for the combined dashboard
+func fetchOverview() tea.Cmd {
+	return func() tea.Msg {
+		content, err := loadOverview()
+		return overviewLoadedMsg{content: content, err: err}
+	}
+}
+
+// layoutPanes splits the height available for content between the overview
+// and the event stream. The overview gets two fifths, minus its border.
+func layoutPanes(available int) (overview, events int) {
+	if available < 10 {
+		return 0, available
+	}
+	overview = available * 2 / 5
+	return overview - 2, available - overview
+}
+
+// overviewPane renders the fixed overview pane
+func (m model) overviewPane() string {
+	body := m.overview.View()
+	if !m.overviewLoaded {
+		body = "Loading overview..."
+	}
+	return overviewPaneStyle.
+		Width(m.overview.Width + 2).
+		Render(body)
+}
@@ -0,0 +1,19 @@
+package monitor
+
+import "path/filepath"
+
+// projectDir is the project root the monitor and indexer run in; empty means
+// the current directory
+var projectDir string
+
+// SetProjectDir monitors dir instead of the current directory
+func SetProjectDir(dir string) {
+	if dir == "" {
+		projectDir = ""
+		return
+	}
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	projectDir = dir
+}
@@ -0,0 +1,77 @@
+package monitor
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// statusMsg is the index health reported by `monitor status --json`, or the
+// periodic status lines of `monitor watch --json`
+type statusMsg struct {
+	filesIndexed int
+	totalFiles   int
+	lastUpdate   time.Time // When the status was taken
+	lastIndexed  time.Time // Zero if the monitor didn't report it
+	indexSize    int64     // Bytes; only reported by the status command
+	healthy      bool
+	issues       []string
+}
+
+// toStatus derives index health from a status line. Fields the monitor left
+// out, such as integrity in watch mode, are not counted as issues.
+func (wl watchLine) toStatus() statusMsg {
+	status := statusMsg{
+		filesIndexed: wl.IndexedFiles,
+		totalFiles:   wl.TotalFiles,
+		lastUpdate:   wl.Timestamp,
+		lastIndexed:  wl.LastIndexed,
+		indexSize:    wl.IndexSize,
+	}
+	
+	switch {
+	case wl.IndexExists != nil && !*wl.IndexExists:
+		status.issues = append(status.issues, "No index found (run smartgrep --index)")
+	case wl.IndexedFiles == 0:
+		status.issues = append(status.issues, "Index is empty")
+	case wl.Consistent == nil && wl.IndexedFiles < wl.TotalFiles:
+		// The integrity check reports this itself when it ran
+		status.issues = append(status.issues, fmt.Sprintf("Index is stale: %d of %d files indexed",
+			wl.IndexedFiles, wl.TotalFiles))
+	}
+	if wl.Consistent != nil && !*wl.Consistent {
+		if len(wl.Issues) == 0 {
+			status.issues = append(status.issues, "Index integrity check failed")
+		}
+		status.issues = append(status.issues, wl.Issues...)
+	}
+	if wl.Watching != nil && !*wl.Watching {
+		status.issues = append(status.issues, "Not watching for changes")
+	}
+	
+	status.healthy = len(status.issues) == 0
+	return status
+}
+
+// parseStatusOutput returns the last status line in monitor output
+func parseStatusOutput(output string, now time.Time) (statusMsg, error) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if _, status := parseWatchLine(strings.TrimSpace(lines[i]), now); status != nil {
+			return *status, nil
+		}
+	}
+	return statusMsg{}, fmt.Errorf("no status in monitor output")
+}
+
+// formatBytes renders a size for the stats box, e.g. 1.5 MB
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
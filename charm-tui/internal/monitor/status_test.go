@@ -0,0 +1,78 @@
+package monitor
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseStatusOutput(t *testing.T) {
+	now := time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC)
+	indexed := time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC)
+	
+	tests := []struct {
+		name    string
+		output  string
+		healthy bool
+		issues  []string
+	}{
+		{
+			name:    "healthy",
+			output:  `{"type":"status","totalFiles":42,"indexedFiles":42,"indexExists":true,"indexSize":2048,"lastIndexed":"2024-03-05T09:00:00Z","consistent":true,"issues":[]}`,
+			healthy: true,
+		},
+		{
+			name:   "missing index",
+			output: "🔍 Initializing incremental indexer...\n" + `{"type":"status","totalFiles":42,"indexedFiles":0,"indexExists":false,"consistent":false,"issues":["Hash tree has 42 files but semantic index has 0"]}`,
+			issues: []string{"No index found (run smartgrep --index)", "Hash tree has 42 files but semantic index has 0"},
+		},
+		{
+			name:   "inconsistent without details",
+			output: `{"type":"status","totalFiles":42,"indexedFiles":40,"indexExists":true,"consistent":false}`,
+			issues: []string{"Index integrity check failed"},
+		},
+		{
+			name:   "stale in watch mode",
+			output: `{"type":"status","totalFiles":42,"indexedFiles":40,"watching":true}`,
+			issues: []string{"Index is stale: 40 of 42 files indexed"},
+		},
+		{
+			name:   "not watching",
+			output: `{"type":"status","totalFiles":42,"indexedFiles":42,"watching":false}`,
+			issues: []string{"Not watching for changes"},
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := parseStatusOutput(tt.output, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status.healthy != tt.healthy || !reflect.DeepEqual(status.issues, tt.issues) {
+				t.Errorf("healthy = %v, issues = %q; want %v, %q", status.healthy, status.issues, tt.healthy, tt.issues)
+			}
+		})
+	}
+	
+	status, _ := parseStatusOutput(tests[0].output, now)
+	if status.filesIndexed != 42 || status.totalFiles != 42 || status.indexSize != 2048 ||
+		!status.lastIndexed.Equal(indexed) || !status.lastUpdate.Equal(now) {
+		t.Errorf("status = %+v", status)
+	}
+}
+
+func TestParseStatusOutputWithoutStatus(t *testing.T) {
+	if _, err := parseStatusOutput("📋 DETAILED STATUS REPORT\n", time.Now()); err == nil {
+		t.Error("expected an error when the output has no status line")
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[int64]string{512: "512 B", 2048: "2.0 KB", 3 << 20: "3.0 MB"}
+	for n, want := range tests {
+		if got := formatBytes(n); got != want {
+			t.Errorf("formatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -1,11 +1,12 @@
 package monitor
 
 import (
+	"context"
 	"fmt"
-	"os/exec"
 	"strings"
 	"time"
 
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/style"
 	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
@@ -16,25 +17,25 @@ import (
 var (
 	titleStyle = lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("212")).
+			Foreground(style.Colors().Title).
 			BorderStyle(lipgloss.DoubleBorder()).
-			BorderForeground(lipgloss.Color("212")).
+			BorderForeground(style.Colors().Title).
 			Padding(1, 2)
 			
 	statsStyle = lipgloss.NewStyle().
 			BorderStyle(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("33")).
+			BorderForeground(style.Colors().Accent).
 			Padding(1, 2).
 			MarginTop(1)
 			
 	addedStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("120"))
+			Foreground(style.Colors().Added)
 			
 	modifiedStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("33"))
+			Foreground(style.Colors().Accent)
 			
 	deletedStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("196"))
+			Foreground(style.Colors().Deleted)
 			
 	headerStyle = lipgloss.NewStyle().
 			Bold(true).
@@ -43,40 +44,73 @@ var (
 
 // Messages
 type tickMsg time.Time
-type monitorOutputMsg string
-type statusMsg struct {
-	filesIndexed int
-	lastUpdate   time.Time
-	healthy      bool
-}
 
 // Main model
 type model struct {
 	mode         string
 	viewport     viewport.Model
 	progress     progress.Model
-	events       []string
+	events       []fileEvent    // Rolling buffer of the last maxEvents events
+	counts       map[string]int // Events seen per kind since start
 	stats        statusMsg
 	width        int
 	height       int
 	showOverview bool
-	err          error
+	err          error    // Last failed status refresh, until one succeeds
+	watcher      *watcher // Streams file events in watch mode
+	
+	// Static overview shown above the events with --overview
+	overview       viewport.Model
+	overviewLoaded bool
+	eventLog     *eventLog // Set via --log-file
+	logErr       error
+	
+	// Status mode refreshing
+	refreshInterval time.Duration
+	refreshing      bool
+	lastRefresh     time.Time
+	
+	// Event display
+	paused    bool        // Incoming events are held back until resumed
+	held      []fileEvent // Events received while paused
+	following bool        // Keep the viewport scrolled to the newest event
+	
+	// Index updates, killed via ctx when the TUI quits
+	ctx          context.Context
+	cancel       context.CancelFunc
+	autoIndex    bool
+	pendingIndex bool      // Files changed since the last index update
+	lastActivity time.Time // When the most recent file change was seen
+	indexing     bool
+	indexStatus  string
 }
 
 func initialModel(mode string, showOverview bool) model {
 	vp := viewport.New(80, 20)
 	prog := progress.New(progress.WithDefaultGradient())
 	
+	ctx, cancel := context.WithCancel(context.Background())
 	return model{
+		ctx:          ctx,
+		cancel:       cancel,
 		mode:         mode,
 		viewport:     vp,
+		overview:     viewport.New(80, 10),
 		progress:     prog,
-		events:       []string{},
+		counts:       make(map[string]int),
+		following:    true,
 		showOverview: showOverview,
 	}
 }
 
 func (m model) Init() tea.Cmd {
+	if m.watcher != nil {
+		var overviewCmd tea.Cmd
+		if m.showOverview {
+			overviewCmd = fetchOverview()
+		}
+		return tea.Batch(tickCmd(), waitForMonitorOutput(m.watcher.events), overviewCmd)
+	}
 	return tea.Batch(
 		tickCmd(),
 		m.startMonitoring(),
@@ -92,34 +126,17 @@ func tickCmd() tea.Cmd {
 func (m model) startMonitoring() tea.Cmd {
 	return func() tea.Msg {
 		switch m.mode {
-		case "watch":
-			// Start file watcher
-			cmdArgs := []string{"run", "../../src/tools/monitor/cli.ts", "watch"}
-			if m.showOverview {
-				cmdArgs = append(cmdArgs, "--overview")
-			}
-			
-			cmd := exec.Command("bun", cmdArgs...)
-			output, err := cmd.CombinedOutput()
-			if err != nil {
-				return err
-			}
-			return monitorOutputMsg(string(output))
-			
 		case "status":
 			// Get status
-			cmd := exec.Command("bun", "run", "../../src/tools/monitor/cli.ts", "status")
-			output, err := cmd.CombinedOutput()
+			cmd := Command("status", "--json")
+			output, err := cmd.Output()
 			if err != nil {
 				return err
 			}
 			
-			// Parse status (simplified)
-			lines := strings.Split(string(output), "\n")
-			status := statusMsg{
-				filesIndexed: len(lines),
-				lastUpdate:   time.Now(),
-				healthy:      true,
+			status, err := parseStatusOutput(string(output), time.Now())
+			if err != nil {
+				return err
 			}
 			return status
 			
@@ -136,6 +153,22 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		m.viewport.Width = msg.Width - 4
 		m.viewport.Height = msg.Height - 10
+		if m.showOverview {
+			m.overview.Width = msg.Width - 8
+			m.overview.Height, m.viewport.Height = layoutPanes(msg.Height - 10)
+		}
+		if m.following {
+			m.viewport.GotoBottom()
+		}
+		return m, nil
+		
+	case overviewLoadedMsg:
+		m.overviewLoaded = true
+		if msg.err != nil {
+			m.overview.SetContent(deletedStyle.Render("✗ Overview failed: " + msg.err.Error()))
+		} else {
+			m.overview.SetContent(msg.content)
+		}
 		return m, nil
 		
 	case tea.KeyMsg:
@@ -144,39 +177,124 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		case "c":
 			// Clear events
-			m.events = []string{}
+			m.events = nil
+			m.held = nil
+			m.refreshEvents()
+			return m, nil
+		case " ":
+			m.paused = !m.paused
+			if !m.paused {
+				for _, event := range m.held {
+					m.events = appendEvent(m.events, event)
+				}
+				m.held = nil
+				m.refreshEvents()
+			}
+			return m, nil
+		case "f":
+			m.following = !m.following
+			if m.following {
+				m.viewport.GotoBottom()
+			}
+			return m, nil
+		case "u":
+			// Manually trigger an index update
+			if !m.indexing {
+				m.indexing = true
+				m.indexStatus = "🔄 Updating index..."
+				return m, startIndexUpdate(m.ctx)
+			}
+			return m, nil
+		case "a":
+			m.autoIndex = !m.autoIndex
+			return m, nil
+		case "r":
+			if m.mode == "status" && !m.refreshing {
+				m.refreshing = true
+				return m, m.startMonitoring()
+			}
 			return m, nil
 		}
 		
 	case tickMsg:
-		// Update every second for live monitoring
-		if m.mode == "watch" {
-			return m, tea.Batch(tickCmd(), m.startMonitoring())
+		// Auto-update the index once file activity has settled
+		var indexCmd tea.Cmd
+		if m.autoIndex && m.pendingIndex && !m.indexing &&
+			time.Since(m.lastActivity) >= indexQuietPeriod {
+			m.indexing = true
+			m.pendingIndex = false
+			m.indexStatus = "🔄 Updating index..."
+			indexCmd = startIndexUpdate(m.ctx)
 		}
-		return m, tickCmd()
+		
+		if m.eventLog != nil && m.logErr == nil {
+			m.logErr = m.eventLog.flush()
+		}
+		
+		// Re-fetch the status once the refresh interval has passed
+		var statusCmd tea.Cmd
+		if m.mode == "status" && m.refreshInterval > 0 && !m.refreshing &&
+			time.Since(m.lastRefresh) >= m.refreshInterval {
+			m.refreshing = true
+			statusCmd = m.startMonitoring()
+		}
+		
+		return m, tea.Batch(tickCmd(), indexCmd, statusCmd)
+		
+	case indexProgressMsg:
+		if !msg.event.done {
+			m.indexStatus = "🔄 " + msg.event.line
+			return m, waitForIndexEvent(msg.events)
+		}
+		m.indexing = false
+		if msg.event.err != nil {
+			m.indexStatus = deletedStyle.Render("✗ Index update failed: " + msg.event.err.Error())
+		} else {
+			m.indexStatus = addedStyle.Render("✓ Index updated at " + time.Now().Format("15:04:05"))
+		}
+		return m, nil
 		
 	case monitorOutputMsg:
-		// Parse and add events
-		lines := strings.Split(string(msg), "\n")
-		for _, line := range lines {
-			if line != "" {
-				m.events = append(m.events, fmt.Sprintf("[%s] %s", 
-					time.Now().Format("15:04:05"), line))
+		if msg.done {
+			line := "Watcher stopped"
+			if msg.err != nil {
+				line = "Watcher stopped: " + msg.err.Error()
 			}
+			m.addEvent(fileEvent{path: line, kind: "error", timestamp: time.Now()})
+			return m, nil
 		}
-		// Keep last 100 events
-		if len(m.events) > 100 {
-			m.events = m.events[len(m.events)-100:]
+		
+		event, status := parseWatchLine(msg.line, time.Now())
+		if status != nil {
+			m.stats = *status
+			return m, waitForMonitorOutput(msg.events)
 		}
-		m.viewport.SetContent(m.renderEvents())
-		return m, nil
+		if event.kind != "log" {
+			if m.eventLog != nil && m.logErr == nil {
+				m.logErr = m.eventLog.write(*event)
+			}
+			m.counts[event.kind]++
+			if event.changesFiles() {
+				m.pendingIndex = true
+				m.lastActivity = time.Now()
+			}
+		}
+		m.addEvent(*event)
+		return m, waitForMonitorOutput(msg.events)
 		
 	case statusMsg:
 		m.stats = msg
+		m.err = nil
+		m.refreshing = false
+		m.lastRefresh = time.Now()
 		return m, nil
 		
 	case error:
+		// Shown in the status line until a refresh succeeds. Failed
+		// refreshes count too, so the next waits a full interval.
 		m.err = msg
+		m.refreshing = false
+		m.lastRefresh = time.Now()
 		return m, nil
 	}
 	
@@ -186,17 +304,78 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// addEvent shows an event, or holds it back while the display is paused
+func (m *model) addEvent(event fileEvent) {
+	if m.paused {
+		m.held = appendEvent(m.held, event)
+		return
+	}
+	m.events = appendEvent(m.events, event)
+	m.refreshEvents()
+}
+
+// refreshEvents re-renders the event log, following the tail if enabled
+func (m *model) refreshEvents() {
+	m.viewport.SetContent(m.renderEvents())
+	if m.following {
+		m.viewport.GotoBottom()
+	}
+}
+
+// renderStats renders the statistics box contents
+func (m model) renderStats() string {
+	var sb strings.Builder
+	sb.WriteString(headerStyle.Render("Statistics") + "\n\n")
+	
+	if m.stats.totalFiles > 0 {
+		sb.WriteString(fmt.Sprintf("Files Indexed: %d/%d\n", m.stats.filesIndexed, m.stats.totalFiles))
+	} else {
+		sb.WriteString(fmt.Sprintf("Files Indexed: %d\n", m.stats.filesIndexed))
+	}
+	if !m.stats.lastIndexed.IsZero() {
+		sb.WriteString("Last Indexed: " + m.stats.lastIndexed.Local().Format("2006-01-02 15:04:05") + "\n")
+	}
+	if m.stats.indexSize > 0 {
+		sb.WriteString("Index Size: " + formatBytes(m.stats.indexSize) + "\n")
+	}
+	sb.WriteString("Last Update: " + m.stats.lastUpdate.Local().Format("15:04:05") + "\n")
+	if m.mode == "watch" {
+		sb.WriteString(fmt.Sprintf("Changes: %s %s %s\n",
+			addedStyle.Render(fmt.Sprintf("+%d", m.counts["added"])),
+			modifiedStyle.Render(fmt.Sprintf("~%d", m.counts["modified"])),
+			deletedStyle.Render(fmt.Sprintf("-%d", m.counts["deleted"]))))
+	}
+	
+	if m.stats.lastUpdate.IsZero() {
+		sb.WriteString("Status: checking...")
+	} else if m.stats.healthy {
+		sb.WriteString("Status: " + addedStyle.Render("✓ Healthy"))
+	} else {
+		sb.WriteString("Status: " + deletedStyle.Render("✗ Issues"))
+		for _, issue := range m.stats.issues {
+			sb.WriteString("\n  " + deletedStyle.Render("• "+issue))
+		}
+	}
+	return sb.String()
+}
+
 func (m model) renderEvents() string {
 	var sb strings.Builder
 	
 	for _, event := range m.events {
-		styled := event
-		if strings.Contains(event, "added") {
-			styled = addedStyle.Render(event)
-		} else if strings.Contains(event, "modified") {
-			styled = modifiedStyle.Render(event)
-		} else if strings.Contains(event, "deleted") {
-			styled = deletedStyle.Render(event)
+		stamp := event.timestamp.Local().Format("15:04:05")
+		var styled string
+		switch event.kind {
+		case "added":
+			styled = addedStyle.Render(fmt.Sprintf("[%s] + %s", stamp, event.path))
+		case "modified":
+			styled = modifiedStyle.Render(fmt.Sprintf("[%s] ~ %s", stamp, event.path))
+		case "deleted":
+			styled = deletedStyle.Render(fmt.Sprintf("[%s] - %s", stamp, event.path))
+		case "error":
+			styled = deletedStyle.Render(fmt.Sprintf("[%s] %s", stamp, event.path))
+		default:
+			styled = fmt.Sprintf("[%s] %s", stamp, event.path)
 		}
 		sb.WriteString(styled + "\n")
 	}
@@ -205,36 +384,52 @@ func (m model) renderEvents() string {
 }
 
 func (m model) View() string {
-	if m.err != nil {
-		return fmt.Sprintf("Error: %v\n\nPress 'q' to quit.", m.err)
-	}
-	
 	// Title
 	title := titleStyle.Render("📊 Monitor Dashboard")
 	
 	// Stats box
-	stats := statsStyle.Render(fmt.Sprintf(
-		"%s\n\n"+
-		"Files Indexed: %d\n"+
-		"Last Update: %s\n"+
-		"Status: %s",
-		headerStyle.Render("Statistics"),
-		m.stats.filesIndexed,
-		m.stats.lastUpdate.Format("15:04:05"),
-		func() string {
-			if m.stats.healthy {
-				return addedStyle.Render("✓ Healthy")
-			}
-			return deletedStyle.Render("✗ Issues")
-		}(),
-	))
+	stats := statsStyle.Render(m.renderStats())
 	
 	// Main content
 	content := m.viewport.View()
+	if m.showOverview {
+		content = lipgloss.JoinVertical(lipgloss.Left, m.overviewPane(), content)
+	}
+	
+	// Index update status
+	autoState := "off"
+	if m.autoIndex {
+		autoState = "on"
+	}
+	indexLine := fmt.Sprintf("Auto-index: %s", autoState)
+	if m.indexStatus != "" {
+		indexLine += " • " + m.indexStatus
+	} else if m.pendingIndex {
+		indexLine += " • changes pending"
+	}
+	if m.paused {
+		indexLine += " • " + modifiedStyle.Render(fmt.Sprintf("⏸ paused (%d held)", len(m.held)))
+	}
+	if m.following {
+		indexLine += " • following"
+	}
+	if m.logErr != nil {
+		indexLine += " • " + deletedStyle.Render("✗ Event log: "+m.logErr.Error())
+	}
 	
 	// Help
-	help := lipgloss.NewStyle().Faint(true).Render(
-		"q: quit • c: clear • ↑/↓: scroll")
+	helpText := "q: quit • c: clear • space: pause • f: follow • u: update index • a: toggle auto-index • ↑/↓: scroll"
+	if m.mode == "status" {
+		helpText = "q: quit • r: refresh • u: update index • ↑/↓: scroll"
+		if m.refreshing {
+			indexLine += " • refreshing..."
+		} else if m.err != nil {
+			indexLine += " • " + deletedStyle.Render("✗ Refresh failed at "+m.lastRefresh.Format("15:04:05")+": "+m.err.Error())
+		} else if !m.lastRefresh.IsZero() {
+			indexLine += " • " + lipgloss.NewStyle().Faint(true).Render("last refreshed "+m.lastRefresh.Format("15:04:05"))
+		}
+	}
+	help := lipgloss.NewStyle().Faint(true).Render(helpText)
 	
 	// Layout
 	return lipgloss.JoinVertical(
@@ -242,6 +437,7 @@ func (m model) View() string {
 		title,
 		stats,
 		content,
+		indexLine,
 		help,
 	)
 }
@@ -249,16 +445,33 @@ func (m model) View() string {
 // RunTUI launches the main monitor TUI
 func RunTUI() error {
 	// Default to watch mode
-	return RunWatchTUI(false)
+	return RunWatchTUI(false, false, "")
 }
 
-// RunWatchTUI launches watch mode TUI
-func RunWatchTUI(withOverview bool) error {
-	p := tea.NewProgram(
-		initialModel("watch", withOverview),
-		tea.WithAltScreen(),
-	)
-	_, err := p.Run()
+// RunWatchTUI launches watch mode TUI. When autoIndex is set the semantic
+// index is refreshed once file activity settles. File events are appended to
+// logFile as JSON lines if one is given.
+func RunWatchTUI(withOverview, autoIndex bool, logFile string) error {
+	w, err := startWatcher(withOverview)
+	if err != nil {
+		return fmt.Errorf("failed to start watcher: %w", err)
+	}
+	defer w.stop()
+	
+	m := initialModel("watch", withOverview)
+	defer m.cancel()
+	m.autoIndex = autoIndex
+	m.watcher = w
+	if logFile != "" {
+		if log, err := openEventLog(logFile); err != nil {
+			m.logErr = err
+		} else {
+			m.eventLog = log
+			defer log.close()
+		}
+	}
+	
+	_, err = style.Run(m)
 	return err
 }
 
@@ -302,27 +515,26 @@ func (m overviewModel) View() string {
 // RunOverviewTUI launches overview TUI
 func RunOverviewTUI() error {
 	// For overview, we'll use a simpler display
-	cmd := exec.Command("bun", "run", "../../src/tools/monitor/cli.ts", "overview")
-	output, err := cmd.CombinedOutput()
+	output, err := loadOverview()
 	if err != nil {
 		return err
 	}
 	
 	// Create a simple pager view
 	vp := viewport.New(80, 30)
-	vp.SetContent(string(output))
+	vp.SetContent(output)
 	
-	p := tea.NewProgram(overviewModel{viewport: vp}, tea.WithAltScreen())
-	_, err = p.Run()
+	_, err = style.Run(overviewModel{viewport: vp})
 	return err
 }
 
-// RunStatusTUI launches status TUI
-func RunStatusTUI() error {
-	p := tea.NewProgram(
-		initialModel("status", false),
-		tea.WithAltScreen(),
-	)
-	_, err := p.Run()
+// RunStatusTUI launches status TUI, re-fetching the status every interval
+func RunStatusTUI(interval time.Duration) error {
+	m := initialModel("status", false)
+	defer m.cancel()
+	m.refreshInterval = interval
+	m.refreshing = true // Init fetches the first status
+	
+	_, err := style.Run(m)
 	return err
 }
\ No newline at end of file
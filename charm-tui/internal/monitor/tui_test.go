@@ -0,0 +1,142 @@
+package monitor
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPauseHoldsEventsUntilResumed(t *testing.T) {
+	var m tea.Model = initialModel("watch", false)
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	change := monitorOutputMsg{line: `{"type":"change","path":"src/a.ts","kind":"added"}`}
+	
+	m, _ = m.Update(space)
+	m, _ = m.Update(change)
+	got := m.(model)
+	if !got.paused || len(got.events) != 0 || len(got.held) != 1 {
+		t.Fatalf("while paused: paused=%v events=%d held=%d", got.paused, len(got.events), len(got.held))
+	}
+	if got.counts["added"] != 1 {
+		t.Errorf("held events should still be counted, counts = %v", got.counts)
+	}
+	
+	m, _ = m.Update(space)
+	got = m.(model)
+	if got.paused || len(got.events) != 1 || len(got.held) != 0 {
+		t.Errorf("after resume: paused=%v events=%d held=%d", got.paused, len(got.events), len(got.held))
+	}
+}
+
+func TestStatusRefreshesOnInterval(t *testing.T) {
+	m := initialModel("status", false)
+	m.refreshInterval = time.Minute
+	m.lastRefresh = time.Now()
+	
+	updated, _ := m.Update(tickMsg(time.Now()))
+	if updated.(model).refreshing {
+		t.Error("refreshed before the interval passed")
+	}
+	
+	m.lastRefresh = time.Now().Add(-2 * time.Minute)
+	updated, _ = m.Update(tickMsg(time.Now()))
+	if !updated.(model).refreshing {
+		t.Error("did not refresh after the interval passed")
+	}
+	
+	updated, _ = updated.Update(statusMsg{lastUpdate: time.Now(), healthy: true})
+	if got := updated.(model); got.refreshing || got.lastRefresh.IsZero() {
+		t.Errorf("after status: refreshing=%v lastRefresh=%v", got.refreshing, got.lastRefresh)
+	}
+}
+
+func TestRefreshKeyOnlyInStatusMode(t *testing.T) {
+	r := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}}
+	
+	updated, cmd := initialModel("status", false).Update(r)
+	if !updated.(model).refreshing || cmd == nil {
+		t.Error("r did not start a refresh in status mode")
+	}
+	
+	updated, _ = initialModel("watch", false).Update(r)
+	if updated.(model).refreshing {
+		t.Error("r started a refresh in watch mode")
+	}
+}
+
+func TestLayoutPanes(t *testing.T) {
+	for _, available := range []int{10, 20, 41} {
+		overview, events := layoutPanes(available)
+		if overview <= 0 || events <= overview {
+			t.Errorf("layoutPanes(%d) = %d, %d", available, overview, events)
+		}
+		if overview+2+events != available {
+			t.Errorf("layoutPanes(%d) uses %d lines", available, overview+2+events)
+		}
+	}
+	if overview, events := layoutPanes(8); overview != 0 || events != 8 {
+		t.Errorf("layoutPanes(8) = %d, %d", overview, events)
+	}
+}
+
+func TestOverviewStaysFixedWhileEventsArrive(t *testing.T) {
+	var m tea.Model = initialModel("watch", true)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 100, Height: 50})
+	m, _ = m.Update(overviewLoadedMsg{content: "🏗️  CODEBASE OVERVIEW"})
+	before := m.(model).overviewPane()
+	
+	m, _ = m.Update(monitorOutputMsg{line: `{"type":"change","path":"src/a.ts","kind":"added"}`})
+	if after := m.(model).overviewPane(); after != before {
+		t.Error("overview pane changed when an event arrived")
+	}
+	if !strings.Contains(m.View(), "CODEBASE OVERVIEW") {
+		t.Error("overview missing from the dashboard")
+	}
+}
+
+func TestFailedRefreshShowsInline(t *testing.T) {
+	m := initialModel("status", false)
+	m.refreshInterval = time.Minute
+	m.refreshing = true
+	
+	updated, _ := m.Update(errors.New("status exited 1"))
+	m = updated.(model)
+	if m.refreshing || m.lastRefresh.IsZero() {
+		t.Fatalf("failed refresh not finished: refreshing=%v lastRefresh=%v", m.refreshing, m.lastRefresh)
+	}
+	view := m.View()
+	if !strings.Contains(view, "Refresh failed") || !strings.Contains(view, "status exited 1") || !strings.Contains(view, "Monitor Dashboard") {
+		t.Errorf("error should show in the dashboard's status line:\n%s", view)
+	}
+	
+	// The failure counts as a refresh, so the next tick waits an interval
+	updated, _ = m.Update(tickMsg(time.Now()))
+	if updated.(model).refreshing {
+		t.Error("retried before the interval passed")
+	}
+	
+	updated, _ = m.Update(statusMsg{totalFiles: 3})
+	m = updated.(model)
+	if m.err != nil || strings.Contains(m.View(), "Refresh failed") {
+		t.Errorf("successful refresh should clear the error, err = %v", m.err)
+	}
+}
+
+func TestOnlyFileChangesQueueIndexUpdate(t *testing.T) {
+	for line, want := range map[string]bool{
+		`{"type":"change","path":"src/a.ts","kind":"modified"}`: true,
+		`{"type":"change","path":"src/a.ts","kind":"deleted"}`:  true,
+		`{"type":"change","path":"src/a.ts"}`:                   false,
+		`{"type":"heartbeat"}`:                                  false,
+		"👀 Watching for changes...":                             false,
+	} {
+		var m tea.Model = initialModel("watch", false)
+		m, _ = m.Update(monitorOutputMsg{line: line})
+		if got := m.(model).pendingIndex; got != want {
+			t.Errorf("%s: pendingIndex = %v, want %v", line, got, want)
+		}
+	}
+}
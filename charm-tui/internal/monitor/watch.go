@@ -0,0 +1,106 @@
+package monitor
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// watchStopTimeout is how long the watcher gets to exit after an interrupt
+const watchStopTimeout = 2 * time.Second
+
+// monitorOutputMsg is a line of watcher output, or its exit when done is set
+type monitorOutputMsg struct {
+	line   string
+	done   bool
+	err    error
+	events <-chan monitorOutputMsg
+}
+
+// watcher is a long-running `monitor watch` process whose output is streamed
+type watcher struct {
+	cmd    *exec.Cmd
+	events chan monitorOutputMsg
+	exited chan struct{}
+}
+
+// startWatcher starts the file watcher once and streams its output lines
+func startWatcher(showOverview bool) (*watcher, error) {
+	cmdArgs := []string{"watch", "--json"}
+	if showOverview {
+		cmdArgs = append(cmdArgs, "--overview")
+	}
+	cmd := Command(cmdArgs...)
+	
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	cmd.Stderr = cmd.Stdout
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	
+	w := &watcher{
+		cmd:    cmd,
+		events: make(chan monitorOutputMsg),
+		exited: make(chan struct{}),
+	}
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				w.events <- monitorOutputMsg{line: line}
+			}
+		}
+		err := cmd.Wait()
+		close(w.exited)
+		w.events <- monitorOutputMsg{done: true, err: err}
+		close(w.events)
+	}()
+	
+	return w, nil
+}
+
+// stop interrupts the watcher, killing it if it doesn't exit in time
+func (w *watcher) stop() {
+	select {
+	case <-w.exited:
+		return
+	default:
+	}
+	
+	if err := w.cmd.Process.Signal(os.Interrupt); err != nil {
+		// Interrupts aren't supported on Windows
+		w.cmd.Process.Kill()
+	}
+	
+	// The reader goroutine may be blocked handing over a line nobody reads
+	go func() {
+		for range w.events {
+		}
+	}()
+	
+	select {
+	case <-w.exited:
+	case <-time.After(watchStopTimeout):
+		w.cmd.Process.Kill()
+	}
+}
+
+// waitForMonitorOutput delivers the next watcher line to the model
+func waitForMonitorOutput(events <-chan monitorOutputMsg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-events
+		if !ok {
+			return nil
+		}
+		msg.events = events
+		return msg
+	}
+}
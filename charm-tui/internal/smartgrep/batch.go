@@ -0,0 +1,139 @@
+package smartgrep
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+)
+
+// defaultBatchSuffixes are appended to the topic to find related code
+var defaultBatchSuffixes = []string{"Service", "Handler", "Test"}
+
+const (
+	// defaultMaxBatchSearches bounds the sub-searches of one batch run,
+	// including the references pass
+	defaultMaxBatchSearches = 6
+	
+	// maxBatchSearchesLimit caps configured values so a batch can't flood
+	// the backend
+	maxBatchSearchesLimit = 20
+	
+	// batchRefRelevance ranks references below direct matches
+	batchRefRelevance = 0.5
+)
+
+// batchQueries returns the searches to run for a topic, leaving room for
+// the references pass within max
+func batchQueries(topic string, suffixes []string, max int) []string {
+	queries := []string{topic}
+	for _, suffix := range suffixes {
+		if len(queries) >= max-1 {
+			break
+		}
+		queries = append(queries, topic+suffix)
+	}
+	return queries
+}
+
+// batchResult is the combined output of a batch run
+type batchResult struct {
+	topic    string
+	results  []searchResult
+	searches int
+	err      error
+}
+
+// runBatch runs the topic's searches and a references pass concurrently and
+// merges the results. It only fails if every sub-search does.
+func runBatch(ctx context.Context, topic string) batchResult {
+	max := config.GetMaxBatchSearches(defaultMaxBatchSearches)
+	if max > maxBatchSearchesLimit {
+		max = maxBatchSearchesLimit
+	}
+	if max < 2 {
+		max = 2
+	}
+	queries := batchQueries(topic, config.GetBatchSuffixes(defaultBatchSuffixes), max)
+	
+	lists := make([][]searchResult, len(queries)+1)
+	errs := make([]error, len(queries)+1)
+	var wg sync.WaitGroup
+	for i, query := range queries {
+		wg.Add(1)
+		go func(i int, query string) {
+			defer wg.Done()
+			lists[i], errs[i] = fetchSearchResults(ctx, query)
+		}(i, query)
+	}
+	
+	refsIndex := len(queries)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		output, err := runSmartgrep(ctx, false, "refs", topic, "--json")
+		if err != nil {
+			errs[refsIndex] = err
+			return
+		}
+		if _, err := findJSONArray(output); err != nil {
+			// No references found
+			return
+		}
+		refs, err := parseReferences(output)
+		lists[refsIndex], errs[refsIndex] = referencesToResults(topic, refs), err
+	}()
+	wg.Wait()
+	
+	res := batchResult{topic: topic, results: mergeResults(lists...), searches: len(queries) + 1}
+	for _, err := range errs {
+		if err == nil {
+			return res
+		}
+	}
+	res.err = fmt.Errorf("all %d searches failed: %w", res.searches, errs[0])
+	return res
+}
+
+// referencesToResults presents references as results so they can be shown
+// alongside search matches
+func referencesToResults(topic string, refs []reference) []searchResult {
+	results := make([]searchResult, len(refs))
+	for i, ref := range refs {
+		results[i] = searchResult{
+			term:      topic,
+			typ:       ref.typ,
+			location:  ref.from,
+			context:   ref.context,
+			relevance: batchRefRelevance,
+		}
+	}
+	return results
+}
+
+// mergeResults combines result lists, keeping the most relevant result for
+// each location, ordered by relevance
+func mergeResults(lists ...[]searchResult) []searchResult {
+	index := make(map[string]int)
+	var merged []searchResult
+	for _, list := range lists {
+		for _, r := range list {
+			key := fmt.Sprintf("%s:%d", r.location.file, r.location.line)
+			if i, ok := index[key]; ok {
+				if r.relevance > merged[i].relevance {
+					merged[i] = r
+				}
+				continue
+			}
+			index[key] = len(merged)
+			merged = append(merged, r)
+		}
+	}
+	
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].relevance > merged[j].relevance
+	})
+	return merged
+}
@@ -0,0 +1,43 @@
+package smartgrep
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBatchQueries(t *testing.T) {
+	suffixes := []string{"Service", "Handler", "Test"}
+	
+	got := batchQueries("auth", suffixes, 6)
+	want := []string{"auth", "authService", "authHandler", "authTest"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("batchQueries = %v, want %v", got, want)
+	}
+	
+	// One search is always left for the references pass
+	got = batchQueries("auth", suffixes, 3)
+	want = []string{"auth", "authService"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("bounded batchQueries = %v, want %v", got, want)
+	}
+}
+
+func TestMergeResultsDedupesByLocation(t *testing.T) {
+	at := func(term, file string, line int, relevance float64) searchResult {
+		return searchResult{term: term, location: location{file: file, line: line}, relevance: relevance}
+	}
+	
+	merged := mergeResults(
+		[]searchResult{at("auth", "a.ts", 1, 0.4), at("auth", "b.ts", 2, 0.9)},
+		[]searchResult{at("authService", "a.ts", 1, 0.8), at("authTest", "c.ts", 3, 0.1)},
+	)
+	
+	var got []string
+	for _, r := range merged {
+		got = append(got, r.term+"@"+r.location.file)
+	}
+	want := []string{"auth@b.ts", "authService@a.ts", "authTest@c.ts"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeResults = %v, want %v", got, want)
+	}
+}
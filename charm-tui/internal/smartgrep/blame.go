@@ -0,0 +1,141 @@
+package smartgrep
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// blameEnabled turns on git blame enrichment in the detail view (set via --blame)
+var blameEnabled bool
+
+// SetBlame enables git blame info for results in the detail view
+func SetBlame(enabled bool) {
+	blameEnabled = enabled
+}
+
+// blameInfo is the last commit that touched a result's line
+type blameInfo struct {
+	author  string
+	date    time.Time
+	commit  string
+	moved   bool   // The line content no longer matches the indexed context
+	err     string // Why blame is unavailable, if it is
+	loading bool
+}
+
+type blameMsg struct {
+	key  string
+	info blameInfo
+}
+
+func blameKey(loc location) string {
+	return fmt.Sprintf("%s:%d", loc.file, loc.line)
+}
+
+// fetchBlame runs git blame for a single line in the background
+func fetchBlame(r searchResult) tea.Cmd {
+	key := blameKey(r.location)
+	return func() tea.Msg {
+		if r.location.line < 1 {
+			return blameMsg{key, blameInfo{err: "no line information"}}
+		}
+		
+		lineRange := fmt.Sprintf("%d,%d", r.location.line, r.location.line)
+		cmd := exec.Command("git", "blame", "--porcelain", "-L", lineRange, "--", r.location.file)
+		cmd.Dir = projectDir
+		config.LogCommand(cmd)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		output, err := cmd.Output()
+		if err != nil {
+			reason := strings.TrimSpace(stderr.String())
+			switch {
+			case strings.Contains(reason, "not a git repository"):
+				reason = "not a git repository"
+			case strings.Contains(reason, "has only"):
+				reason = "line no longer exists"
+			case reason == "":
+				reason = err.Error()
+			}
+			return blameMsg{key, blameInfo{err: reason}}
+		}
+		
+		info := parseBlame(output)
+		if r.context != "" && info.err == "" {
+			info.moved = strings.TrimSpace(blameLineContent(output)) != strings.TrimSpace(r.context)
+		}
+		return blameMsg{key, info}
+	}
+}
+
+// parseBlame extracts author, date and commit from git blame --porcelain output
+func parseBlame(output []byte) blameInfo {
+	var info blameInfo
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	first := true
+	for scanner.Scan() {
+		line := scanner.Text()
+		if first {
+			if fields := strings.Fields(line); len(fields) > 0 {
+				info.commit = fields[0]
+			}
+			first = false
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "author "):
+			info.author = strings.TrimPrefix(line, "author ")
+		case strings.HasPrefix(line, "author-time "):
+			if ts, err := strconv.ParseInt(strings.TrimPrefix(line, "author-time "), 10, 64); err == nil {
+				info.date = time.Unix(ts, 0)
+			}
+		}
+	}
+	
+	if info.commit == "" {
+		info.err = "no blame information"
+	}
+	return info
+}
+
+// blameLineContent returns the source line from git blame --porcelain output
+func blameLineContent(output []byte) string {
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.HasPrefix(line, "\t") {
+			return strings.TrimPrefix(line, "\t")
+		}
+	}
+	return ""
+}
+
+// renderBlame formats blame info for the detail view
+func renderBlame(info blameInfo) string {
+	switch {
+	case info.loading:
+		return metaStyle.Render("👤 Last change: loading...") + "\n"
+	case info.err != "":
+		return metaStyle.Render("👤 Last change: unavailable ("+info.err+")") + "\n"
+	}
+	
+	commit := info.commit
+	if len(commit) > 8 {
+		commit = commit[:8]
+	}
+	if strings.HasPrefix(commit, "00000000") {
+		return "👤 Last change: not committed yet\n"
+	}
+	
+	line := fmt.Sprintf("👤 Last change: %s • %s • %s", info.author, info.date.Format("2006-01-02"), commit)
+	if info.moved {
+		line += metaStyle.Render(" (line has changed since indexing)")
+	}
+	return line + "\n"
+}
@@ -0,0 +1,214 @@
+package smartgrep
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-runewidth"
+)
+
+// bookmark is a result saved for later, kept in the same form as exports
+type bookmark struct {
+	Project string          `json:"project"`
+	Time    time.Time       `json:"time"`
+	Result  json.RawMessage `json:"result"`
+}
+
+func bookmarksPath() string {
+	return filepath.Join(config.GetDataDir(), "smartgrep_bookmarks.json")
+}
+
+// loadBookmarks reads the bookmarks of every project, oldest first
+func loadBookmarks() ([]bookmark, error) {
+	data, err := os.ReadFile(bookmarksPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	
+	var entries []bookmark
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse bookmarks: %w", err)
+	}
+	return entries, nil
+}
+
+func saveBookmarks(entries []bookmark) error {
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(bookmarksPath(), data)
+}
+
+// decodeExportedResult reads a result written by searchResult.MarshalJSON
+func decodeExportedResult(data []byte) (searchResult, error) {
+	var r struct {
+		TSInfo
+		RelevanceScore float64   `json:"relevanceScore"`
+		UsageCount     int       `json:"usageCount"`
+		References     []TSUsage `json:"references"`
+	}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return searchResult{}, err
+	}
+	return TSResult{
+		Info:           r.TSInfo,
+		RelevanceScore: r.RelevanceScore,
+		UsageCount:     r.UsageCount,
+		SampleUsages:   r.References,
+	}.toSearchResult(), nil
+}
+
+// projectBookmarks returns the results bookmarked in project, oldest first.
+// Entries that no longer decode are skipped.
+func projectBookmarks(entries []bookmark, project string) []searchResult {
+	var results []searchResult
+	for _, e := range entries {
+		if e.Project != project {
+			continue
+		}
+		if r, err := decodeExportedResult(e.Result); err == nil {
+			results = append(results, r)
+		}
+	}
+	return results
+}
+
+// loadProjectBookmarks returns the current project's bookmarks
+func loadProjectBookmarks() []searchResult {
+	entries, err := loadBookmarks()
+	if err != nil {
+		return nil
+	}
+	return projectBookmarks(entries, historyProject())
+}
+
+// toggleBookmark bookmarks result in the current project, or removes its
+// bookmark if it already has one
+func (m *resultViewModel) toggleBookmark(result searchResult) {
+	entries, err := loadBookmarks()
+	if err != nil {
+		m.notice = fmt.Sprintf("⚠️  %v", err)
+		return
+	}
+	
+	project := historyProject()
+	key := resultKey(result)
+	removed := false
+	kept := entries[:0]
+	for _, e := range entries {
+		if r, err := decodeExportedResult(e.Result); err == nil && e.Project == project && resultKey(r) == key {
+			removed = true
+			continue
+		}
+		kept = append(kept, e)
+	}
+	entries = kept
+	
+	if !removed {
+		data, err := json.Marshal(result)
+		if err != nil {
+			m.notice = fmt.Sprintf("⚠️  Cannot bookmark %s: %v", result.term, err)
+			return
+		}
+		entries = append(entries, bookmark{Project: project, Time: time.Now(), Result: data})
+	}
+	
+	if err := saveBookmarks(entries); err != nil {
+		m.notice = fmt.Sprintf("⚠️  Cannot save bookmarks: %v", err)
+		return
+	}
+	m.bookmarks = projectBookmarks(entries, project)
+	if m.bookmarkCursor >= len(m.bookmarks) {
+		m.bookmarkCursor = max(len(m.bookmarks)-1, 0)
+	}
+	if removed {
+		m.notice = fmt.Sprintf("☆ Removed bookmark %s", result.term)
+	} else {
+		m.notice = fmt.Sprintf("★ Bookmarked %s", result.term)
+	}
+	m.refreshRows()
+}
+
+// isBookmarked reports whether a result is bookmarked in the current project
+func (m *resultViewModel) isBookmarked(r searchResult) bool {
+	key := resultKey(r)
+	for _, b := range m.bookmarks {
+		if resultKey(b) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// moveBookmarkCursor selects another bookmark in the bookmarks view
+func (m *resultViewModel) moveBookmarkCursor(delta int) {
+	if len(m.bookmarks) == 0 {
+		return
+	}
+	m.bookmarkCursor = min(max(m.bookmarkCursor+delta, 0), len(m.bookmarks)-1)
+	m.updateBookmarksView()
+}
+
+// openBookmark opens the selected bookmark in the editor
+func (m *resultViewModel) openBookmark() tea.Cmd {
+	if m.bookmarkCursor >= len(m.bookmarks) {
+		return nil
+	}
+	result := m.bookmarks[m.bookmarkCursor]
+	
+	cmd, err := editFile(result.location.file, result.location.line)
+	if err != nil {
+		m.notice = fmt.Sprintf("⚠️  Cannot open %s: %v", result.location.file, err)
+		m.updateBookmarksView()
+	}
+	return cmd
+}
+
+func (m *resultViewModel) updateBookmarksView() {
+	var content strings.Builder
+	
+	if m.notice != "" {
+		content.WriteString(refExtendsStyle.Render(m.notice))
+		content.WriteString("\n\n")
+	}
+	
+	content.WriteString(mainTitleStyle.Render("★ Bookmarks"))
+	content.WriteString("\n\n")
+	
+	if len(m.bookmarks) == 0 {
+		content.WriteString(metaStyle.Render("No bookmarks in this project yet. Press b on a result to add one."))
+		m.viewport.SetContent(content.String())
+		return
+	}
+	
+	for i, r := range m.bookmarks {
+		cursor := "  "
+		term := r.term
+		if i == m.bookmarkCursor {
+			cursor = "▶ "
+			term = graphNodeStyle.Render(term)
+		}
+		content.WriteString(fmt.Sprintf("%s%s %s %s\n", cursor, getTypeIcon(r.typ), term,
+			metaStyle.Render(fmt.Sprintf("%s:%d", r.location.file, r.location.line))))
+		
+		summary := signatureText(r)
+		if summary == "" {
+			summary = strings.TrimSpace(r.context)
+		}
+		if summary != "" {
+			content.WriteString("    " + metaStyle.Render(runewidth.Truncate(summary, max(m.viewport.Width-4, 20), "…")) + "\n")
+		}
+	}
+	
+	m.viewport.SetContent(content.String())
+}
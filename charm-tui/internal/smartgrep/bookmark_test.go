@@ -0,0 +1,76 @@
+package smartgrep
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestToggleBookmarkPersists(t *testing.T) {
+	t.Setenv("CURATOR_DATA_DIR", t.TempDir())
+	
+	m := newResultViewModel()
+	m.setResults([]searchResult{
+		{term: "authenticate", typ: "function", location: location{file: "auth.ts", line: 12},
+			references: []reference{{typ: "call", target: "authenticate", from: location{file: "login.ts", line: 3}}}},
+		{term: "logout", typ: "function", location: location{file: "auth.ts", line: 40}},
+	})
+	
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	rv := updated.(resultViewModel)
+	if len(rv.bookmarks) != 1 || !strings.HasPrefix(rv.table.Rows()[0][0], "★ ") {
+		t.Fatalf("bookmarks = %+v, rows = %v", rv.bookmarks, rv.table.Rows())
+	}
+	
+	// A new session in the same project sees the bookmark, references included
+	saved := loadProjectBookmarks()
+	if len(saved) != 1 || saved[0].term != "authenticate" || saved[0].location.line != 12 || len(saved[0].references) != 1 {
+		t.Fatalf("reloaded bookmarks = %+v", saved)
+	}
+	if tmp, _ := filepath.Glob(bookmarksPath() + ".*.tmp"); len(tmp) != 0 {
+		t.Errorf("temporary files left behind: %v", tmp)
+	}
+	
+	SetProjectDir(t.TempDir())
+	t.Cleanup(func() { SetProjectDir("") })
+	if other := loadProjectBookmarks(); len(other) != 0 {
+		t.Errorf("bookmarks leaked into another project: %+v", other)
+	}
+	SetProjectDir("")
+	
+	rv.toggleBookmark(rv.results[0])
+	if len(rv.bookmarks) != 0 || len(loadProjectBookmarks()) != 0 {
+		t.Errorf("bookmark not removed: %+v", rv.bookmarks)
+	}
+}
+
+func TestBookmarksTab(t *testing.T) {
+	t.Setenv("CURATOR_DATA_DIR", t.TempDir())
+	
+	m := newResultViewModel()
+	m.setResults([]searchResult{{term: "authenticate", typ: "function", location: location{file: "auth.ts", line: 12}}})
+	m.toggleBookmark(m.results[0])
+	
+	var updated tea.Model = m
+	for i := 0; i < 4; i++ {
+		updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyTab})
+	}
+	rv := updated.(resultViewModel)
+	if rv.activeView != "bookmarks" {
+		t.Fatalf("activeView = %q, want bookmarks", rv.activeView)
+	}
+	if view := rv.View(); !strings.Contains(view, "authenticate") || !strings.Contains(view, "auth.ts:12") {
+		t.Errorf("bookmarks view missing the bookmark:\n%s", view)
+	}
+	
+	updated, _ = rv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	if rv = updated.(resultViewModel); len(rv.bookmarks) != 0 {
+		t.Errorf("b in the bookmarks tab should remove the selected bookmark")
+	}
+	updated, _ = rv.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if updated.(resultViewModel).activeView != "list" {
+		t.Errorf("tab from bookmarks should return to the list")
+	}
+}
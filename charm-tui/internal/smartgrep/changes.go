@@ -0,0 +1,471 @@
+package smartgrep
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/style"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxDiffLines caps the diff snippet shown for one file
+const maxDiffLines = 40
+
+// Changes view styles. Diffs use the monitor's file event colors.
+var (
+	diffAddedStyle = lipgloss.NewStyle().
+			Foreground(style.Colors().Added)
+	
+	diffModifiedStyle = lipgloss.NewStyle().
+				Foreground(style.Colors().Accent)
+	
+	diffDeletedStyle = lipgloss.NewStyle().
+				Foreground(style.Colors().Deleted)
+	
+	diffContextStyle = lipgloss.NewStyle().
+				Foreground(style.Colors().Meta)
+	
+	changeSymbolStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(style.Colors().Title)
+	
+	riskBadgeStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(style.Colors().Surface).
+			Padding(0, 1)
+	
+	changeCursorStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(style.Colors().Selection).
+				Background(style.Colors().Selected)
+)
+
+// impactSymbol is a heavily used symbol in a changed file
+type impactSymbol struct {
+	Symbol string `json:"symbol"`
+	File   string `json:"file"`
+	Uses   int    `json:"uses"`
+}
+
+// fileChange is a changed file as git reports it
+type fileChange struct {
+	Status string `json:"status"`
+	Path   string `json:"path"`
+}
+
+// changesReport mirrors the output of `changes --json`
+type changesReport struct {
+	Branch            string         `json:"branch"`
+	Files             []fileChange   `json:"files"`
+	TotalImpact       int            `json:"totalImpact"`     // References to symbols of changed files
+	AffectedSymbols   int            `json:"affectedSymbols"` // Used symbols in changed files
+	HighImpactSymbols []impactSymbol `json:"highImpactSymbols"`
+}
+
+// changedFile is one file of the working tree changes with its affected
+// symbols and diff
+type changedFile struct {
+	status  string // git status letter: A, M, D or R
+	path    string
+	symbols []impactSymbol
+	diff    []string
+}
+
+// parseChanges extracts the report from `changes --json` output, skipping
+// any progress lines printed before it
+func parseChanges(output []byte) (changesReport, error) {
+	var report changesReport
+	output = style.StripANSIBytes(output)
+	start := bytes.Index(output, []byte("\n{"))
+	if bytes.HasPrefix(bytes.TrimSpace(output), []byte("{")) {
+		start = 0
+	} else if start < 0 {
+		config.LogParseError("changes", fmt.Errorf("no JSON output found"))
+		return report, fmt.Errorf("no JSON output found")
+	}
+	if err := json.Unmarshal(output[start:], &report); err != nil {
+		config.LogParseError("changes", err)
+		return report, fmt.Errorf("failed to parse changes: %w", err)
+	}
+	return report, nil
+}
+
+// splitDiff splits `git diff` output into the lines of each file, keyed by
+// the file's path after the change
+func splitDiff(diff string) map[string][]string {
+	files := make(map[string][]string)
+	var path string
+	var lines []string
+	flush := func() {
+		if path != "" {
+			files[path] = lines
+		}
+		path, lines = "", nil
+	}
+	
+	scanner := bufio.NewScanner(strings.NewReader(diff))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			flush()
+		case strings.HasPrefix(line, "@@"), len(lines) > 0:
+			lines = append(lines, line)
+		case strings.HasPrefix(line, "--- a/"):
+			path = strings.TrimPrefix(line, "--- a/")
+		case strings.HasPrefix(line, "+++ b/"):
+			path = strings.TrimPrefix(line, "+++ b/")
+		}
+	}
+	flush()
+	return files
+}
+
+// gitDiff returns the diff of the changes opts select
+func gitDiff(opts ChangesOptions) (string, error) {
+	cmd := exec.Command("git", opts.diffArgs()...)
+	cmd.Dir = projectDir
+	config.LogCommand(cmd)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git diff failed: %w\n%s", err, strings.TrimSpace(stderr.String()))
+	}
+	return string(output), nil
+}
+
+// changedFiles pairs each changed file of the report with its symbols and
+// diff lines
+func changedFiles(report changesReport, diffs map[string][]string) []changedFile {
+	files := make([]changedFile, len(report.Files))
+	for i, f := range report.Files {
+		files[i] = changedFile{status: f.Status, path: f.Path, diff: diffs[f.Path]}
+		for _, s := range report.HighImpactSymbols {
+			if s.File == f.Path {
+				files[i].symbols = append(files[i].symbols, s)
+			}
+		}
+	}
+	return files
+}
+
+type changesLoadedMsg struct {
+	report changesReport
+	files  []changedFile
+	err    error
+}
+
+func fetchChanges(opts ChangesOptions) tea.Cmd {
+	return func() tea.Msg {
+		return loadChanges(opts)
+	}
+}
+
+func loadChanges(opts ChangesOptions) tea.Msg {
+	output, err := runSmartgrep(context.Background(), false, opts.changesArgs()...)
+	if err != nil {
+		return changesLoadedMsg{err: fmt.Errorf("failed to analyze changes: %w", err)}
+	}
+	report, err := parseChanges(output)
+	if err != nil {
+		return changesLoadedMsg{err: err}
+	}
+	
+	// Diffs only add detail, so the changes are still shown without them
+	diff, _ := gitDiff(opts)
+	return changesLoadedMsg{report: report, files: changedFiles(report, splitDiff(diff))}
+}
+
+// impactRisk rates how risky the changes are by the references pointing at
+// their symbols, with the same thresholds as `changes --compact`
+func impactRisk(totalImpact int) (string, lipgloss.AdaptiveColor) {
+	switch {
+	case totalImpact == 0:
+		return "✅ No risk", style.Colors().Added
+	case totalImpact < 10:
+		return "🟡 Low risk", style.Colors().Added
+	case totalImpact < 50:
+		return "🟠 Medium risk", style.Colors().Modified
+	default:
+		return "🔴 High risk", style.Colors().Deleted
+	}
+}
+
+// impactSummary renders the risk badge, how many symbols and references
+// the changes touch, and the symbol with the largest blast radius
+func impactSummary(report changesReport) string {
+	label, color := impactRisk(report.TotalImpact)
+	badge := riskBadgeStyle.Copy().Background(color).Render(label)
+	
+	// CLIs without affectedSymbols still list the heavily used ones
+	affected := max(report.AffectedSymbols, len(report.HighImpactSymbols))
+	summary := badge + " " + metaStyle.Render(fmt.Sprintf("%s • %s to them",
+		plural(affected, "affected symbol"), plural(report.TotalImpact, "reference")))
+	
+	if len(report.HighImpactSymbols) == 0 {
+		return summary
+	}
+	top := report.HighImpactSymbols[0]
+	for _, s := range report.HighImpactSymbols[1:] {
+		if s.Uses > top.Uses {
+			top = s
+		}
+	}
+	return summary + "\n" + lipgloss.NewStyle().Foreground(color).Render(
+		fmt.Sprintf("💥 Largest blast radius: %s in %s (%s)", top.Symbol, top.File, plural(top.Uses, "reference")))
+}
+
+// plural formats a count with its noun, adding an s unless it is one
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// statusLabel names a git status letter and picks its color
+func statusLabel(status string) (string, lipgloss.Style) {
+	switch status {
+	case "A":
+		return "✨ added", diffAddedStyle
+	case "D":
+		return "🗑️  deleted", diffDeletedStyle
+	case "R":
+		return "📝 renamed", diffModifiedStyle
+	default:
+		return "📝 modified", diffModifiedStyle
+	}
+}
+
+// renderDiff colors a file's diff lines, cut off after maxDiffLines
+func renderDiff(lines []string) string {
+	var b strings.Builder
+	for i, line := range lines {
+		if i == maxDiffLines {
+			b.WriteString(metaStyle.Render(fmt.Sprintf("… %d more lines", len(lines)-maxDiffLines)) + "\n")
+			break
+		}
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			line = diffModifiedStyle.Render(line)
+		case strings.HasPrefix(line, "+"):
+			line = diffAddedStyle.Render(line)
+		case strings.HasPrefix(line, "-"):
+			line = diffDeletedStyle.Render(line)
+		default:
+			line = diffContextStyle.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// changesModel lists the changed files of the working tree, each of which
+// expands to its affected symbols and diff
+type changesModel struct {
+	opts     ChangesOptions
+	report   changesReport
+	files    []changedFile
+	expanded []bool
+	cursor   int
+	offsets  []int // Viewport line of each file's header
+	viewport viewport.Model
+	loading  bool
+	err      error
+	width    int
+	height   int
+	help     help.Model
+}
+
+func newChangesModel(opts ChangesOptions) changesModel {
+	return changesModel{
+		opts:     opts,
+		viewport: viewport.New(80, 20),
+		loading:  true,
+		help:     help.New(),
+	}
+}
+
+func (m changesModel) Init() tea.Cmd {
+	return fetchChanges(m.opts)
+}
+
+func (m changesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.viewport.Width = msg.Width
+		m.help.Width = msg.Width
+		m.fitViewport()
+		m.render()
+		return m, nil
+	
+	case changesLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		m.report = msg.report
+		m.files = msg.files
+		m.expanded = make([]bool, len(m.files))
+		m.cursor = 0
+		m.fitViewport()
+		m.render()
+		return m, nil
+	
+	case tea.KeyMsg:
+		k := resultKeys
+		switch {
+		case key.Matches(msg, k.Quit):
+			return m, tea.Quit
+		case key.Matches(msg, k.Help):
+			m.help.ShowAll = !m.help.ShowAll
+			m.fitViewport()
+			return m, nil
+		case m.loading:
+			return m, nil
+		case key.Matches(msg, k.Retry):
+			m.loading = true
+			return m, fetchChanges(m.opts)
+		case len(m.files) == 0:
+			return m, nil
+		case key.Matches(msg, k.Up):
+			m.cursor = max(m.cursor-1, 0)
+		case key.Matches(msg, k.Down):
+			m.cursor = min(m.cursor+1, len(m.files)-1)
+		case key.Matches(msg, k.Expand):
+			m.expanded[m.cursor] = !m.expanded[m.cursor]
+		case key.Matches(msg, k.ExpandAll):
+			m.toggleAll()
+		default:
+			var cmd tea.Cmd
+			m.viewport, cmd = m.viewport.Update(msg)
+			return m, cmd
+		}
+		m.render()
+		m.scrollToCursor()
+		return m, nil
+	}
+	return m, nil
+}
+
+// fitViewport gives the file list the lines the header, impact summary and
+// help leave
+func (m *changesModel) fitViewport() {
+	helpHeight := 1
+	if m.help.ShowAll {
+		helpHeight = 3
+	}
+	summaryHeight := lipgloss.Height(impactSummary(m.report))
+	m.viewport.Height = max(m.height-1-summaryHeight-helpHeight, 3)
+}
+
+// toggleAll expands every file, or collapses them all if all are open
+func (m *changesModel) toggleAll() {
+	open := true
+	for _, e := range m.expanded {
+		open = open && e
+	}
+	for i := range m.expanded {
+		m.expanded[i] = !open
+	}
+}
+
+// render lists the files, recording where each header lands so the cursor
+// can be kept in view
+func (m *changesModel) render() {
+	var content strings.Builder
+	lines := 0
+	write := func(s string) {
+		content.WriteString(s)
+		lines += strings.Count(s, "\n")
+	}
+	
+	m.offsets = make([]int, len(m.files))
+	for i, f := range m.files {
+		marker := "▸"
+		if m.expanded[i] {
+			marker = "▾"
+		}
+		label, labelStyle := statusLabel(f.status)
+		header := fmt.Sprintf("%s %s", marker, style.TruncatePath(f.path, max(m.width-24, 20)))
+		if i == m.cursor {
+			header = changeCursorStyle.Render(header)
+		}
+		if n := len(f.symbols); n > 0 {
+			label += fmt.Sprintf(" • %d affected", n)
+		}
+		m.offsets[i] = lines
+		write(header + " " + labelStyle.Render(label) + "\n")
+	
+		if !m.expanded[i] {
+			continue
+		}
+		for _, s := range f.symbols {
+			write(fmt.Sprintf("    🔗 %s %s\n", changeSymbolStyle.Render(s.Symbol), metaStyle.Render(fmt.Sprintf("(%d uses)", s.Uses))))
+		}
+		if len(f.diff) == 0 {
+			write(metaStyle.Render("    No diff available") + "\n")
+		} else {
+			write(lipgloss.NewStyle().PaddingLeft(4).Render(strings.TrimSuffix(renderDiff(f.diff), "\n")) + "\n")
+		}
+		write("\n")
+	}
+	m.viewport.SetContent(content.String())
+}
+
+// scrollToCursor scrolls the viewport just enough to show the header under
+// the cursor
+func (m *changesModel) scrollToCursor() {
+	if m.cursor >= len(m.offsets) {
+		return
+	}
+	line := m.offsets[m.cursor]
+	switch {
+	case line < m.viewport.YOffset:
+		m.viewport.SetYOffset(line)
+	case line >= m.viewport.YOffset+m.viewport.Height:
+		m.viewport.SetYOffset(line - m.viewport.Height + 1)
+	}
+}
+
+func (m changesModel) helpKeys() viewHelp {
+	k := resultKeys
+	return viewHelp{
+		short: []key.Binding{k.Up, k.Down, k.Expand, k.Help, k.Quit},
+		full:  [][]key.Binding{{k.Up, k.Down}, {k.Expand, k.ExpandAll, k.Retry}, {k.Help, k.Quit}},
+	}
+}
+
+func (m changesModel) View() string {
+	if m.loading {
+		return "📊 Analyzing changes..."
+	}
+	if m.err != nil {
+		return fmt.Sprintf("Error: %v\n\nPress 'q' to quit.", m.err)
+	}
+	if len(m.files) == 0 {
+		return fmt.Sprintf("✨ %s\n\nPress 'q' to quit.", m.opts.noChanges())
+	}
+	
+	var content strings.Builder
+	content.WriteString(headerStyle.Render(fmt.Sprintf("📊 %d changed files on %s (%s)", len(m.files), m.report.Branch, m.opts.scope())))
+	content.WriteString("\n")
+	content.WriteString(impactSummary(m.report))
+	content.WriteString("\n")
+	content.WriteString(m.viewport.View())
+	content.WriteString("\n")
+	content.WriteString(m.help.View(m.helpKeys()))
+	return content.String()
+}
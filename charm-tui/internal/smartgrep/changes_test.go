@@ -0,0 +1,147 @@
+package smartgrep
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParseChanges(t *testing.T) {
+	output := "\x1b[2m📚 Loading semantic index...\x1b[0m\n" + `{
+  "branch": "main",
+  "files": [
+    {"status": "M", "path": "src/auth.ts"},
+    {"status": "A", "path": "src/new.ts"}
+  ],
+  "totalImpact": 12,
+  "highImpactSymbols": [{"symbol": "login", "file": "src/auth.ts", "uses": 9}]
+}
+`
+	report, err := parseChanges([]byte(output))
+	if err != nil {
+		t.Fatalf("parseChanges: %v", err)
+	}
+	if report.Branch != "main" || len(report.Files) != 2 || report.TotalImpact != 12 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+	
+	if _, err := parseChanges([]byte("✨ No changes in working directory\n")); err == nil {
+		t.Error("expected an error when the output has no JSON")
+	}
+}
+
+const sampleDiff = `diff --git a/src/auth.ts b/src/auth.ts
+index 1111111..2222222 100644
+--- a/src/auth.ts
++++ b/src/auth.ts
+@@ -1,3 +1,3 @@
+ import x
+--- a/removed comment
++export function login() {}
+diff --git a/old.ts b/old.ts
+deleted file mode 100644
+--- a/old.ts
++++ /dev/null
+@@ -1 +0,0 @@
+-gone
+`
+
+func TestSplitDiff(t *testing.T) {
+	files := splitDiff(sampleDiff)
+	if len(files) != 2 {
+		t.Fatalf("got %d files, want 2: %v", len(files), files)
+	}
+	
+	auth := files["src/auth.ts"]
+	if len(auth) != 4 || auth[0] != "@@ -1,3 +1,3 @@" || auth[2] != "--- a/removed comment" {
+		t.Errorf("src/auth.ts hunk = %q", auth)
+	}
+	if deleted := files["old.ts"]; len(deleted) != 2 || deleted[1] != "-gone" {
+		t.Errorf("deleted file hunk = %q", deleted)
+	}
+}
+
+func TestRenderDiffTruncates(t *testing.T) {
+	lines := make([]string, maxDiffLines+5)
+	for i := range lines {
+		lines[i] = "+line"
+	}
+	rendered := renderDiff(lines)
+	if !strings.Contains(rendered, "5 more lines") {
+		t.Errorf("long diff not truncated:\n%s", rendered)
+	}
+}
+
+func TestChangesNavigation(t *testing.T) {
+	report := changesReport{
+		Branch:            "main",
+		Files:             []fileChange{{"D", "old.ts"}, {"M", "src/auth.ts"}},
+		HighImpactSymbols: []impactSymbol{{Symbol: "login", File: "src/auth.ts", Uses: 9}},
+	}
+	files := changedFiles(report, splitDiff(sampleDiff))
+	if len(files[1].symbols) != 1 || len(files[0].symbols) != 0 {
+		t.Fatalf("symbols not grouped by file: %+v", files)
+	}
+	
+	var m tea.Model = newChangesModel(ChangesOptions{})
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	m, _ = m.Update(changesLoadedMsg{report: report, files: files})
+	press := func(msg tea.KeyMsg) changesModel {
+		m, _ = m.Update(msg)
+		return m.(changesModel)
+	}
+	
+	cm := press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if cm.cursor != 1 {
+		t.Fatalf("cursor = %d, want 1", cm.cursor)
+	}
+	if strings.Contains(cm.viewport.View(), "export function login") {
+		t.Fatal("files should start collapsed")
+	}
+	
+	cm = press(tea.KeyMsg{Type: tea.KeyEnter})
+	view := cm.viewport.View()
+	if !cm.expanded[1] || !strings.Contains(view, "export function login") || !strings.Contains(view, "login (9 uses)") {
+		t.Errorf("expanded file missing its diff or symbols:\n%s", view)
+	}
+	
+	cm = press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if !cm.expanded[0] || !cm.expanded[1] {
+		t.Errorf("expanded = %v, want all expanded", cm.expanded)
+	}
+	cm = press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if cm.expanded[0] || cm.expanded[1] {
+		t.Errorf("expanded = %v, want all collapsed", cm.expanded)
+	}
+}
+
+func TestImpactSummary(t *testing.T) {
+	tests := []struct {
+		report changesReport
+		want   []string
+	}{
+		{changesReport{}, []string{"No risk", "0 affected symbols", "0 references"}},
+		{changesReport{TotalImpact: 4, AffectedSymbols: 1}, []string{"Low risk", "1 affected symbol •", "4 references"}},
+		{
+			changesReport{TotalImpact: 30, AffectedSymbols: 5, HighImpactSymbols: []impactSymbol{
+				{Symbol: "parse", File: "a.ts", Uses: 8},
+				{Symbol: "login", File: "src/auth.ts", Uses: 21},
+			}},
+			[]string{"Medium risk", "5 affected symbols", "login in src/auth.ts (21 references)"},
+		},
+		// Older CLIs report only the heavily used symbols
+		{
+			changesReport{TotalImpact: 80, HighImpactSymbols: []impactSymbol{{Symbol: "run", File: "cli.ts", Uses: 80}}},
+			[]string{"High risk", "1 affected symbol •", "80 references"},
+		},
+	}
+	for _, tt := range tests {
+		summary := impactSummary(tt.report)
+		for _, want := range tt.want {
+			if !strings.Contains(summary, want) {
+				t.Errorf("impactSummary(%+v) = %q, missing %q", tt.report, summary, want)
+			}
+		}
+	}
+}
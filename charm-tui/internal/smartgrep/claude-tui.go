@@ -1,15 +1,26 @@
 package smartgrep
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/style"
 )
 
 // Enhanced styles for Claude-optimized display
@@ -17,9 +28,9 @@ var (
 	// Title styles
 	mainTitleStyle = lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("212")).
+		Foreground(style.Colors().Title).
 		BorderStyle(lipgloss.DoubleBorder()).
-		BorderForeground(lipgloss.Color("212")).
+		BorderForeground(style.Colors().Title).
 		Padding(1, 3).
 		MarginBottom(1).
 		Align(lipgloss.Center)
@@ -27,47 +38,47 @@ var (
 	// Section styles
 	sectionStyle = lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("33")).
+		Foreground(style.Colors().Accent).
 		BorderStyle(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("33")).
+		BorderForeground(style.Colors().Accent).
 		Padding(0, 2).
 		MarginTop(1)
 		
 	// Code styles
 	codeStyle = lipgloss.NewStyle().
-		Background(lipgloss.Color("236")).
-		Foreground(lipgloss.Color("252")).
+		Background(style.Colors().Surface).
+		Foreground(style.Colors().Text).
 		Padding(0, 1)
 		
 	signatureStyle = lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("120"))
+		Foreground(style.Colors().Added)
 		
 	// Metadata styles
 	metaStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("244")).
+		Foreground(style.Colors().Meta).
 		Italic(true)
 		
 	scoreStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("220"))
+		Foreground(style.Colors().Modified)
 		
 	// Reference styles
 	refCallStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("120"))
+		Foreground(style.Colors().Added)
 		
 	refImportStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("33"))
+		Foreground(style.Colors().Accent)
 		
 	refExtendsStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("212"))
+		Foreground(style.Colors().Title)
 		
 	// Graph styles
 	graphNodeStyle = lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("212"))
+		Foreground(style.Colors().Title)
 		
 	graphEdgeStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("244"))
+		Foreground(style.Colors().Meta)
 )
 
 // Enhanced result display model
@@ -78,9 +89,64 @@ type resultViewModel struct {
 	progress   progress.Model
 	width      int
 	height     int
-	activeView string // "list", "detail", "graph", "stats"
+	activeView string // "list", "detail", "graph", "stats", "bookmarks"
+	graphLayout string // "flat" or "tree"
 	selected   int
 	renderer   *glamour.TermRenderer
+	wrapWidth  int // Width the renderer currently wraps at
+	focus      string // File that results are re-ranked around
+	minRelevance float64 // Results below these thresholds are dropped
+	minUsage     int
+	sortBy       string // Result order, as for --sort
+	sortDesc     bool   // Reverse the sort order
+	maxResults   int    // Most results kept, 0 for no limit
+	compact      bool   // Hide the score and usage columns
+	relativePaths bool  // Show paths relative to the project root
+	split        bool   // Show the selected result's detail beside the list
+	
+	// Watch mode: re-running the search when project files change
+	watching       bool
+	watchGen       int       // Incremented whenever watching is toggled
+	watchSeen      uint64    // Project fingerprint at the last check
+	watchPending   bool      // A change is waiting to be searched again
+	watchReplace   bool      // The next batch replaces the results shown
+	watchRefreshed time.Time // When the last re-run started
+	blame      map[string]blameInfo // Cached git blame per file:line
+	marked     []string             // Multi-selected result keys, in mark order
+	loading    bool                 // Results are still streaming in
+	loadErr    error
+	capped     bool                 // The search stopped at maxStreamResults
+	ctx        context.Context      // Cancelled when the view quits, stopping its search
+	cancel     context.CancelFunc
+	searchArgs []string             // CLI arguments of the search being shown, for retries
+	spinner    spinner.Model
+	help       help.Model
+	title      string // Describes where the results came from, e.g. a batch run
+	query      string // Pattern searched for, when the results are a single search
+	backToMenu bool   // Quit to the search menu rather than exiting
+	selection  *resultSelection // Result to open once loaded, from --select
+	notice     string // Status line, e.g. editor, clipboard or export results
+	
+	// List filtering
+	filterInput textinput.Model
+	filtering   bool
+	filter      string
+	hiddenTypes map[string]bool // Result types toggled off with the number keys
+	visible     []int // Indices into results of the rows that pass the filter
+	pageStart   int   // Index into visible of the first table row
+	
+	// Export popup
+	exporting    bool
+	exportCursor int
+	
+	// Stats view file selection
+	statFiles     []fileStat // Files listed in the stats view, in display order
+	statCursor    int
+	statFilesLine int // Viewport line of the first listed file
+	
+	// Bookmarks of the current project
+	bookmarks      []searchResult
+	bookmarkCursor int
 }
 
 type searchResult struct {
@@ -105,16 +171,68 @@ type location struct {
 
 type reference struct {
 	typ      string
+	target   string // Term being referenced
 	from     location
 	context  string
 }
 
+// MarshalJSON encodes a result as a flat object for exports: the CLI's info
+// fields (term, type, location, ...) sit next to relevanceScore, usageCount
+// and the fetched references rather than under "info"
+func (r searchResult) MarshalJSON() ([]byte, error) {
+	type jsonLocation struct {
+		File   string `json:"file"`
+		Line   int    `json:"line"`
+		Column int    `json:"column"`
+	}
+	type jsonReference struct {
+		TargetTerm   string       `json:"targetTerm,omitempty"`
+		Type         string       `json:"referenceType"`
+		FromLocation jsonLocation `json:"fromLocation"`
+		Context      string       `json:"context"`
+	}
+	
+	refs := make([]jsonReference, 0, len(r.references))
+	for _, ref := range r.references {
+		refs = append(refs, jsonReference{
+			TargetTerm:   ref.target,
+			Type:         ref.typ,
+			FromLocation: jsonLocation{ref.from.file, ref.from.line, ref.from.column},
+			Context:      ref.context,
+		})
+	}
+	
+	return json.Marshal(struct {
+		Term             string                 `json:"term"`
+		Type             string                 `json:"type"`
+		Location         jsonLocation           `json:"location"`
+		Context          string                 `json:"context"`
+		SurroundingLines []string               `json:"surroundingLines,omitempty"`
+		RelatedTerms     []string               `json:"relatedTerms,omitempty"`
+		Language         string                 `json:"language"`
+		RelevanceScore   float64                `json:"relevanceScore"`
+		UsageCount       int                    `json:"usageCount"`
+		References       []jsonReference        `json:"references"`
+		Metadata         map[string]interface{} `json:"metadata,omitempty"`
+	}{
+		Term:             r.term,
+		Type:             r.typ,
+		Location:         jsonLocation{r.location.file, r.location.line, r.location.column},
+		Context:          r.context,
+		SurroundingLines: r.surrounding,
+		RelatedTerms:     r.related,
+		Language:         r.language,
+		RelevanceScore:   r.relevance,
+		UsageCount:       r.usageCount,
+		References:       refs,
+		Metadata:         r.metadata,
+	})
+}
+
 func newResultViewModel() resultViewModel {
 	// Create glamour renderer for markdown
-	renderer, _ := glamour.NewTermRenderer(
-		glamour.WithAutoStyle(),
-		glamour.WithWordWrap(80),
-	)
+	wrap := style.WordWrap()
+	renderer, _ := glamour.NewTermRenderer(style.GlamourOptions(wrap)...)
 	
 	// Create viewport
 	vp := viewport.New(80, 20)
@@ -123,16 +241,8 @@ func newResultViewModel() resultViewModel {
 	prog := progress.New(progress.WithDefaultGradient())
 	
 	// Create table for list view
-	columns := []table.Column{
-		{Title: "🎯 Term", Width: 20},
-		{Title: "📦 Type", Width: 10},
-		{Title: "📍 Location", Width: 30},
-		{Title: "📈 Score", Width: 8},
-		{Title: "🔢 Uses", Width: 8},
-	}
-	
 	tbl := table.New(
-		table.WithColumns(columns),
+		table.WithColumns(resultColumns(false)),
 		table.WithFocused(true),
 		table.WithHeight(15),
 	)
@@ -140,28 +250,90 @@ func newResultViewModel() resultViewModel {
 	s := table.DefaultStyles()
 	s.Header = s.Header.
 		BorderStyle(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color("240")).
+		BorderForeground(style.Colors().Border).
 		BorderBottom(true).
 		Bold(false)
 	s.Selected = s.Selected.
-		Foreground(lipgloss.Color("229")).
-		Background(lipgloss.Color("57")).
+		Foreground(style.Colors().Selection).
+		Background(style.Colors().Selected).
 		Bold(false)
 	tbl.SetStyles(s)
 	
+	// Space marks results for comparison instead of paging
+	tbl.KeyMap.PageDown = key.NewBinding(
+		key.WithKeys("f", "pgdown"),
+		key.WithHelp("f/pgdn", "page down"),
+	)
+	
+	ctx, cancel := context.WithCancel(context.Background())
 	return resultViewModel{
-		viewport:   vp,
-		table:      tbl,
-		progress:   prog,
-		activeView: "list",
-		renderer:   renderer,
+		ctx:         ctx,
+		cancel:      cancel,
+		viewport:    vp,
+		table:       tbl,
+		progress:    prog,
+		activeView:  "list",
+		graphLayout: "flat",
+		renderer:    renderer,
+		wrapWidth:   wrap,
+		blame:       make(map[string]blameInfo),
+		help:        help.New(),
+		filterInput: newFilterInput(),
+		spinner:     style.NewSpinner(style.Colors().Title),
+		bookmarks:   loadProjectBookmarks(),
+		relativePaths: config.GetPathDisplay() == config.PathsRelative,
 	}
 }
 
 func (m resultViewModel) Init() tea.Cmd {
+	if m.loading && m.searchArgs != nil {
+		cmds := []tea.Cmd{style.SpinnerTick(m.spinner), searchResultsCmd(m.ctx, m.searchArgs)}
+		if m.watching {
+			cmds = append(cmds, watchProject(m.watchGen))
+		}
+		return tea.Batch(cmds...)
+	}
 	return nil
 }
 
+// canRetry reports whether the search failed and can be run again
+func (m resultViewModel) canRetry() bool {
+	// r finds references in the detail pane
+	return m.loadErr != nil && !m.loading && m.searchArgs != nil && m.activeView != "detail"
+}
+
+// capturesEsc reports whether Esc means something to the view itself: it
+// closes the filter or export popup, clears the filter, or returns to the
+// list. Models that embed the view only treat Esc as back when it doesn't.
+func (m resultViewModel) capturesEsc() bool {
+	if m.filtering || m.exporting {
+		return true
+	}
+	switch m.activeView {
+	case "detail", "compare":
+		return true
+	case "list":
+		return m.filter != ""
+	}
+	return false
+}
+
+// retry clears the failed search and runs it again
+func (m resultViewModel) retry() (resultViewModel, tea.Cmd) {
+	m.loading = true
+	m.loadErr = nil
+	m.capped = false
+	m.notice = ""
+	m.setResults(nil)
+	return m, tea.Batch(style.SpinnerTick(m.spinner), searchResultsCmd(m.ctx, m.searchArgs))
+}
+
+// quit stops a search still streaming in and exits
+func (m resultViewModel) quit() (tea.Model, tea.Cmd) {
+	m.cancel()
+	return m, tea.Quit
+}
+
 // Ensure we implement tea.Model
 var _ tea.Model = resultViewModel{}
 
@@ -172,14 +344,222 @@ func (m resultViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		
 		// Update component sizes
-		m.viewport.Width = msg.Width - 4
-		m.viewport.Height = msg.Height - 10
-		m.table.SetWidth(msg.Width - 4)
-		m.table.SetHeight(msg.Height / 2)
+		m.resize()
+		m.help.Width = msg.Width
+		
+		// Re-wrap markdown for the new width
+		if wrap := style.MarkdownWidth(m.viewport.Width); wrap != m.wrapWidth {
+			if renderer, err := glamour.NewTermRenderer(style.GlamourOptions(wrap)...); err == nil {
+				m.renderer = renderer
+				m.wrapWidth = wrap
+			}
+		}
+		m.refreshView()
+		
+	case resultsBatchMsg:
+		if m.watchReplace {
+			m.applyWatchResults(msg.results)
+		} else {
+			m.setResults(append(m.results, msg.results...))
+		}
+		return m, waitForResults(msg.next)
+		
+	case spinner.TickMsg:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+		
+	case resultsDoneMsg:
+		m.loading = false
+		m.loadErr = msg.err
+		m.capped = msg.capped
+		if m.watchReplace && msg.err == nil {
+			// The re-run found nothing
+			m.applyWatchResults(nil)
+		}
+		m.watchReplace = false
+		m.applySelection()
+		return m, m.requestBlame()
+		
+	case watchTickMsg:
+		return m, m.updateWatch(msg)
+		
+	case editorDoneMsg:
+		if msg.err != nil {
+			m.notice = fmt.Sprintf("⚠️  Editor exited: %v", msg.err)
+		}
+		m.refreshView()
+		return m, nil
+		
+	case termRefsMsg:
+		m.applyTermRefs(msg)
+		switch m.activeView {
+		case "detail":
+			m.updateDetailView()
+		case "graph":
+			m.updateGraphView()
+		}
+		return m, nil
+		
+	case blameMsg:
+		m.blame[msg.key] = msg.info
+		if m.activeView == "detail" {
+			m.updateDetailView()
+		}
+		return m, nil
 		
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "tab":
+		if m.filtering {
+			return m.updateFilter(msg)
+		}
+		if m.exporting {
+			return m.updateExport(msg)
+		}
+		if m.canRetry() && key.Matches(msg, resultKeys.Retry) {
+			return m.retry()
+		}
+		if m.noMatches() || m.searchFailed() {
+			switch {
+			case key.Matches(msg, resultKeys.Back):
+				m.backToMenu = true
+				return m.quit()
+			case key.Matches(msg, resultKeys.Quit):
+				return m.quit()
+			}
+			return m, nil
+		}
+		
+		switch {
+		case key.Matches(msg, resultKeys.Quit):
+			return m.quit()
+			
+		case m.activeView == "list" && key.Matches(msg, resultKeys.Sort):
+			m.cycleSort(false)
+			return m, nil
+			
+		case m.activeView == "list" && key.Matches(msg, resultKeys.Reverse):
+			m.cycleSort(true)
+			return m, nil
+			
+		case (m.activeView == "list" || m.activeView == "stats") && key.Matches(msg, resultKeys.Types):
+			m.toggleType(int(msg.Runes[0] - '0'))
+			return m, nil
+			
+		case m.activeView == "stats" && key.Matches(msg, resultKeys.Up):
+			m.moveStatCursor(-1)
+			return m, nil
+			
+		case m.activeView == "stats" && key.Matches(msg, resultKeys.Down):
+			m.moveStatCursor(1)
+			return m, nil
+			
+		case m.activeView == "stats" && key.Matches(msg, resultKeys.OpenFile):
+			return m, m.openStatFile()
+			
+		case m.activeView == "bookmarks" && key.Matches(msg, resultKeys.Up):
+			m.moveBookmarkCursor(-1)
+			return m, nil
+			
+		case m.activeView == "bookmarks" && key.Matches(msg, resultKeys.Down):
+			m.moveBookmarkCursor(1)
+			return m, nil
+			
+		case m.activeView == "bookmarks" && key.Matches(msg, resultKeys.OpenFile):
+			m.notice = ""
+			return m, m.openBookmark()
+			
+		case key.Matches(msg, resultKeys.Bookmark):
+			switch {
+			case m.activeView == "bookmarks" && m.bookmarkCursor < len(m.bookmarks):
+				m.toggleBookmark(m.bookmarks[m.bookmarkCursor])
+				m.updateBookmarksView()
+			case (m.activeView == "list" || m.activeView == "detail") && m.selected < len(m.results):
+				m.toggleBookmark(m.results[m.selected])
+				if m.activeView == "detail" {
+					m.updateDetailView()
+				}
+			}
+			return m, nil
+			
+		case key.Matches(msg, resultKeys.Open):
+			if m.activeView == "detail" {
+				m.notice = ""
+				return m, m.openInEditor()
+			}
+			
+		case key.Matches(msg, resultKeys.Refs):
+			if m.activeView == "detail" && m.selected < len(m.results) {
+				result := m.results[m.selected]
+				m.notice = fmt.Sprintf("🔍 Finding references to %s...", result.term)
+				m.updateDetailView()
+				return m, fetchTermReferences(result)
+			}
+			
+		case key.Matches(msg, resultKeys.Layout):
+			if m.activeView == "graph" {
+				if m.graphLayout == "tree" {
+					m.graphLayout = "flat"
+				} else {
+					m.graphLayout = "tree"
+				}
+				m.updateGraphView()
+				return m, nil
+			}
+			
+		case key.Matches(msg, resultKeys.Watch):
+			m.notice = ""
+			cmd := m.toggleWatch()
+			m.refreshView()
+			return m, cmd
+			
+		case m.activeView == "list" && key.Matches(msg, resultKeys.Split):
+			m.notice = ""
+			m.toggleSplit()
+			return m, nil
+			
+		case (m.activeView == "list" || m.activeView == "detail") && key.Matches(msg, resultKeys.Compact):
+			m.toggleCompact()
+			return m, nil
+			
+		case (m.activeView == "list" || m.activeView == "detail") && key.Matches(msg, resultKeys.Paths):
+			m.togglePaths()
+			return m, nil
+			
+		case key.Matches(msg, resultKeys.Copy):
+			if m.activeView == "detail" && m.selected < len(m.results) {
+				m.copyToClipboard(locationText(m.results[m.selected]), "location")
+				return m, nil
+			}
+			
+		case key.Matches(msg, resultKeys.CopySig):
+			if m.activeView == "detail" && m.selected < len(m.results) {
+				m.copyToClipboard(signatureText(m.results[m.selected]), "signature")
+				return m, nil
+			}
+			
+		case key.Matches(msg, resultKeys.Filter):
+			if m.activeView == "list" {
+				m.filtering = true
+				m.filterInput.SetValue(m.filter)
+				return m, m.filterInput.Focus()
+			}
+			
+		case key.Matches(msg, resultKeys.Export):
+			if m.activeView == "list" {
+				m.exporting = true
+				m.notice = ""
+				return m, nil
+			}
+			
+		case key.Matches(msg, resultKeys.Help):
+			m.help.ShowAll = !m.help.ShowAll
+			return m, nil
+			
+		case key.Matches(msg, resultKeys.NextView):
+			m.notice = ""
 			// Cycle through views
 			switch m.activeView {
 			case "list":
@@ -192,14 +572,55 @@ func (m resultViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.activeView = "stats"
 				m.updateStatsView()
 			case "stats":
+				m.activeView = "bookmarks"
+				m.updateBookmarksView()
+			case "bookmarks", "compare":
 				m.activeView = "list"
 			}
 			
-		case "enter":
-			if m.activeView == "list" && len(m.results) > 0 {
+		case key.Matches(msg, resultKeys.Mark):
+			if m.activeView == "list" {
+				m.toggleMark()
+				return m, nil
+			}
+			
+		case key.Matches(msg, resultKeys.Compare):
+			if m.activeView == "list" || m.activeView == "detail" {
+				m.activeView = "compare"
+				m.updateCompareView()
+				return m, nil
+			}
+			
+		case key.Matches(msg, resultKeys.Back):
+			if m.activeView == "compare" || m.activeView == "detail" {
+				m.activeView = "list"
+				return m, nil
+			}
+			if m.activeView == "list" && m.filter != "" {
+				m.filterInput.SetValue("")
+				m.setFilter("")
+				return m, nil
+			}
+			
+		case key.Matches(msg, resultKeys.Details):
+			if m.activeView == "list" && m.selected < len(m.results) {
 				m.activeView = "detail"
 				m.updateDetailView()
 			}
+			
+		case key.Matches(msg, resultKeys.Focus):
+			// Re-center exploration around the selected result's file
+			if (m.activeView == "list" || m.activeView == "detail") && m.selected < len(m.results) {
+				m.focus = m.results[m.selected].location.file
+				m.pageStart = 0
+				m.setResults(m.results)
+				m.table.SetCursor(0)
+				m.syncSelected()
+				if m.activeView == "detail" {
+					m.updateDetailView()
+				}
+				return m, nil
+			}
 		}
 	}
 	
@@ -208,49 +629,158 @@ func (m resultViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch m.activeView {
 	case "list":
 		m.table, cmd = m.table.Update(msg)
-		if m.table.Cursor() != m.selected {
-			m.selected = m.table.Cursor()
-		}
-	case "detail", "graph", "stats":
+		m.syncSelected()
+		m.slidePage()
+	case "detail", "graph", "stats", "bookmarks", "compare":
 		m.viewport, cmd = m.viewport.Update(msg)
 	}
 	
+	if blameCmd := m.requestBlame(); blameCmd != nil {
+		return m, tea.Batch(cmd, blameCmd)
+	}
 	return m, cmd
 }
 
+// requestBlame lazily starts a git blame for the selected result in the detail view
+func (m *resultViewModel) requestBlame() tea.Cmd {
+	if !blameEnabled || m.activeView != "detail" || m.selected >= len(m.results) {
+		return nil
+	}
+	
+	result := m.results[m.selected]
+	key := blameKey(result.location)
+	if _, ok := m.blame[key]; ok {
+		return nil
+	}
+	m.blame[key] = blameInfo{loading: true}
+	m.updateDetailView()
+	return fetchBlame(result)
+}
+
+// refreshView re-renders the active viewport-backed view, e.g. after a resize
+func (m *resultViewModel) refreshView() {
+	switch m.activeView {
+	case "detail":
+		m.updateDetailView()
+	case "graph":
+		m.updateGraphView()
+	case "stats":
+		m.updateStatsView()
+	case "bookmarks":
+		m.updateBookmarksView()
+	case "compare":
+		m.updateCompareView()
+	}
+}
+
+// setResults stores the results, applying the thresholds and focus re-rank,
+// and refreshes the table
+func (m *resultViewModel) setResults(results []searchResult) {
+	results = applyThresholds(results, m.minRelevance, m.minUsage)
+	results = sortResults(results, m.sortBy, m.sortDesc)
+	if m.focus != "" {
+		results = rankByFocus(results, m.focus)
+	}
+	if m.maxResults > 0 && len(results) > m.maxResults {
+		results = results[:m.maxResults]
+	}
+	m.results = results
+	m.refreshRows()
+}
+
+// refreshRows rebuilds the table rows for the current page of results
+// matching the filter
+func (m *resultViewModel) refreshRows() {
+	m.visible = filteredIndices(m.results, m.filter, m.hiddenTypes)
+	m.pageStart = clampPageStart(m.pageStart, len(m.visible))
+	start, end := m.pageBounds()
+	rows := make([]table.Row, 0, end-start)
+	for _, i := range m.visible[start:end] {
+		r := m.results[i]
+		term := r.term
+		if m.isBookmarked(r) {
+			term = "★ " + term
+		}
+		if m.isMarked(r) {
+			term = "● " + term
+		}
+		where := fmt.Sprintf("%s:%d", displayPath(r.location.file, m.relativePaths), r.location.line)
+		if m.splitActive() {
+			rows = append(rows, table.Row{term, where})
+			continue
+		}
+		row := table.Row{term, r.typ, where}
+		if !m.compact {
+			row = append(row, fmt.Sprintf("%.0f%%", r.relevance*100), fmt.Sprintf("%d", r.usageCount))
+		}
+		rows = append(rows, row)
+	}
+	m.table.SetRows(rows)
+	m.syncSelected()
+}
+
 func (m *resultViewModel) updateDetailView() {
 	if m.selected >= len(m.results) {
 		return
 	}
 	
 	result := m.results[m.selected]
+	file := displayPath(result.location.file, m.relativePaths)
+	titleStyle, sectionStyle, gap := m.detailStyles()
 	var content strings.Builder
 	
+	if m.notice != "" {
+		content.WriteString(refExtendsStyle.Render(m.notice))
+		content.WriteString("\n\n")
+	}
+	
 	// Title
-	content.WriteString(mainTitleStyle.Render(fmt.Sprintf("🎯 %s", result.term)))
-	content.WriteString("\n\n")
+	content.WriteString(titleStyle.Render(fmt.Sprintf("🎯 %s", result.term)))
+	content.WriteString("\n" + gap)
 	
 	// Location and metadata
 	content.WriteString(sectionStyle.Render("📍 Location"))
 	content.WriteString("\n")
-	content.WriteString(fmt.Sprintf("📂 File: %s\n", result.location.file))
-	content.WriteString(fmt.Sprintf("📏 Line %d, Column %d\n", result.location.line, result.location.column))
-	content.WriteString(fmt.Sprintf("🔤 Language: %s\n", result.language))
-	content.WriteString(scoreStyle.Render(fmt.Sprintf("📈 Relevance: %.1f%%\n", result.relevance*100)))
-	content.WriteString(fmt.Sprintf("🔢 Usage Count: %d\n", result.usageCount))
+	if m.compact {
+		content.WriteString(fmt.Sprintf("📂 %s:%d:%d • %s • ", file, result.location.line, result.location.column, result.language))
+		content.WriteString(scoreStyle.Render(fmt.Sprintf("📈 %.0f%%", result.relevance*100)))
+		content.WriteString(fmt.Sprintf(" • 🔢 %d\n", result.usageCount))
+	} else {
+		content.WriteString(fmt.Sprintf("📂 File: %s\n", file))
+		content.WriteString(fmt.Sprintf("📏 Line %d, Column %d\n", result.location.line, result.location.column))
+		content.WriteString(fmt.Sprintf("🔤 Language: %s\n", result.language))
+		content.WriteString(scoreStyle.Render(fmt.Sprintf("📈 Relevance: %.1f%%\n", result.relevance*100)))
+		content.WriteString(fmt.Sprintf("🔢 Usage Count: %d\n", result.usageCount))
+	}
+	if blameEnabled {
+		if info, ok := m.blame[blameKey(result.location)]; ok {
+			content.WriteString(renderBlame(info))
+		}
+	}
 	
 	// Code context with syntax highlighting
-	content.WriteString("\n")
+	content.WriteString(gap)
 	content.WriteString(sectionStyle.Render("📄 Code Context"))
 	content.WriteString("\n")
 	
 	// Show surrounding lines
 	if len(result.surrounding) > 0 {
-		for i, line := range result.surrounding {
-			lineNum := result.location.line - len(result.surrounding)/2 + i
-			if lineNum == result.location.line {
+		lines, offset := result.surrounding, -len(result.surrounding)/2
+		if m.compact {
+			lines, offset = trimSurrounding(lines, compactContextRadius)
+		}
+		highlighted := highlightLines(lines, result.language)
+		for i, line := range lines {
+			lineNum := result.location.line + offset + i
+			switch {
+			case highlighted != nil && lineNum == result.location.line:
+				// Mark the result's line in the gutter so the colors stay intact
+				content.WriteString(signatureStyle.Render(fmt.Sprintf("▶%3d: ", lineNum)) + highlighted[i] + "\n")
+			case highlighted != nil:
+				content.WriteString(metaStyle.Render(fmt.Sprintf("%4d: ", lineNum)) + highlighted[i] + "\n")
+			case lineNum == result.location.line:
 				content.WriteString(signatureStyle.Render(fmt.Sprintf("%4d: %s\n", lineNum, line)))
-			} else {
+			default:
 				content.WriteString(codeStyle.Render(fmt.Sprintf("%4d: %s\n", lineNum, line)))
 			}
 		}
@@ -261,7 +791,7 @@ func (m *resultViewModel) updateDetailView() {
 	// Function signature extraction
 	if result.typ == "function" || result.typ == "class" {
 		if sig := extractSignature(result); sig != "" {
-			content.WriteString("\n")
+			content.WriteString(gap)
 			content.WriteString(sectionStyle.Render("🔧 Signature"))
 			content.WriteString("\n")
 			content.WriteString(signatureStyle.Render(sig))
@@ -271,7 +801,7 @@ func (m *resultViewModel) updateDetailView() {
 	
 	// Related terms
 	if len(result.related) > 0 {
-		content.WriteString("\n")
+		content.WriteString(gap)
 		content.WriteString(sectionStyle.Render("🔗 Related Terms"))
 		content.WriteString("\n")
 		content.WriteString(strings.Join(result.related, ", "))
@@ -280,35 +810,20 @@ func (m *resultViewModel) updateDetailView() {
 	
 	// References with beautiful formatting
 	if len(result.references) > 0 {
-		content.WriteString("\n")
+		content.WriteString(gap)
 		content.WriteString(sectionStyle.Render(fmt.Sprintf("📍 All References (%d)", len(result.references))))
 		content.WriteString("\n")
 		
-		// Group by type
-		refsByType := make(map[string][]reference)
-		for _, ref := range result.references {
-			refsByType[ref.typ] = append(refsByType[ref.typ], ref)
-		}
-		
-		for refType, refs := range refsByType {
-			style := getRefStyle(refType)
-			icon := getRefIcon(refType)
-			content.WriteString(fmt.Sprintf("\n%s %s (%d):\n", icon, refType, len(refs)))
-			
-			for i, ref := range refs {
-				if i >= 10 && len(refs) > 10 {
-					content.WriteString(metaStyle.Render(fmt.Sprintf("   ... and %d more\n", len(refs)-10)))
-					break
-				}
-				content.WriteString(style.Render(fmt.Sprintf("   %s:%d\n", ref.from.file, ref.from.line)))
-				content.WriteString(codeStyle.Render(fmt.Sprintf("      %s\n", ref.context)))
-			}
+		limit := detailRefLimit
+		if m.compact {
+			limit = compactRefLimit
 		}
+		content.WriteString(renderReferences(result.references, limit, m.relativePaths))
 	}
 	
-	// Metadata
-	if len(result.metadata) > 0 {
-		content.WriteString("\n")
+	// Metadata is left out of the compact view
+	if len(result.metadata) > 0 && !m.compact {
+		content.WriteString(gap)
 		content.WriteString(sectionStyle.Render("📊 Metadata"))
 		content.WriteString("\n")
 		for key, value := range result.metadata {
@@ -319,6 +834,39 @@ func (m *resultViewModel) updateDetailView() {
 	m.viewport.SetContent(content.String())
 }
 
+// renderReferences lists references grouped by type, showing at most limit
+// per group
+func renderReferences(refs []reference, limit int, relative bool) string {
+	var content strings.Builder
+	
+	// Group by type
+	refsByType := make(map[string][]reference)
+	var order []string
+	for _, ref := range refs {
+		if _, ok := refsByType[ref.typ]; !ok {
+			order = append(order, ref.typ)
+		}
+		refsByType[ref.typ] = append(refsByType[ref.typ], ref)
+	}
+	
+	for _, refType := range order {
+		refs := refsByType[refType]
+		style := getRefStyle(refType)
+		icon := getRefIcon(refType)
+		content.WriteString(fmt.Sprintf("\n%s %s (%d):\n", icon, refType, len(refs)))
+		
+		for i, ref := range refs {
+			if i >= limit {
+				content.WriteString(metaStyle.Render(fmt.Sprintf("   ... and %d more\n", len(refs)-limit)))
+				break
+			}
+			content.WriteString(style.Render(fmt.Sprintf("   %s:%d\n", displayPath(ref.from.file, relative), ref.from.line)))
+			content.WriteString(codeStyle.Render(fmt.Sprintf("      %s\n", ref.context)))
+		}
+	}
+	return content.String()
+}
+
 func (m *resultViewModel) updateGraphView() {
 	var content strings.Builder
 	
@@ -326,37 +874,35 @@ func (m *resultViewModel) updateGraphView() {
 	content.WriteString("\n\n")
 	
 	// Build relationship graph from results
-	graph := make(map[string][]string)
-	for _, result := range m.results {
-		// Add related terms
-		for _, related := range result.related {
-			graph[result.term] = append(graph[result.term], related)
-		}
-		
-		// Add reference relationships
-		for _, ref := range result.references {
-			// Extract term from context (simplified)
-			parts := strings.Fields(ref.context)
-			if len(parts) > 0 {
-				graph[result.term] = append(graph[result.term], parts[0])
+	if m.graphLayout == "tree" {
+		for i, line := range renderGraphTree(m.results, m.query, graphTreeDepth) {
+			if i == 0 {
+				line = graphNodeStyle.Render(line)
 			}
+			content.WriteString(line)
+			content.WriteString("\n")
 		}
+		m.viewport.SetContent(content.String())
+		return
 	}
 	
-	// Visualize graph with beautiful formatting
-	for node, edges := range graph {
-		content.WriteString(graphNodeStyle.Render(node))
+	// Hubs first, with their in/out degree so they stand out
+	for _, node := range buildGraph(m.results) {
+		if node.degree() == 0 {
+			continue
+		}
+		content.WriteString(graphNodeStyle.Render(node.name))
+		content.WriteString(metaStyle.Render(fmt.Sprintf(" (in %d, out %d)", len(node.in), len(node.out))))
 		content.WriteString(graphEdgeStyle.Render(" → {"))
 		
-		uniqueEdges := unique(edges)
-		for i, edge := range uniqueEdges {
+		for i, edge := range node.out {
 			if i > 0 {
 				content.WriteString(", ")
 			}
 			content.WriteString(edge)
 			
-			if i >= 5 && len(uniqueEdges) > 6 {
-				content.WriteString(fmt.Sprintf(", ... +%d more", len(uniqueEdges)-6))
+			if i >= 5 && len(node.out) > 6 {
+				content.WriteString(fmt.Sprintf(", ... +%d more", len(node.out)-6))
 				break
 			}
 		}
@@ -370,71 +916,72 @@ func (m *resultViewModel) updateGraphView() {
 func (m *resultViewModel) updateStatsView() {
 	var content strings.Builder
 	
+	// Describe what the list shows, so filters and type toggles apply here too
+	results := m.visibleResults()
+	
+	if m.notice != "" {
+		content.WriteString(refExtendsStyle.Render(m.notice))
+		content.WriteString("\n\n")
+	}
+	
 	content.WriteString(mainTitleStyle.Render("📊 Search Statistics"))
+	if len(results) != len(m.results) {
+		content.WriteString(metaStyle.Render(fmt.Sprintf("  %d of %d results", len(results), len(m.results))))
+	}
 	content.WriteString("\n\n")
 	
 	// Type distribution
 	typeStats := make(map[string]int)
-	for _, r := range m.results {
+	for _, r := range results {
 		typeStats[r.typ]++
 	}
 	
 	content.WriteString(sectionStyle.Render("📈 Type Distribution"))
 	content.WriteString("\n")
 	
-	total := len(m.results)
+	total := len(results)
 	for typ, count := range typeStats {
 		percentage := float64(count) / float64(total) * 100
 		icon := getTypeIcon(typ)
 		bar := renderProgressBar(percentage, 30)
-		content.WriteString(fmt.Sprintf("%s %-12s %s %.1f%% (%d)\n", 
-			icon, typ, bar, percentage, count))
+		content.WriteString(fmt.Sprintf("%s %s %s %.1f%% (%d)\n", 
+			icon, padRight(typ, 12), bar, percentage, count))
 	}
 	
 	// File distribution
-	fileStats := make(map[string]int)
-	for _, r := range m.results {
-		fileStats[r.location.file]++
-	}
-	
 	content.WriteString("\n")
 	content.WriteString(sectionStyle.Render("📁 File Distribution"))
 	content.WriteString("\n")
 	
-	// Sort and show top files
-	type fileStat struct {
-		file  string
-		count int
+	files, fileCount := topFiles(results, 10)
+	m.statFiles = files
+	if m.statCursor >= len(files) {
+		m.statCursor = max(len(files)-1, 0)
 	}
-	var files []fileStat
-	for f, c := range fileStats {
-		files = append(files, fileStat{f, c})
-	}
-	// Simple sort by count
-	for i := range files {
-		for j := i + 1; j < len(files); j++ {
-			if files[j].count > files[i].count {
-				files[i], files[j] = files[j], files[i]
-			}
-		}
-	}
-	
+	m.statFilesLine = strings.Count(content.String(), "\n")
 	for i, fs := range files {
-		if i >= 10 {
-			content.WriteString(metaStyle.Render(fmt.Sprintf("\n... and %d more files", len(files)-10)))
-			break
-		}
 		percentage := float64(fs.count) / float64(total) * 100
 		bar := renderProgressBar(percentage, 20)
-		content.WriteString(fmt.Sprintf("%-40s %s %.1f%% (%d)\n", 
-			truncatePath(fs.file, 40), bar, percentage, fs.count))
+		name := padRight(style.TruncatePath(fs.file, 40), 40)
+		cursor := "  "
+		if i == m.statCursor {
+			cursor = "▶ "
+			name = graphNodeStyle.Render(name)
+		}
+		content.WriteString(fmt.Sprintf("%s%s %s %.1f%% (%d)\n", 
+			cursor, name, bar, percentage, fs.count))
+	}
+	if fileCount > len(files) {
+		content.WriteString(metaStyle.Render(fmt.Sprintf("\n... and %d more files", fileCount-len(files))))
 	}
 	
 	// Usage statistics
 	var totalUsage, maxUsage int
 	var withUsage int
-	for _, r := range m.results {
+	var usages []int
+	for _, r := range results {
 		if r.usageCount > 0 {
+			usages = append(usages, r.usageCount)
 			totalUsage += r.usageCount
 			withUsage++
 			if r.usageCount > maxUsage {
@@ -451,6 +998,8 @@ func (m *resultViewModel) updateStatsView() {
 		content.WriteString(fmt.Sprintf("• Average usage: %.1f\n", avgUsage))
 		content.WriteString(fmt.Sprintf("• Maximum usage: %d\n", maxUsage))
 		content.WriteString(fmt.Sprintf("• Items with usage data: %d/%d\n", withUsage, total))
+		spark := lipgloss.NewStyle().Foreground(style.Colors().Added).Render(renderSparkline(usages, 30))
+		content.WriteString(fmt.Sprintf("• Distribution: %s\n", spark))
 	}
 	
 	// Relevance distribution
@@ -458,18 +1007,12 @@ func (m *resultViewModel) updateStatsView() {
 	content.WriteString(sectionStyle.Render("📊 Relevance Distribution"))
 	content.WriteString("\n")
 	
-	relevanceBuckets := make(map[string]int)
-	for _, r := range m.results {
-		bucket := fmt.Sprintf("%d-%d%%", int(r.relevance*10)*10, int(r.relevance*10)*10+10)
-		relevanceBuckets[bucket]++
-	}
-	
-	buckets := []string{"90-100%", "80-90%", "70-80%", "60-70%", "50-60%", "40-50%", "30-40%", "20-30%", "10-20%", "0-10%"}
-	for _, bucket := range buckets {
+	relevanceBuckets := countRelevance(results)
+	for _, bucket := range relevanceBucketNames {
 		if count, ok := relevanceBuckets[bucket]; ok && count > 0 {
 			percentage := float64(count) / float64(total) * 100
 			bar := renderProgressBar(percentage, 20)
-			content.WriteString(fmt.Sprintf("%-10s %s %.1f%% (%d)\n", bucket, bar, percentage, count))
+			content.WriteString(fmt.Sprintf("%s %s %.1f%% (%d)\n", padRight(bucket, 10), bar, percentage, count))
 		}
 	}
 	
@@ -477,6 +1020,13 @@ func (m *resultViewModel) updateStatsView() {
 }
 
 func (m resultViewModel) View() string {
+	if m.noMatches() {
+		return m.emptyView()
+	}
+	if m.searchFailed() {
+		return m.errorView()
+	}
+	
 	var content strings.Builder
 	
 	// Header
@@ -486,21 +1036,80 @@ func (m resultViewModel) View() string {
 		tabStyle("Detail", m.activeView == "detail"),
 		tabStyle("Graph", m.activeView == "graph"),
 		tabStyle("Stats", m.activeView == "stats"),
+		tabStyle("Bookmarks", m.activeView == "bookmarks"),
 	)
+	if m.activeView == "compare" {
+		header = lipgloss.JoinHorizontal(lipgloss.Center, header, tabStyle("Compare", true))
+	}
 	
 	content.WriteString(lipgloss.PlaceHorizontal(m.width, lipgloss.Center, header))
 	content.WriteString("\n")
+	if m.activeView == "list" || m.activeView == "stats" {
+		content.WriteString(m.typeToggleView())
+	}
+	if m.activeView == "list" {
+		content.WriteString(lipgloss.PlaceHorizontal(m.width, lipgloss.Center, metaStyle.Render("↕ "+m.sortLabel())))
+		content.WriteString("\n")
+	}
 	
 	// Main content
 	switch m.activeView {
 	case "list":
-		content.WriteString(m.table.View())
-	case "detail", "graph", "stats":
+		content.WriteString(m.filterView())
+		if m.exporting {
+			content.WriteString(m.exportView())
+		} else if m.splitActive() {
+			content.WriteString(m.splitView())
+		} else {
+			content.WriteString(m.table.View())
+			if page := m.pageView(); page != "" {
+				content.WriteString("\n")
+				content.WriteString(metaStyle.Render(page))
+			}
+		}
+		if m.notice != "" {
+			content.WriteString("\n")
+			content.WriteString(metaStyle.Render(m.notice))
+		}
+	case "detail", "graph", "stats", "bookmarks", "compare":
 		content.WriteString(m.viewport.View())
 	}
 	
 	// Footer
-	footer := metaStyle.Render("Tab: switch view • Enter: details • ↑/↓: navigate • q: quit")
+	if m.title != "" {
+		content.WriteString("\n")
+		content.WriteString(metaStyle.Render(m.title))
+	}
+	if m.focus != "" {
+		content.WriteString("\n")
+		content.WriteString(metaStyle.Render("🎯 Focus: " + m.focus))
+	}
+	if m.loading {
+		label := fmt.Sprintf("Loading results... (%d so far)", len(m.results))
+		if m.query != "" {
+			label = fmt.Sprintf("Searching for %q... (%d so far)", m.query, len(m.results))
+		}
+		content.WriteString("\n")
+		content.WriteString(metaStyle.Render(style.Loading(m.spinner, label)))
+	} else if m.loadErr != nil {
+		content.WriteString("\n")
+		content.WriteString(refExtendsStyle.Render(fmt.Sprintf("Error: %v", m.loadErr)))
+		if m.canRetry() {
+			content.WriteString(metaStyle.Render(" • r to retry"))
+		}
+	} else if m.capped {
+		content.WriteString("\n")
+		content.WriteString(metaStyle.Render(fmt.Sprintf("⚠️  Showing the first %d results; narrow the search to see the rest", maxStreamResults)))
+	}
+	if watch := m.watchView(); watch != "" {
+		content.WriteString("\n")
+		content.WriteString(metaStyle.Render(watch))
+	}
+	if len(m.marked) > 0 {
+		content.WriteString("\n")
+		content.WriteString(metaStyle.Render(fmt.Sprintf("● %d marked", len(m.marked))))
+	}
+	footer := m.help.View(m.helpKeys())
 	content.WriteString("\n")
 	content.WriteString(footer)
 	
@@ -509,17 +1118,47 @@ func (m resultViewModel) View() string {
 
 // Helper functions
 
+type fileStat struct {
+	file  string
+	count int
+}
+
+// topFiles returns the n files with the most results, most first and ties
+// by name, along with the number of distinct files
+func topFiles(results []searchResult, n int) ([]fileStat, int) {
+	counts := make(map[string]int)
+	for _, r := range results {
+		counts[r.location.file]++
+	}
+	
+	files := make([]fileStat, 0, len(counts))
+	for f, c := range counts {
+		files = append(files, fileStat{f, c})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].count != files[j].count {
+			return files[i].count > files[j].count
+		}
+		return files[i].file < files[j].file
+	})
+	
+	if len(files) > n {
+		return files[:n], len(files)
+	}
+	return files, len(files)
+}
+
 func tabStyle(label string, active bool) string {
 	if active {
 		return lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("212")).
-			Background(lipgloss.Color("236")).
+			Foreground(style.Colors().Title).
+			Background(style.Colors().Surface).
 			Padding(0, 2).
 			Render(label)
 	}
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color("244")).
+		Foreground(style.Colors().Meta).
 		Padding(0, 2).
 		Render(label)
 }
@@ -569,27 +1208,6 @@ func getTypeIcon(typ string) string {
 	return "📄"
 }
 
-func extractSignature(result searchResult) string {
-	// Extract function/class signature from context
-	context := result.context
-	
-	if result.typ == "function" {
-		// Try various function patterns
-		if strings.Contains(context, "func ") {
-			// Go function
-			return context
-		} else if strings.Contains(context, "function ") {
-			// JavaScript/TypeScript
-			return context
-		} else if strings.Contains(context, "def ") {
-			// Python
-			return context
-		}
-	}
-	
-	return ""
-}
-
 func renderProgressBar(percentage float64, width int) string {
 	filled := int(percentage / 100.0 * float64(width))
 	if filled > width {
@@ -597,25 +1215,87 @@ func renderProgressBar(percentage float64, width int) string {
 	}
 	
 	bar := strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
-	return lipgloss.NewStyle().Foreground(lipgloss.Color("120")).Render(bar)
+	return lipgloss.NewStyle().Foreground(style.Colors().Added).Render(bar)
 }
 
-func truncatePath(path string, maxLen int) string {
-	if len(path) <= maxLen {
-		return path
+// relevanceBucketNames lists the relevance distribution's buckets, highest first
+var relevanceBucketNames = []string{"90-100%", "80-90%", "70-80%", "60-70%", "50-60%", "40-50%", "30-40%", "20-30%", "10-20%", "0-10%"}
+
+// relevanceBucket names the 10% bucket a relevance score falls in. A perfect
+// score belongs to the top bucket rather than a bucket of its own.
+func relevanceBucket(relevance float64) string {
+	decile := int(relevance * 10)
+	if decile > 9 {
+		decile = 9
+	}
+	if decile < 0 {
+		decile = 0
+	}
+	return fmt.Sprintf("%d-%d%%", decile*10, decile*10+10)
+}
+
+// countRelevance counts the results in each relevance bucket
+func countRelevance(results []searchResult) map[string]int {
+	counts := make(map[string]int)
+	for _, r := range results {
+		counts[relevanceBucket(r.relevance)]++
+	}
+	return counts
+}
+
+// sparkLevels are the block characters of a sparkline, lowest first
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// renderSparkline draws a histogram of values using at most width block
+// characters, the range from the smallest to the largest value split evenly
+// across them. Empty buckets are left blank.
+func renderSparkline(values []int, width int) string {
+	if len(values) == 0 || width <= 0 {
+		return ""
+	}
+	
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		if v < lo {
+			lo = v
+		}
+		if v > hi {
+			hi = v
+		}
+	}
+	span := hi - lo + 1
+	buckets := width
+	if span < buckets {
+		buckets = span
 	}
 	
-	parts := strings.Split(path, "/")
-	if len(parts) <= 2 {
-		return "..." + path[len(path)-maxLen+3:]
+	counts := make([]int, buckets)
+	peak := 0
+	for _, v := range values {
+		i := (v - lo) * buckets / span
+		counts[i]++
+		if counts[i] > peak {
+			peak = counts[i]
+		}
 	}
 	
-	// Show first and last parts
-	result := parts[0] + "/.../" + parts[len(parts)-1]
-	if len(result) > maxLen {
-		return "..." + parts[len(parts)-1][len(parts[len(parts)-1])-maxLen+3:]
+	var b strings.Builder
+	for _, c := range counts {
+		if c == 0 {
+			b.WriteRune(' ')
+			continue
+		}
+		b.WriteRune(sparkLevels[(c*len(sparkLevels)-1)/peak])
 	}
-	return result
+	return b.String()
+}
+
+// padRight pads s with spaces to width display columns
+func padRight(s string, width int) string {
+	if gap := width - runewidth.StringWidth(s); gap > 0 {
+		return s + strings.Repeat(" ", gap)
+	}
+	return s
 }
 
 func unique(items []string) []string {
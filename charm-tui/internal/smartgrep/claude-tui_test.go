@@ -0,0 +1,113 @@
+package smartgrep
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/mattn/go-runewidth"
+)
+
+func TestPadRightUsesDisplayWidth(t *testing.T) {
+	for _, s := range []string{"func", "関数", "🔧x"} {
+		got := padRight(s, 10)
+		if w := runewidth.StringWidth(got); w != 10 {
+			t.Errorf("padRight(%q, 10) has width %d, want 10", s, w)
+		}
+	}
+}
+
+func TestTopFiles(t *testing.T) {
+	var results []searchResult
+	for file, n := range map[string]int{"b.ts": 3, "a.ts": 3, "c.ts": 5, "d.ts": 1} {
+		for i := 0; i < n; i++ {
+			results = append(results, searchResult{location: location{file: file}})
+		}
+	}
+	
+	files, total := topFiles(results, 3)
+	if total != 4 {
+		t.Errorf("total = %d, want 4", total)
+	}
+	want := []fileStat{{"c.ts", 5}, {"a.ts", 3}, {"b.ts", 3}}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("topFiles() = %v, want %v", files, want)
+	}
+}
+
+// syntheticResults spreads n results over files distinct files
+func syntheticResults(n, files int) []searchResult {
+	results := make([]searchResult, n)
+	for i := range results {
+		results[i].location.file = fmt.Sprintf("src/pkg%d/file%d.ts", i%50, i%files)
+	}
+	return results
+}
+
+func BenchmarkTopFiles(b *testing.B) {
+	results := syntheticResults(50000, 10000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		topFiles(results, 10)
+	}
+}
+
+// BenchmarkTopFilesPairwise is the double-loop sort topFiles replaced, kept
+// for comparison
+func BenchmarkTopFilesPairwise(b *testing.B) {
+	results := syntheticResults(50000, 10000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		counts := make(map[string]int)
+		for _, r := range results {
+			counts[r.location.file]++
+		}
+		var files []fileStat
+		for f, c := range counts {
+			files = append(files, fileStat{f, c})
+		}
+		for i := range files {
+			for j := i + 1; j < len(files); j++ {
+				if files[j].count > files[i].count {
+					files[i], files[j] = files[j], files[i]
+				}
+			}
+		}
+	}
+}
+
+func TestRenderSparkline(t *testing.T) {
+	if got := renderSparkline(nil, 10); got != "" {
+		t.Errorf("empty input = %q, want empty", got)
+	}
+	if got := renderSparkline([]int{7}, 10); got != "█" {
+		t.Errorf("single value = %q, want a single full block", got)
+	}
+	if got := renderSparkline([]int{5, 5, 5}, 10); got != "█" {
+		t.Errorf("equal values = %q, want a single full block", got)
+	}
+	
+	// 1..4 fit in four buckets: three 1s peak, one 4, nothing in between
+	if got := renderSparkline([]int{1, 1, 1, 4}, 10); got != "█  ▃" {
+		t.Errorf("renderSparkline() = %q, want %q", got, "█  ▃")
+	}
+	if got := renderSparkline([]int{1, 1000}, 8); runewidth.StringWidth(got) != 8 {
+		t.Errorf("wide range should use the full width, got %q", got)
+	}
+}
+
+func TestCountRelevanceIncludesPerfectScores(t *testing.T) {
+	results := []searchResult{{relevance: 1.0}, {relevance: 0.95}, {relevance: 0.5}, {relevance: 0}, {relevance: 1.2}}
+	counts := countRelevance(results)
+	if counts["90-100%"] != 3 {
+		t.Errorf("90-100%% bucket = %d, want 3", counts["90-100%"])
+	}
+	
+	sum := 0
+	for _, bucket := range relevanceBucketNames {
+		sum += counts[bucket]
+	}
+	if sum != len(results) {
+		t.Errorf("distribution sums to %d, want %d", sum, len(results))
+	}
+}
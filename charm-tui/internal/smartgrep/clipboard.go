@@ -0,0 +1,38 @@
+package smartgrep
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atotto/clipboard"
+)
+
+// locationText is what y copies from the detail view
+func locationText(result searchResult) string {
+	return fmt.Sprintf("%s:%d", result.location.file, result.location.line)
+}
+
+// signatureText is what Y copies from the detail view. It is empty when no
+// signature could be extracted for the result.
+func signatureText(result searchResult) string {
+	return strings.TrimSpace(extractSignature(result))
+}
+
+// copyToClipboard writes text to the system clipboard and reports the outcome
+// in the detail view notice. Over SSH or without xclip/xsel/wl-copy there is
+// no clipboard, so that case only sets a message.
+func (m *resultViewModel) copyToClipboard(text, what string) {
+	switch {
+	case text == "":
+		m.notice = fmt.Sprintf("⚠️  No %s to copy", what)
+	case clipboard.Unsupported:
+		m.notice = "⚠️  No clipboard available"
+	default:
+		if err := clipboard.WriteAll(text); err != nil {
+			m.notice = fmt.Sprintf("⚠️  Copy failed: %v", err)
+		} else {
+			m.notice = fmt.Sprintf("✓ Copied %s", what)
+		}
+	}
+	m.updateDetailView()
+}
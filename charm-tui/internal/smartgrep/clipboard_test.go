@@ -0,0 +1,29 @@
+package smartgrep
+
+import "testing"
+
+func TestLocationText(t *testing.T) {
+	result := searchResult{location: location{file: "src/auth/login.ts", line: 42}}
+	if got := locationText(result); got != "src/auth/login.ts:42" {
+		t.Errorf("locationText() = %q", got)
+	}
+}
+
+func TestSignatureText(t *testing.T) {
+	tests := []struct {
+		name   string
+		result searchResult
+		want   string
+	}{
+		{"go function", searchResult{typ: "function", context: "  func Login(user string) error {  "}, "func Login(user string) error"},
+		{"python function", searchResult{typ: "function", context: "def login(user):"}, "def login(user)"},
+		{"variable", searchResult{typ: "variable", context: "const token = ''"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := signatureText(tt.result); got != tt.want {
+				t.Errorf("signatureText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
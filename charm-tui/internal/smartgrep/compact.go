@@ -0,0 +1,75 @@
+package smartgrep
+
+import (
+	"fmt"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/style"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// References listed per type in the detail view
+const (
+	detailRefLimit  = 10
+	compactRefLimit = 3
+)
+
+// compactContextRadius is how many lines either side of a result the compact
+// detail view keeps
+const compactContextRadius = 1
+
+// Compact detail view styles: headings without borders, padding or margins
+var (
+	compactTitleStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(style.Colors().Title)
+		
+	compactSectionStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(style.Colors().Accent)
+)
+
+// detailStyles returns the title and section heading styles for the detail
+// view, and the separator placed between sections
+func (m resultViewModel) detailStyles() (title, section lipgloss.Style, gap string) {
+	if m.compact {
+		return compactTitleStyle, compactSectionStyle, ""
+	}
+	return mainTitleStyle, sectionStyle, "\n"
+}
+
+// trimSurrounding keeps the lines within radius of the middle one, which is
+// the result's own line, returning them with the offset of the first kept
+// line from the middle
+func trimSurrounding(lines []string, radius int) ([]string, int) {
+	mid := len(lines) / 2
+	start := max(mid-radius, 0)
+	end := min(mid+radius+1, len(lines))
+	return lines[start:end], start - mid
+}
+
+// toggleCompact switches between the full and compact list and detail views
+func (m *resultViewModel) toggleCompact() {
+	m.compact = !m.compact
+	// Drop the rows first: the table renders them against the new columns
+	m.table.SetRows(nil)
+	m.table.SetColumns(m.columns())
+	m.refreshRows()
+	m.refreshView()
+}
+
+// togglePaths switches between project-relative and absolute paths and
+// remembers the choice in the user config
+func (m *resultViewModel) togglePaths() {
+	m.relativePaths = !m.relativePaths
+	mode := config.PathsAbsolute
+	if m.relativePaths {
+		mode = config.PathsRelative
+	}
+	m.notice = "📂 Showing " + mode + " paths"
+	if err := config.SetPathDisplay(mode); err != nil {
+		m.notice = fmt.Sprintf("⚠️  Showing %s paths, but could not save the setting: %v", mode, err)
+	}
+	m.refreshRows()
+	m.refreshView()
+}
@@ -0,0 +1,129 @@
+package smartgrep
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTrimSurrounding(t *testing.T) {
+	lines := []string{"a", "b", "c", "d", "e"}
+	got, offset := trimSurrounding(lines, 1)
+	if !reflect.DeepEqual(got, []string{"b", "c", "d"}) || offset != -1 {
+		t.Errorf("trimSurrounding() = %v, %d", got, offset)
+	}
+	got, offset = trimSurrounding([]string{"only"}, 1)
+	if !reflect.DeepEqual(got, []string{"only"}) || offset != 0 {
+		t.Errorf("single line = %v, %d", got, offset)
+	}
+}
+
+func TestCompactDetailView(t *testing.T) {
+	var refs []reference
+	for i := 1; i <= 5; i++ {
+		refs = append(refs, reference{typ: "call", from: location{file: "app.ts", line: i}})
+	}
+	m := newResultViewModel()
+	m.viewport.Height = 100
+	m.setResults([]searchResult{{
+		term:        "login",
+		location:    location{file: "auth.ts", line: 10},
+		surrounding: []string{"l8", "l9", "l10", "l11", "l12"},
+		references:  refs,
+		metadata:    map[string]interface{}{"async": true},
+	}})
+	m.activeView = "detail"
+	
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("C")})
+	m = updated.(resultViewModel)
+	if !m.compact {
+		t.Fatal("C should switch to the compact view")
+	}
+	view := m.viewport.View()
+	for _, want := range []string{"9: l9", "11: l11", "... and 2 more"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("compact view missing %q", want)
+		}
+	}
+	for _, unwanted := range []string{"8: l8", "Metadata", "app.ts:4"} {
+		if strings.Contains(view, unwanted) {
+			t.Errorf("compact view should leave out %q", unwanted)
+		}
+	}
+	
+	// Back to the full view, with every column in the list
+	m.toggleCompact()
+	if strings.Contains(m.viewport.View(), "more") || len(m.table.Rows()[0]) != 5 {
+		t.Error("full view should list every reference and column")
+	}
+	if view := m.viewport.View(); !strings.Contains(view, fmt.Sprintf("%4d: l8", 8)) {
+		t.Error("full view should show all surrounding lines")
+	}
+}
+
+func TestDisplayPath(t *testing.T) {
+	root := t.TempDir()
+	SetProjectDir(root)
+	t.Cleanup(func() { SetProjectDir("") })
+	
+	inside := filepath.Join(root, "src", "auth.ts")
+	outside := filepath.Join(filepath.Dir(root), "elsewhere.ts")
+	tests := []struct {
+		file     string
+		relative bool
+		want     string
+	}{
+		{inside, true, filepath.Join("src", "auth.ts")},
+		{"src/auth.ts", true, "src/auth.ts"},
+		{outside, true, outside},
+		{"src/auth.ts", false, inside},
+		{inside, false, inside},
+	}
+	for _, tt := range tests {
+		if got := displayPath(tt.file, tt.relative); got != tt.want {
+			t.Errorf("displayPath(%q, %v) = %q, want %q", tt.file, tt.relative, got, tt.want)
+		}
+	}
+}
+
+func TestTogglePaths(t *testing.T) {
+	root := t.TempDir()
+	SetProjectDir(root)
+	t.Cleanup(func() { SetProjectDir("") })
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv("CURATOR_CONFIG", configPath)
+	
+	m := newResultViewModel()
+	m.relativePaths = true
+	m.viewport.Height = 100
+	m.setResults([]searchResult{{
+		term:       "login",
+		location:   location{file: filepath.Join(root, "auth.ts"), line: 10},
+		references: []reference{{typ: "call", from: location{file: filepath.Join(root, "app.ts"), line: 3}}},
+	}})
+	if got := m.table.Rows()[0][2]; got != "auth.ts:10" {
+		t.Fatalf("relative row location = %q", got)
+	}
+	
+	m.activeView = "detail"
+	m.updateDetailView()
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	m = updated.(resultViewModel)
+	if m.relativePaths {
+		t.Fatal("p should switch to absolute paths")
+	}
+	if want := filepath.Join(root, "auth.ts") + ":10"; m.table.Rows()[0][2] != want {
+		t.Errorf("absolute row location = %q, want %q", m.table.Rows()[0][2], want)
+	}
+	if view := m.viewport.View(); !strings.Contains(view, filepath.Join(root, "app.ts")+":3") {
+		t.Errorf("detail references not shown with absolute paths:\n%s", view)
+	}
+	if data, err := os.ReadFile(configPath); err != nil || !strings.Contains(string(data), "pathDisplay: absolute") {
+		t.Errorf("preference not saved: %q, %v", data, err)
+	}
+}
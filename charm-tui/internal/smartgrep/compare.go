@@ -0,0 +1,122 @@
+package smartgrep
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/style"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// minSplitWidth is the narrowest terminal that still fits two columns side by side
+const minSplitWidth = 100
+
+// resultKey identifies a result independently of its position in the list
+func resultKey(r searchResult) string {
+	return fmt.Sprintf("%s@%s:%d", r.term, r.location.file, r.location.line)
+}
+
+// toggleMark marks or unmarks the selected result
+func (m *resultViewModel) toggleMark() {
+	if m.selected >= len(m.results) {
+		return
+	}
+	
+	key := resultKey(m.results[m.selected])
+	for i, marked := range m.marked {
+		if marked == key {
+			m.marked = append(m.marked[:i], m.marked[i+1:]...)
+			m.refreshRows()
+			return
+		}
+	}
+	m.marked = append(m.marked, key)
+	m.refreshRows()
+}
+
+// isMarked reports whether a result is in the multi-selection
+func (m *resultViewModel) isMarked(r searchResult) bool {
+	key := resultKey(r)
+	for _, marked := range m.marked {
+		if marked == key {
+			return true
+		}
+	}
+	return false
+}
+
+// markedResults returns the marked results in the order they were marked
+func (m *resultViewModel) markedResults() []searchResult {
+	byKey := make(map[string]searchResult, len(m.results))
+	for _, r := range m.results {
+		byKey[resultKey(r)] = r
+	}
+	
+	var results []searchResult
+	for _, key := range m.marked {
+		if r, ok := byKey[key]; ok {
+			results = append(results, r)
+		}
+	}
+	return results
+}
+
+func (m *resultViewModel) updateCompareView() {
+	marked := m.markedResults()
+	if len(marked) != 2 {
+		m.viewport.SetContent(metaStyle.Render(fmt.Sprintf(
+			"Mark exactly two results with space to compare them (%d marked).", len(marked))))
+		return
+	}
+	
+	width := m.viewport.Width
+	if width >= minSplitWidth {
+		colWidth := (width - 3) / 2
+		left := lipgloss.NewStyle().Width(colWidth).Render(renderCompareColumn(marked[0], colWidth))
+		right := lipgloss.NewStyle().Width(colWidth).Render(renderCompareColumn(marked[1], colWidth))
+		divider := graphEdgeStyle.Render(strings.TrimSuffix(strings.Repeat(" │\n", lipgloss.Height(left)), "\n"))
+		m.viewport.SetContent(lipgloss.JoinHorizontal(lipgloss.Top, left, divider, right))
+		return
+	}
+	
+	// Narrow terminal: stack the two results
+	m.viewport.SetContent(lipgloss.JoinVertical(lipgloss.Left,
+		renderCompareColumn(marked[0], width),
+		graphEdgeStyle.Render(strings.Repeat("─", width)),
+		renderCompareColumn(marked[1], width),
+	))
+}
+
+func renderCompareColumn(r searchResult, width int) string {
+	var content strings.Builder
+	
+	content.WriteString(graphNodeStyle.Render(fmt.Sprintf("🎯 %s", r.term)))
+	content.WriteString("\n")
+	content.WriteString(fmt.Sprintf("%s %s • %s\n", getTypeIcon(r.typ), r.typ, r.language))
+	content.WriteString(fmt.Sprintf("📂 %s:%d\n", style.TruncatePath(r.location.file, width-10), r.location.line))
+	content.WriteString(scoreStyle.Render(fmt.Sprintf("📈 Relevance: %.1f%%", r.relevance*100)))
+	content.WriteString("\n")
+	content.WriteString(fmt.Sprintf("🔢 Usage Count: %d\n", r.usageCount))
+	
+	content.WriteString("\n")
+	content.WriteString(headerStyle.Render("🔧 Signature"))
+	content.WriteString("\n")
+	sig := extractSignature(r)
+	if sig == "" {
+		sig = r.context
+	}
+	content.WriteString(signatureStyle.Render(sig))
+	content.WriteString("\n")
+	
+	if len(r.surrounding) > 0 {
+		content.WriteString("\n")
+		content.WriteString(headerStyle.Render("📄 Context"))
+		content.WriteString("\n")
+		for _, line := range r.surrounding {
+			content.WriteString(codeStyle.Render(line))
+			content.WriteString("\n")
+		}
+	}
+	
+	return content.String()
+}
@@ -0,0 +1,64 @@
+package smartgrep
+
+import (
+	"context"
+	"strings"
+)
+
+// SemanticTypes are the values the TypeScript indexer uses for SemanticInfo.type
+var SemanticTypes = []string{
+	"function",
+	"class",
+	"variable",
+	"constant",
+	"string",
+	"comment",
+	"import",
+	"file",
+	"module",
+	"type-alias",
+	"interface",
+	"enum",
+	"property",
+	"method",
+}
+
+// CompleteTypes completes the last entry of a comma-separated --type value,
+// skipping types already listed before it
+func CompleteTypes(toComplete string) []string {
+	prefix, partial := "", toComplete
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		prefix, partial = toComplete[:i+1], toComplete[i+1:]
+	}
+
+	chosen := make(map[string]bool)
+	for _, t := range strings.Split(prefix, ",") {
+		chosen[t] = true
+	}
+
+	var completions []string
+	for _, t := range SemanticTypes {
+		if !chosen[t] && strings.HasPrefix(t, partial) {
+			completions = append(completions, prefix+t)
+		}
+	}
+	return completions
+}
+
+// GroupNames lists the concept group names known to the TypeScript CLI
+func GroupNames() ([]string, error) {
+	output, err := runSmartgrep(context.Background(), false, "group", "list", "--json")
+	if err != nil {
+		return nil, err
+	}
+	groups, err := parseConceptGroups(output)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(groups))
+	for _, g := range groups {
+		names = append(names, g.Name)
+	}
+	return names, nil
+}
@@ -0,0 +1,32 @@
+package smartgrep
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompleteTypes(t *testing.T) {
+	tests := []struct {
+		name       string
+		toComplete string
+		want       []string
+	}{
+		{"prefix", "cl", []string{"class"}},
+		{"shared prefix", "con", []string{"constant"}},
+		{"after comma", "function,c", []string{"function,class", "function,constant", "function,comment"}},
+		{"skips chosen", "class,cl", nil},
+		{"no match", "widget", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompleteTypes(tt.toComplete); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CompleteTypes(%q) = %v, want %v", tt.toComplete, got, tt.want)
+			}
+		})
+	}
+
+	if got := CompleteTypes(""); len(got) != len(SemanticTypes) {
+		t.Errorf("CompleteTypes(\"\") returned %d types, want %d", len(got), len(SemanticTypes))
+	}
+}
@@ -0,0 +1,93 @@
+package smartgrep
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// editorDoneMsg is sent when the editor launched from the detail view exits
+type editorDoneMsg struct {
+	err error
+}
+
+// editorCommand builds the command line that opens file at line. editor is
+// the value of $VISUAL/$EDITOR and may carry its own arguments; when empty,
+// VS Code is used if installed and vi otherwise.
+func editorCommand(editor, file string, line int) []string {
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		if _, err := exec.LookPath("code"); err == nil {
+			args = []string{"code"}
+		} else {
+			args = []string{"vi"}
+		}
+	}
+	
+	name := strings.TrimSuffix(filepath.Base(args[0]), ".exe")
+	switch name {
+	case "code", "code-insiders", "codium", "cursor":
+		return append(args, "-g", fmt.Sprintf("%s:%d", file, line))
+	case "subl", "zed":
+		return append(args, fmt.Sprintf("%s:%d", file, line))
+	default:
+		// vi, vim, nvim, nano, emacs, micro, hx and most others accept +line
+		return append(args, fmt.Sprintf("+%d", line), file)
+	}
+}
+
+// resolveResultPath makes a result path absolute. The CLI reports paths
+// relative to the project root, which is --project or the working directory.
+func resolveResultPath(file string) string {
+	if filepath.IsAbs(file) {
+		return file
+	}
+	if projectDir != "" {
+		return filepath.Join(projectDir, file)
+	}
+	if wd, err := os.Getwd(); err == nil {
+		return filepath.Join(wd, file)
+	}
+	return file
+}
+
+// openInEditor suspends the TUI and opens the selected result in the editor
+func (m *resultViewModel) openInEditor() tea.Cmd {
+	if m.selected >= len(m.results) {
+		return nil
+	}
+	result := m.results[m.selected]
+	
+	cmd, err := editFile(result.location.file, result.location.line)
+	if err != nil {
+		m.notice = fmt.Sprintf("⚠️  Cannot open %s: %v", result.location.file, err)
+		m.updateDetailView()
+	}
+	return cmd
+}
+
+// editFile suspends the TUI and opens file, a path as reported by the CLI,
+// at line in the user's editor. It fails if the file cannot be found.
+func editFile(file string, line int) (tea.Cmd, error) {
+	path := resolveResultPath(file)
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	args := editorCommand(editor, path, line)
+	
+	cmd := exec.Command(args[0], args[1:]...)
+	config.LogCommand(cmd)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return editorDoneMsg{err: err}
+	}), nil
+}
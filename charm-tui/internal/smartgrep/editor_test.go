@@ -0,0 +1,41 @@
+package smartgrep
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEditorCommand(t *testing.T) {
+	tests := []struct {
+		editor string
+		want   []string
+	}{
+		{"vim", []string{"vim", "+42", "/src/a.go"}},
+		{"/usr/bin/nvim", []string{"/usr/bin/nvim", "+42", "/src/a.go"}},
+		{"emacs -nw", []string{"emacs", "-nw", "+42", "/src/a.go"}},
+		{"code --wait", []string{"code", "--wait", "-g", "/src/a.go:42"}},
+		{"subl", []string{"subl", "/src/a.go:42"}},
+	}
+	for _, tt := range tests {
+		if got := editorCommand(tt.editor, "/src/a.go", 42); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("editorCommand(%q) = %v, want %v", tt.editor, got, tt.want)
+		}
+	}
+}
+
+func TestEditorCommandFallback(t *testing.T) {
+	// Depends on whether VS Code is installed, but always opens the right line
+	got := editorCommand("", "/src/a.go", 7)
+	switch got[0] {
+	case "code":
+		if !reflect.DeepEqual(got, []string{"code", "-g", "/src/a.go:7"}) {
+			t.Errorf("fallback = %v", got)
+		}
+	case "vi":
+		if !reflect.DeepEqual(got, []string{"vi", "+7", "/src/a.go"}) {
+			t.Errorf("fallback = %v", got)
+		}
+	default:
+		t.Errorf("unexpected fallback editor %v", got)
+	}
+}
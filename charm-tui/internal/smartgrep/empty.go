@@ -0,0 +1,61 @@
+package smartgrep
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// noMatches reports whether a finished search came back without results
+func (m resultViewModel) noMatches() bool {
+	return m.query != "" && !m.loading && m.loadErr == nil && len(m.results) == 0
+}
+
+// emptyView is shown instead of an empty table when a search finds nothing
+func (m resultViewModel) emptyView() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(fmt.Sprintf("No matches for '%s'", m.query)))
+	b.WriteString("\n\n")
+	b.WriteString("Try broadening your pattern or using a concept group:\n\n")
+	b.WriteString(metaStyle.Render("  • Use | to match any of several terms: auth|login|session"))
+	b.WriteString("\n")
+	b.WriteString(metaStyle.Render("  • Drop --type filters or part of a compound pattern"))
+	b.WriteString("\n")
+	b.WriteString(metaStyle.Render("  • Browse related terms with: smartgrep group list"))
+	b.WriteString("\n")
+	if m.minRelevance > 0 || m.minUsage > 0 {
+		b.WriteString(metaStyle.Render("  • Lower --min-relevance / --min-usage, which may hide matches"))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(metaStyle.Render("esc back to menu • q quit"))
+	
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, b.String())
+}
+
+// searchFailed reports whether a search errored before returning any results
+func (m resultViewModel) searchFailed() bool {
+	return m.query != "" && !m.loading && m.loadErr != nil && len(m.results) == 0
+}
+
+// errorView is shown instead of an empty table when a search fails, so the
+// user can retry or go back without the TUI exiting
+func (m resultViewModel) errorView() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(fmt.Sprintf("Search for '%s' failed", m.query)))
+	b.WriteString("\n\n")
+	b.WriteString(refExtendsStyle.Render(fmt.Sprintf("Error: %v", m.loadErr)))
+	b.WriteString("\n\n")
+	b.WriteString(metaStyle.Render("  • Check the CLI is installed with: smartgrep doctor"))
+	b.WriteString("\n")
+	b.WriteString(metaStyle.Render("  • Rebuild the index with: smartgrep --index"))
+	b.WriteString("\n\n")
+	hint := "esc back to menu • q quit"
+	if m.canRetry() {
+		hint = "r retry • " + hint
+	}
+	b.WriteString(metaStyle.Render(hint))
+	
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, b.String())
+}
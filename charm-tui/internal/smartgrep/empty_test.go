@@ -0,0 +1,76 @@
+package smartgrep
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestNoMatches(t *testing.T) {
+	m := newResultViewModel()
+	if m.noMatches() {
+		t.Error("a view without a query is not a failed search")
+	}
+	
+	m.query = "zzz"
+	m.loading = true
+	if m.noMatches() {
+		t.Error("still loading")
+	}
+	
+	m.loading = false
+	if !m.noMatches() {
+		t.Error("finished search without results should show the empty screen")
+	}
+	if view := m.View(); !strings.Contains(view, "No matches for 'zzz'") {
+		t.Errorf("View() = %q", view)
+	}
+	
+	m.loadErr = errors.New("boom")
+	if m.noMatches() {
+		t.Error("errors are reported, not shown as no matches")
+	}
+}
+
+func TestNoMatchesBackToMenu(t *testing.T) {
+	m := newResultViewModel()
+	m.query = "zzz"
+	
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !updated.(resultViewModel).backToMenu || cmd == nil {
+		t.Fatal("esc should quit back to the menu")
+	}
+	
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	if updated.(resultViewModel).backToMenu {
+		t.Error("q should quit without returning to the menu")
+	}
+}
+
+func TestSearchFailedView(t *testing.T) {
+	m := newResultViewModel()
+	m.query = "auth"
+	m.searchArgs = []string{"auth"}
+	m.loadErr = errors.New("smartgrep not found")
+	if !m.searchFailed() {
+		t.Fatal("an errored search without results should show the error screen")
+	}
+	view := m.View()
+	for _, want := range []string{"smartgrep not found", "r retry", "esc back to menu"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() missing %q", want)
+		}
+	}
+	
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !updated.(resultViewModel).backToMenu || cmd == nil {
+		t.Error("esc should quit back to the menu")
+	}
+	
+	m.results = []searchResult{{term: "auth"}}
+	if m.searchFailed() {
+		t.Error("partial results are kept in the table with the error in the footer")
+	}
+}
@@ -0,0 +1,159 @@
+package smartgrep
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/style"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// exportFormat is one choice of the export popup
+type exportFormat struct {
+	label string
+	ext   string
+	write func(io.Writer, []searchResult) error
+}
+
+var exportFormats = []exportFormat{
+	{"JSON", "json", writeResultsJSON},
+	{"CSV", "csv", writeResultsCSV},
+	{"Markdown", "md", writeResultsMarkdown},
+}
+
+// writeResultsJSON writes the results as flat objects (see MarshalJSON)
+func writeResultsJSON(w io.Writer, results []searchResult) error {
+	if results == nil {
+		results = []searchResult{}
+	}
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// writeResultsCSV writes one row per result with a header row
+func writeResultsCSV(w io.Writer, results []searchResult) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"term", "type", "file", "line", "relevance", "usageCount"})
+	for _, r := range results {
+		cw.Write([]string{
+			r.term,
+			r.typ,
+			r.location.file,
+			strconv.Itoa(r.location.line),
+			strconv.FormatFloat(r.relevance, 'f', -1, 64),
+			strconv.Itoa(r.usageCount),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeResultsMarkdown writes the results as a Markdown table
+func writeResultsMarkdown(w io.Writer, results []searchResult) error {
+	var b strings.Builder
+	b.WriteString("| Term | Type | Location | Score | Uses | Refs |\n")
+	b.WriteString("|------|------|----------|-------|------|------|\n")
+	for _, r := range results {
+		b.WriteString(fmt.Sprintf("| %s | %s | %s:%d | %.0f%% | %d | %d |\n",
+			markdownCell(r.term),
+			markdownCell(r.typ),
+			markdownCell(r.location.file),
+			r.location.line,
+			r.relevance*100,
+			r.usageCount,
+			len(r.references),
+		))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownCell escapes pipes so a value cannot break the table
+func markdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// exportResults writes the results to a timestamped file in dir and returns its path
+func exportResults(dir string, format exportFormat, results []searchResult, now time.Time) (string, error) {
+	var buf bytes.Buffer
+	if err := format.write(&buf, results); err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("smartgrep-results-%s.%s", now.Format("20060102-150405"), format.ext)
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// visibleResults returns the results currently shown in the list, in order
+func (m resultViewModel) visibleResults() []searchResult {
+	results := make([]searchResult, 0, len(m.visible))
+	for _, i := range m.visible {
+		results = append(results, m.results[i])
+	}
+	return results
+}
+
+// updateExport handles keys while the export popup is open
+func (m resultViewModel) updateExport(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.exporting = false
+	case "ctrl+c":
+		return m.quit()
+	case "up", "k":
+		if m.exportCursor > 0 {
+			m.exportCursor--
+		}
+	case "down", "j":
+		if m.exportCursor < len(exportFormats)-1 {
+			m.exportCursor++
+		}
+	case "enter":
+		m.exporting = false
+		format := exportFormats[m.exportCursor]
+		if path, err := exportResults(".", format, m.visibleResults(), time.Now()); err != nil {
+			m.notice = fmt.Sprintf("⚠️  Export failed: %v", err)
+		} else {
+			m.notice = fmt.Sprintf("💾 Exported %d results to %s", len(m.visible), path)
+		}
+	}
+	return m, nil
+}
+
+// exportView renders the format picker
+func (m resultViewModel) exportView() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("💾 Export %d results as:\n\n", len(m.visible)))
+	for i, f := range exportFormats {
+		if i == m.exportCursor {
+			b.WriteString(graphNodeStyle.Render("▸ " + f.label))
+		} else {
+			b.WriteString("  " + f.label)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(metaStyle.Render("enter export • esc cancel"))
+	
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(style.Colors().Title).
+		Padding(1, 2).
+		Render(b.String()) + "\n"
+}
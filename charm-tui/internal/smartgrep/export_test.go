@@ -0,0 +1,104 @@
+package smartgrep
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var exportFixture = []searchResult{
+	{
+		term:       "authenticateUser",
+		typ:        "function",
+		location:   location{file: "src/auth/login.ts", line: 42, column: 3},
+		context:    "function authenticateUser(user) {",
+		relevance:  0.95,
+		usageCount: 12,
+		references: []reference{
+			{typ: "call", from: location{file: "src/api.ts", line: 7}},
+			{typ: "import", from: location{file: "src/index.ts", line: 1}},
+		},
+	},
+	{
+		term:       "a|b, \"c\"",
+		typ:        "variable",
+		location:   location{file: "src/odd.ts", line: 1},
+		relevance:  0.5,
+		usageCount: 0,
+	},
+}
+
+func TestWriteResultsJSON(t *testing.T) {
+	var b strings.Builder
+	if err := writeResultsJSON(&b, exportFixture); err != nil {
+		t.Fatal(err)
+	}
+	
+	var decoded []map[string]interface{}
+	if err := json.Unmarshal([]byte(b.String()), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, b.String())
+	}
+	if len(decoded) != 2 || decoded[0]["term"] != "authenticateUser" || decoded[0]["usageCount"] != 12.0 {
+		t.Errorf("unexpected JSON: %v", decoded)
+	}
+	if refs := decoded[0]["references"].([]interface{}); len(refs) != 2 {
+		t.Errorf("references = %v", refs)
+	}
+}
+
+func TestWriteResultsCSV(t *testing.T) {
+	var b strings.Builder
+	if err := writeResultsCSV(&b, exportFixture); err != nil {
+		t.Fatal(err)
+	}
+	want := "term,type,file,line,relevance,usageCount\n" +
+		"authenticateUser,function,src/auth/login.ts,42,0.95,12\n" +
+		"\"a|b, \"\"c\"\"\",variable,src/odd.ts,1,0.5,0\n"
+	if b.String() != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestWriteResultsMarkdown(t *testing.T) {
+	var b strings.Builder
+	if err := writeResultsMarkdown(&b, exportFixture); err != nil {
+		t.Fatal(err)
+	}
+	want := "| Term | Type | Location | Score | Uses | Refs |\n" +
+		"|------|------|----------|-------|------|------|\n" +
+		"| authenticateUser | function | src/auth/login.ts:42 | 95% | 12 | 2 |\n" +
+		"| a\\|b, \"c\" | variable | src/odd.ts:1 | 50% | 0 | 0 |\n"
+	if b.String() != want {
+		t.Errorf("Markdown =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestExportResults(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC)
+	
+	path, err := exportResults(dir, exportFormats[1], exportFixture, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dir, "smartgrep-results-20240305-143000.csv"); path != want {
+		t.Errorf("path = %q, want %q", path, want)
+	}
+	if data, err := os.ReadFile(path); err != nil || !strings.HasPrefix(string(data), "term,type,") {
+		t.Errorf("file = %q, %v", data, err)
+	}
+}
+
+func TestVisibleResultsFollowFilter(t *testing.T) {
+	m := newResultViewModel()
+	m.setResults(exportFixture)
+	m.setFilter("odd")
+	
+	got := m.visibleResults()
+	if len(got) != 1 || got[0].location.file != "src/odd.ts" {
+		t.Errorf("visibleResults() = %v", got)
+	}
+}
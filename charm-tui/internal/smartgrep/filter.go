@@ -0,0 +1,108 @@
+package smartgrep
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// fuzzyMatch reports whether the characters of pattern appear in s in order,
+// ignoring case
+func fuzzyMatch(pattern, s string) bool {
+	pattern = strings.ToLower(pattern)
+	s = strings.ToLower(s)
+	
+	for _, pr := range pattern {
+		if pr == ' ' {
+			continue
+		}
+		i := strings.IndexRune(s, pr)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(string(pr)):]
+	}
+	return true
+}
+
+// filteredIndices returns the indices of the results whose term or file
+// fuzzy-match the filter and whose type is not hidden, in their original order
+func filteredIndices(results []searchResult, filter string, hidden map[string]bool) []int {
+	indices := make([]int, 0, len(results))
+	for i, r := range results {
+		if hidden[r.typ] {
+			continue
+		}
+		if filter == "" || fuzzyMatch(filter, r.term+" "+r.location.file) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+func newFilterInput() textinput.Model {
+	ti := textinput.New()
+	ti.Prompt = "🔎 "
+	ti.Placeholder = "filter by term or file..."
+	ti.CharLimit = 100
+	return ti
+}
+
+// syncSelected maps the table cursor back into the unfiltered results,
+// accounting for the page the table currently holds
+func (m *resultViewModel) syncSelected() {
+	cursor := m.pageStart + m.table.Cursor()
+	if cursor >= m.pageStart && cursor < len(m.visible) {
+		m.selected = m.visible[cursor]
+	} else {
+		m.selected = len(m.results)
+	}
+	m.syncSplitDetail()
+}
+
+// setFilter narrows the list to matching results
+func (m *resultViewModel) setFilter(filter string) {
+	m.filter = filter
+	m.pageStart = 0
+	m.refreshRows()
+	m.table.SetCursor(0)
+	m.syncSelected()
+}
+
+// updateFilter handles keys while the filter input has focus
+func (m resultViewModel) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.filtering = false
+		m.filterInput.Blur()
+		m.filterInput.SetValue("")
+		m.setFilter("")
+		return m, nil
+	case tea.KeyEnter:
+		m.filtering = false
+		m.filterInput.Blur()
+		return m, nil
+	case tea.KeyCtrlC:
+		return m.quit()
+	}
+	
+	var cmd tea.Cmd
+	m.filterInput, cmd = m.filterInput.Update(msg)
+	if value := m.filterInput.Value(); value != m.filter {
+		m.setFilter(value)
+	}
+	return m, cmd
+}
+
+// filterView shows the filter input or the active filter above the list
+func (m resultViewModel) filterView() string {
+	if m.filtering {
+		return m.filterInput.View() + "\n"
+	}
+	if m.filter != "" {
+		return metaStyle.Render(fmt.Sprintf("🔎 %q • %d of %d results (esc to clear)", m.filter, len(m.visible), len(m.results))) + "\n"
+	}
+	return ""
+}
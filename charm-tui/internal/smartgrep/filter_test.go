@@ -0,0 +1,78 @@
+package smartgrep
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		pattern, s string
+		want       bool
+	}{
+		{"auth", "authenticateUser src/auth.ts", true},
+		{"aus", "authenticateUser src/auth.ts", true},
+		{"AUTH", "authenticateUser", true},
+		{"auth ts", "authenticateUser src/auth.ts", true},
+		{"userauthx", "authenticateUser src/auth.ts", false},
+		{"xyz", "authenticateUser", false},
+		{"", "anything", true},
+	}
+	for _, tt := range tests {
+		if got := fuzzyMatch(tt.pattern, tt.s); got != tt.want {
+			t.Errorf("fuzzyMatch(%q, %q) = %v, want %v", tt.pattern, tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestFilteredIndicesMapIntoResults(t *testing.T) {
+	results := []searchResult{
+		{term: "login", location: location{file: "src/auth.ts"}},
+		{term: "render", location: location{file: "src/ui.tsx"}},
+		{term: "logout", location: location{file: "src/auth.ts"}},
+	}
+	
+	if got, want := filteredIndices(results, "auth", nil), []int{0, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("filteredIndices(auth) = %v, want %v", got, want)
+	}
+	if got, want := filteredIndices(results, "", nil), []int{0, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("filteredIndices(\"\") = %v, want %v", got, want)
+	}
+}
+
+func TestToggleType(t *testing.T) {
+	m := newResultViewModel()
+	m.setResults([]searchResult{
+		{term: "login", typ: "function"},
+		{term: "User", typ: "class"},
+		{term: "logout", typ: "function"},
+	})
+	if got, want := resultTypes(m.results), []string{"class", "function"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("resultTypes() = %v, want %v", got, want)
+	}
+	
+	// 2 is function: hide it, leaving the class
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")})
+	rv := updated.(resultViewModel)
+	if !reflect.DeepEqual(rv.visible, []int{1}) || rv.selected != 1 {
+		t.Errorf("visible = %v selected = %d, want only the class", rv.visible, rv.selected)
+	}
+	
+	rv.activeView = "stats"
+	rv.updateStatsView()
+	if !strings.Contains(rv.viewport.View(), "1 of 3 results") {
+		t.Error("stats should describe the visible results")
+	}
+	
+	rv.toggleType(9)
+	if len(rv.visible) != 1 {
+		t.Errorf("out of range toggle changed the list: %v", rv.visible)
+	}
+	rv.toggleType(0)
+	if len(rv.visible) != 3 || rv.hiddenTypes != nil {
+		t.Errorf("0 should show every type, got %v", rv.visible)
+	}
+}
@@ -0,0 +1,75 @@
+package smartgrep
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// focusFile is the file results are biased towards (set via --focus)
+var focusFile string
+
+// SetFocus sets the file that TUI results are re-ranked around
+func SetFocus(file string) {
+	focusFile = normalizeFocus(file)
+}
+
+// FocusScope returns the directory used to scope CLI searches for a focus file
+func FocusScope(file string) string {
+	dir := filepath.Dir(normalizeFocus(file))
+	if dir == "." {
+		return ""
+	}
+	return filepath.ToSlash(dir)
+}
+
+// normalizeFocus makes the focus path, given relative to the working
+// directory, relative to the project root so it lines up with the
+// project-relative paths reported by the CLI
+func normalizeFocus(file string) string {
+	if file == "" {
+		return ""
+	}
+	if abs, err := filepath.Abs(file); err == nil {
+		if rel, err := filepath.Rel(projectRoot(), abs); err == nil {
+			file = rel
+		}
+	}
+	return filepath.ToSlash(filepath.Clean(file))
+}
+
+// pathDistance counts the directory hops between two files; 0 means same file
+func pathDistance(a, b string) int {
+	a = strings.TrimPrefix(filepath.ToSlash(filepath.Clean(a)), "./")
+	b = strings.TrimPrefix(filepath.ToSlash(filepath.Clean(b)), "./")
+	if a == b {
+		return 0
+	}
+	
+	dirA := strings.Split(filepath.ToSlash(filepath.Dir(a)), "/")
+	dirB := strings.Split(filepath.ToSlash(filepath.Dir(b)), "/")
+	
+	common := 0
+	for common < len(dirA) && common < len(dirB) && dirA[common] == dirB[common] {
+		common++
+	}
+	
+	// Same directory counts as one hop so it still ranks below the file itself
+	return 1 + (len(dirA) - common) + (len(dirB) - common)
+}
+
+// rankByFocus re-orders results so those near the focus file come first.
+// Relevance still matters: the proximity boost decays with distance.
+func rankByFocus(results []searchResult, focus string) []searchResult {
+	ranked := make([]searchResult, len(results))
+	copy(ranked, results)
+	
+	score := func(r searchResult) float64 {
+		return r.relevance + 1.0/float64(1+pathDistance(r.location.file, focus))
+	}
+	
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return score(ranked[i]) > score(ranked[j])
+	})
+	return ranked
+}
@@ -0,0 +1,41 @@
+package smartgrep
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNormalizeFocusWithProject(t *testing.T) {
+	project := t.TempDir()
+	elsewhere := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(elsewhere); err != nil {
+		t.Fatal(err)
+	}
+	SetProjectDir(project)
+	t.Cleanup(func() {
+		os.Chdir(wd)
+		SetProjectDir("")
+	})
+	
+	abs := filepath.Join(project, "src", "auth", "login.ts")
+	if got := normalizeFocus(abs); got != "src/auth/login.ts" {
+		t.Errorf("normalizeFocus(%q) = %q, want src/auth/login.ts", abs, got)
+	}
+	if got := FocusScope(abs); got != "src/auth" {
+		t.Errorf("FocusScope(%q) = %q, want src/auth", abs, got)
+	}
+	
+	// Relative paths are given from the working directory
+	rel, err := filepath.Rel(elsewhere, abs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := normalizeFocus(rel); got != "src/auth/login.ts" {
+		t.Errorf("normalizeFocus(%q) = %q, want src/auth/login.ts", rel, got)
+	}
+}
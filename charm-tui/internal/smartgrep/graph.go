@@ -0,0 +1,163 @@
+package smartgrep
+
+import (
+	"path/filepath"
+	"sort"
+)
+
+// graphNode is a symbol in the relationship graph with its distinct edges
+type graphNode struct {
+	name string
+	out  []string // Symbols this one uses or relates to
+	in   []string // Symbols that use this one
+}
+
+// degree is the number of distinct symbols the node is connected to
+func (n graphNode) degree() int {
+	return len(n.in) + len(n.out)
+}
+
+// buildGraph turns search results into a deduplicated graph. Each reference
+// is an edge from the result declared closest above it in the same file (or
+// the file itself when no result encloses it) to the referenced term. Nodes
+// are ordered by degree so hub symbols come first.
+func buildGraph(results []searchResult) []graphNode {
+	nodes := make(map[string]*graphNode)
+	edges := make(map[[2]string]bool)
+	node := func(name string) *graphNode {
+		n, ok := nodes[name]
+		if !ok {
+			n = &graphNode{name: name}
+			nodes[name] = n
+		}
+		return n
+	}
+	addEdge := func(from, to string) {
+		if from == "" || to == "" || from == to || edges[[2]string{from, to}] {
+			return
+		}
+		edges[[2]string{from, to}] = true
+		node(from).out = append(node(from).out, to)
+		node(to).in = append(node(to).in, from)
+	}
+
+	for _, result := range results {
+		node(result.term)
+		for _, related := range result.related {
+			addEdge(result.term, related)
+		}
+		for _, ref := range result.references {
+			target := ref.target
+			if target == "" {
+				target = result.term
+			}
+			addEdge(enclosingSymbol(results, ref.from), target)
+		}
+	}
+
+	sorted := make([]graphNode, 0, len(nodes))
+	for _, n := range nodes {
+		sort.Strings(n.out)
+		sort.Strings(n.in)
+		sorted = append(sorted, *n)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].degree() != sorted[j].degree() {
+			return sorted[i].degree() > sorted[j].degree()
+		}
+		return sorted[i].name < sorted[j].name
+	})
+	return sorted
+}
+
+// enclosingSymbol names the result declared nearest above loc in its file,
+// falling back to the file name
+func enclosingSymbol(results []searchResult, loc location) string {
+	best := -1
+	for i, r := range results {
+		if r.location.file != loc.file || r.location.line > loc.line {
+			continue
+		}
+		if best < 0 || r.location.line > results[best].location.line {
+			best = i
+		}
+	}
+	if best < 0 {
+		return filepath.Base(loc.file)
+	}
+	return results[best].term
+}
+
+// graphTreeDepth bounds how far the tree layout follows callers and callees
+const graphTreeDepth = 3
+
+// graphRoot picks the node the tree layout starts from: the result whose
+// term is the query, otherwise the biggest hub
+func graphRoot(graph []graphNode, query string) string {
+	for _, n := range graph {
+		if n.name == query {
+			return n.name
+		}
+	}
+	if len(graph) == 0 {
+		return ""
+	}
+	return graph[0].name
+}
+
+// renderGraphTree lays the graph of results out as a tree rooted at the
+// query's term, listing what it uses and what uses it, each followed
+// recursively up to maxDepth levels. Symbols already on the current path are
+// not expanded again.
+func renderGraphTree(results []searchResult, query string, maxDepth int) []string {
+	graph := buildGraph(results)
+	root := graphRoot(graph, query)
+	byName := make(map[string]graphNode, len(graph))
+	for _, n := range graph {
+		byName[n.name] = n
+	}
+	if _, ok := byName[root]; !ok {
+		return nil
+	}
+	
+	lines := []string{root}
+	branches := []struct {
+		label string
+		next  func(graphNode) []string
+	}{
+		{"uses", func(n graphNode) []string { return n.out }},
+		{"used by", func(n graphNode) []string { return n.in }},
+	}
+	
+	var walk func(name, prefix string, depth int, next func(graphNode) []string, path map[string]bool)
+	walk = func(name, prefix string, depth int, next func(graphNode) []string, path map[string]bool) {
+		children := next(byName[name])
+		for i, child := range children {
+			connector, indent := "├── ", "│   "
+			if i == len(children)-1 {
+				connector, indent = "└── ", "    "
+			}
+			switch {
+			case path[child]:
+				lines = append(lines, prefix+connector+child+" ↺")
+			case depth >= maxDepth && len(next(byName[child])) > 0:
+				lines = append(lines, prefix+connector+child+" …")
+			default:
+				lines = append(lines, prefix+connector+child)
+				path[child] = true
+				walk(child, prefix+indent, depth+1, next, path)
+				delete(path, child)
+			}
+		}
+	}
+	
+	for i, b := range branches {
+		connector, indent := "├── ", "│   "
+		if i == len(branches)-1 {
+			connector, indent = "└── ", "    "
+		}
+		lines = append(lines, connector+b.label)
+		walk(root, indent, 1, b.next, map[string]bool{root: true})
+	}
+	return lines
+}
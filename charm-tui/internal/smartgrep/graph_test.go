@@ -0,0 +1,73 @@
+package smartgrep
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuildGraph(t *testing.T) {
+	results := []searchResult{
+		{term: "login", location: location{file: "auth.ts", line: 10}, related: []string{"logout", "logout"}},
+		{term: "handler", location: location{file: "app.ts", line: 5}, references: []reference{
+			{target: "login", from: location{file: "app.ts", line: 8}},
+		}},
+		{term: "session", location: location{file: "auth.ts", line: 30}, references: []reference{
+			{target: "login", from: location{file: "auth.ts", line: 40}},
+			{target: "login", from: location{file: "auth.ts", line: 41}},
+			{from: location{file: "main.ts", line: 2}},
+		}},
+	}
+	
+	graph := buildGraph(results)
+	if graph[0].name != "login" {
+		t.Fatalf("hub should come first, got %+v", graph)
+	}
+	if want := []string{"handler", "session"}; !reflect.DeepEqual(graph[0].in, want) {
+		t.Errorf("login in = %v, want %v", graph[0].in, want)
+	}
+	if want := []string{"logout"}; !reflect.DeepEqual(graph[0].out, want) {
+		t.Errorf("login out = %v, want deduplicated %v", graph[0].out, want)
+	}
+	
+	byName := make(map[string]graphNode)
+	for _, n := range graph {
+		byName[n.name] = n
+	}
+	// A reference without an enclosing result comes from its file and
+	// defaults to the result's own term
+	if out := byName["main.ts"].out; !reflect.DeepEqual(out, []string{"session"}) {
+		t.Errorf("main.ts out = %v, want [session]", out)
+	}
+}
+
+func TestRenderGraphTree(t *testing.T) {
+	results := []searchResult{
+		{term: "login", location: location{file: "auth.ts", line: 1}, related: []string{"session"}},
+		{term: "session", location: location{file: "session.ts", line: 1}, related: []string{"store", "login"}},
+		{term: "store", location: location{file: "store.ts", line: 1}, related: []string{"db"}},
+	}
+	
+	got := renderGraphTree(results, "login", 2)
+	want := []string{
+		"login",
+		"├── uses",
+		"│   └── session",
+		"│       ├── login ↺",
+		"│       └── store …",
+		"└── used by",
+		"    └── session",
+		"        └── login ↺",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("renderGraphTree() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	
+	// Unknown queries root the tree at the biggest hub
+	if got := renderGraphTree(results, "auth|login", 1); len(got) == 0 || got[0] != "session" {
+		t.Errorf("root = %v, want session", got)
+	}
+	if got := renderGraphTree(nil, "login", 2); got != nil {
+		t.Errorf("empty results should render nothing, got %v", got)
+	}
+}
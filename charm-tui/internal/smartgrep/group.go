@@ -0,0 +1,281 @@
+package smartgrep
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/style"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// conceptGroup mirrors the TypeScript ConceptGroupDefinition
+type conceptGroup struct {
+	Name  string   `json:"name"`
+	Desc  string   `json:"description"`
+	Emoji string   `json:"emoji"`
+	Terms []string `json:"terms"`
+}
+
+func (g conceptGroup) Title() string       { return strings.TrimSpace(g.Emoji + " " + g.Name) }
+func (g conceptGroup) Description() string { return g.Desc }
+func (g conceptGroup) FilterValue() string { return g.Name }
+
+// Group browser styles
+var (
+	groupPaneStyle = lipgloss.NewStyle().
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(style.Colors().Border)
+	
+	groupFocusedPaneStyle = groupPaneStyle.Copy().
+				BorderForeground(style.Colors().Title)
+	
+	groupTermStyle = lipgloss.NewStyle().
+			Foreground(style.Colors().Added)
+)
+
+// groupListWidth is the widest the group list pane gets
+const groupListWidth = 36
+
+// parseConceptGroups extracts the group list from `group list --json` output
+func parseConceptGroups(output []byte) ([]conceptGroup, error) {
+	payload, err := findJSONArray(output)
+	if err != nil {
+		return nil, err
+	}
+	
+	var groups []conceptGroup
+	if err := json.Unmarshal(payload, &groups); err != nil {
+		config.LogParseError("group list", err)
+		return nil, fmt.Errorf("failed to parse concept groups: %w", err)
+	}
+	return groups, nil
+}
+
+type groupsLoadedMsg struct {
+	groups []conceptGroup
+	err    error
+}
+
+type groupPreviewMsg struct {
+	name    string
+	results []searchResult
+	err     error
+}
+
+func fetchConceptGroups() tea.Msg {
+	output, err := runSmartgrep(context.Background(), false, "group", "list", "--json")
+	if err != nil {
+		return groupsLoadedMsg{err: fmt.Errorf("failed to list groups: %w", err)}
+	}
+	groups, err := parseConceptGroups(output)
+	return groupsLoadedMsg{groups: groups, err: err}
+}
+
+func fetchGroupPreview(name string) tea.Cmd {
+	return func() tea.Msg {
+		results, err := fetchSearchResults(context.Background(), "group", name)
+		return groupPreviewMsg{name: name, results: results, err: err}
+	}
+}
+
+// groupModel is a two-pane concept group browser: groups on the left, the
+// highlighted group's terms and a preview of its matches on the right
+type groupModel struct {
+	list         list.Model
+	preview      resultViewModel
+	previewName  string // Group the preview holds results for
+	focusPreview bool
+	loading      bool
+	err          error
+	width        int
+	height       int
+}
+
+func newGroupModel() groupModel {
+	l := list.New(nil, list.NewDefaultDelegate(), 0, 0)
+	l.Title = "📦 Concept Groups"
+	l.SetShowStatusBar(false)
+	
+	return groupModel{
+		list:    l,
+		preview: newResultViewModel(),
+		loading: true,
+	}
+}
+
+func (m groupModel) Init() tea.Cmd {
+	return fetchConceptGroups
+}
+
+func (m groupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.resize()
+		return m, nil
+	
+	case groupsLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		items := make([]list.Item, len(msg.groups))
+		for i, g := range msg.groups {
+			items[i] = g
+		}
+		cmd := m.list.SetItems(items)
+		m.resize()
+		return m, cmd
+	
+	case groupPreviewMsg:
+		if msg.name != m.previewName {
+			// Results for a group that is no longer being previewed
+			return m, nil
+		}
+		m.preview.loading = false
+		m.preview.loadErr = msg.err
+		m.preview.setResults(msg.results)
+		return m, nil
+	
+	case tea.KeyMsg:
+		if m.focusPreview {
+			// Esc returns to the group list unless the preview uses it itself
+			if key.Matches(msg, keys.Back) && !m.preview.capturesEsc() {
+				m.focusPreview = false
+				return m, nil
+			}
+			return m.updatePreview(msg)
+		}
+		
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+		switch {
+		case key.Matches(msg, keys.Quit):
+			return m, tea.Quit
+		case key.Matches(msg, keys.Select):
+			group, ok := m.list.SelectedItem().(conceptGroup)
+			if !ok {
+				return m, nil
+			}
+			m.previewName = group.Name
+			m.preview = newResultViewModel()
+			m.preview.loading = true
+			m.focusPreview = true
+			m.resize()
+			return m, tea.Batch(fetchGroupPreview(group.Name), style.SpinnerTick(m.preview.spinner))
+		case key.Matches(msg, resultKeys.NextView):
+			if m.previewName != "" {
+				m.focusPreview = true
+			}
+			return m, nil
+		}
+		
+	default:
+		if m.previewName != "" {
+			// References, blame, editor exits, watch and spinner ticks
+			// belong to the preview; the list ignores what isn't its own
+			updated, previewCmd := m.updatePreview(msg)
+			m = updated.(groupModel)
+			var cmd tea.Cmd
+			m.list, cmd = m.list.Update(msg)
+			return m, tea.Batch(previewCmd, cmd)
+		}
+	}
+	
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	m.resize()
+	return m, cmd
+}
+
+// updatePreview forwards a message to the embedded result view
+func (m groupModel) updatePreview(msg tea.Msg) (tea.Model, tea.Cmd) {
+	updated, cmd := m.preview.Update(msg)
+	m.preview = updated.(resultViewModel)
+	return m, cmd
+}
+
+// paneWidths splits the terminal between the list and the preview
+func (m groupModel) paneWidths() (int, int) {
+	left := m.width / 3
+	if left > groupListWidth {
+		left = groupListWidth
+	}
+	// Each pane has a border on both sides
+	right := m.width - left - 4
+	if right < 0 {
+		right = 0
+	}
+	return left, right
+}
+
+// resize lays out the list and the preview around the terms header, whose
+// height depends on the highlighted group
+func (m *groupModel) resize() {
+	if m.width == 0 {
+		return
+	}
+	left, right := m.paneWidths()
+	m.list.SetSize(left, m.height-2)
+	
+	previewHeight := m.height - 2 - lipgloss.Height(m.termsView(right))
+	updated, _ := m.preview.Update(tea.WindowSizeMsg{Width: right, Height: previewHeight})
+	m.preview = updated.(resultViewModel)
+}
+
+// termsView describes the highlighted group
+func (m groupModel) termsView(width int) string {
+	group, ok := m.list.SelectedItem().(conceptGroup)
+	if !ok {
+		return ""
+	}
+	
+	var b strings.Builder
+	b.WriteString(headerStyle.Render(group.Title()))
+	if group.Desc != "" {
+		b.WriteString(" — " + group.Desc)
+	}
+	b.WriteString("\n")
+	b.WriteString(groupTermStyle.Copy().Width(width).Render(strings.Join(group.Terms, " · ")))
+	b.WriteString("\n")
+	return b.String()
+}
+
+func (m groupModel) View() string {
+	if m.loading {
+		return "⏳ Loading concept groups..."
+	}
+	if m.err != nil {
+		return fmt.Sprintf("Error: %v\n\nPress 'q' to quit.", m.err)
+	}
+	if len(m.list.Items()) == 0 {
+		return "No concept groups found.\n\nAdd one with: smartgrep group add <name> <term1,term2,...>\n\nPress 'q' to quit."
+	}
+	
+	left, right := m.paneWidths()
+	
+	var preview string
+	group, _ := m.list.SelectedItem().(conceptGroup)
+	if m.previewName != "" && m.previewName == group.Name {
+		preview = m.preview.View()
+	} else {
+		preview = metaStyle.Render("Press Enter to preview matches")
+	}
+	
+	listStyle, previewStyle := groupFocusedPaneStyle, groupPaneStyle
+	if m.focusPreview {
+		listStyle, previewStyle = groupPaneStyle, groupFocusedPaneStyle
+	}
+	
+	return lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		listStyle.Width(left).Height(m.height-2).Render(m.list.View()),
+		previewStyle.Width(right).Height(m.height-2).Render(m.termsView(right)+"\n"+preview),
+	)
+}
@@ -0,0 +1,102 @@
+package smartgrep
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParseConceptGroups(t *testing.T) {
+	output := "\x1b[2m📚 Loading config...\x1b[0m\n" + `[
+  {"name": "auth", "description": "Authentication & security patterns", "emoji": "🔐", "terms": ["auth", "login", "token"]},
+  {"name": "payments", "description": "", "emoji": "", "terms": ["charge", "invoice"]}
+]
+`
+	groups, err := parseConceptGroups([]byte(output))
+	if err != nil {
+		t.Fatalf("parseConceptGroups: %v", err)
+	}
+	if len(groups) != 2 {
+		t.Fatalf("got %d groups, want 2", len(groups))
+	}
+	if groups[0].Title() != "🔐 auth" || len(groups[0].Terms) != 3 {
+		t.Errorf("unexpected first group: %+v", groups[0])
+	}
+	if groups[1].Title() != "payments" {
+		t.Errorf("title without emoji = %q, want %q", groups[1].Title(), "payments")
+	}
+}
+
+func TestParseConceptGroupsEmpty(t *testing.T) {
+	groups, err := parseConceptGroups([]byte("[]\n"))
+	if err != nil || len(groups) != 0 {
+		t.Errorf("parseConceptGroups([]) = %v, %v", groups, err)
+	}
+	
+	if _, err := parseConceptGroups([]byte("Available groups:\n  auth\n")); err == nil {
+		t.Error("expected an error when the output has no JSON")
+	}
+}
+
+func TestGroupPreviewKeepsEscItUses(t *testing.T) {
+	esc := tea.KeyMsg{Type: tea.KeyEsc}
+	preview := func() groupModel {
+		m := newGroupModel()
+		m.focusPreview = true
+		m.preview.setResults([]searchResult{
+			{term: "login", location: location{file: "src/auth.ts"}},
+			{term: "render", location: location{file: "src/ui.tsx"}},
+		})
+		return m
+	}
+	press := func(m groupModel, msg tea.KeyMsg) groupModel {
+		updated, _ := m.Update(msg)
+		return updated.(groupModel)
+	}
+	
+	// Esc leaves the detail view for the list, then the preview
+	m := preview()
+	m.preview.activeView = "detail"
+	m = press(m, esc)
+	if !m.focusPreview || m.preview.activeView != "list" {
+		t.Fatalf("esc in detail: focusPreview = %v, view = %q, want the preview list", m.focusPreview, m.preview.activeView)
+	}
+	if m = press(m, esc); m.focusPreview {
+		t.Error("esc in the list should return to the groups")
+	}
+	
+	// Esc closes the filter input, then clears an applied filter
+	m = preview()
+	for _, msg := range []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune("/")}, {Type: tea.KeyRunes, Runes: []rune("ui")}} {
+		m = press(m, msg)
+	}
+	if !m.preview.filtering || m.preview.filter != "ui" {
+		t.Fatalf("filtering = %v, filter = %q", m.preview.filtering, m.preview.filter)
+	}
+	if m = press(m, esc); !m.focusPreview || m.preview.filtering {
+		t.Fatalf("esc while filtering: focusPreview = %v, filtering = %v", m.focusPreview, m.preview.filtering)
+	}
+	
+	m = preview()
+	m.preview.setFilter("ui")
+	if m = press(m, esc); !m.focusPreview || m.preview.filter != "" {
+		t.Fatalf("esc with a filter: focusPreview = %v, filter = %q", m.focusPreview, m.preview.filter)
+	}
+	if m = press(m, esc); m.focusPreview {
+		t.Error("esc without a filter should return to the groups")
+	}
+}
+
+func TestGroupPreviewReceivesResultViewMessages(t *testing.T) {
+	loc := location{file: "src/auth.ts", line: 3}
+	m := newGroupModel()
+	m.previewName = "auth"
+	m.preview.setResults([]searchResult{{term: "login", location: loc}})
+	
+	refs := []reference{{typ: "call", from: location{file: "b.ts", line: 9}}}
+	updated, _ := m.Update(termRefsMsg{term: "login", location: loc, refs: refs})
+	m = updated.(groupModel)
+	if got := m.preview.results[0].references; len(got) != 1 {
+		t.Errorf("references not applied to the preview: %+v", m.preview.results[0])
+	}
+}
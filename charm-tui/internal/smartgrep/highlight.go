@@ -0,0 +1,59 @@
+package smartgrep
+
+import (
+	"strings"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/style"
+	"github.com/alecthomas/chroma"
+	"github.com/alecthomas/chroma/formatters"
+	"github.com/alecthomas/chroma/lexers"
+	"github.com/alecthomas/chroma/styles"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// highlightStyle returns the chroma theme used for code context, matching
+// the terminal background
+func highlightStyle() string {
+	if style.DarkBackground() {
+		return "monokai"
+	}
+	return "monokailight"
+}
+
+// highlightLines colors source lines written in language for the terminal,
+// returning one string per input line. It returns nil when the language is
+// unknown, highlighting fails or colors are off (NO_COLOR or no terminal),
+// so callers can fall back to plain styling.
+func highlightLines(lines []string, language string) []string {
+	if language == "" || len(lines) == 0 || lipgloss.ColorProfile() == termenv.Ascii {
+		return nil
+	}
+	lexer := lexers.Get(language)
+	if lexer == nil {
+		return nil
+	}
+	
+	// Lex the block as a whole so strings and comments spanning lines color
+	// correctly, then format it line by line
+	iterator, err := chroma.Coalesce(lexer).Tokenise(nil, strings.Join(lines, "\n"))
+	if err != nil {
+		return nil
+	}
+	split := chroma.SplitTokensIntoLines(iterator.Tokens())
+	if len(split) < len(lines) {
+		return nil
+	}
+	
+	formatter := formatters.Get("terminal256")
+	theme := styles.Get(highlightStyle())
+	highlighted := make([]string, len(lines))
+	for i := range lines {
+		var b strings.Builder
+		if err := formatter.Format(&b, theme, chroma.Literator(split[i]...)); err != nil {
+			return nil
+		}
+		highlighted[i] = strings.ReplaceAll(b.String(), "\n", "")
+	}
+	return highlighted
+}
@@ -0,0 +1,60 @@
+package smartgrep
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestHighlightLines(t *testing.T) {
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	t.Cleanup(func() { lipgloss.SetColorProfile(termenv.Ascii) })
+	
+	lines := []string{"const a = 1", "/* spans", "lines */", "function f() {}"}
+	got := highlightLines(lines, "typescript")
+	if len(got) != len(lines) {
+		t.Fatalf("got %d lines, want %d", len(got), len(lines))
+	}
+	for i, line := range got {
+		if !strings.Contains(line, "\x1b[") {
+			t.Errorf("line %d was not colored: %q", i, line)
+		}
+		if strings.Contains(line, "\n") {
+			t.Errorf("line %d kept its newline", i)
+		}
+	}
+	
+	if got := highlightLines(lines, "no-such-language"); got != nil {
+		t.Errorf("unknown language should fall back, got %q", got)
+	}
+	if got := highlightLines(lines, ""); got != nil {
+		t.Errorf("missing language should fall back, got %q", got)
+	}
+	
+	lipgloss.SetColorProfile(termenv.Ascii)
+	if got := highlightLines(lines, "typescript"); got != nil {
+		t.Errorf("without colors there should be no highlighting, got %q", got)
+	}
+}
+
+func TestDetailViewMarksHighlightedLine(t *testing.T) {
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	t.Cleanup(func() { lipgloss.SetColorProfile(termenv.Ascii) })
+	
+	m := newResultViewModel()
+	m.viewport.Height = 50
+	m.setResults([]searchResult{{
+		term:        "f",
+		language:    "go",
+		location:    location{file: "f.go", line: 11},
+		surrounding: []string{"package f", "func f() {}", "var x = 1"},
+	}})
+	m.activeView = "detail"
+	m.updateDetailView()
+	
+	if view := m.viewport.View(); !strings.Contains(view, "▶ 11: ") {
+		t.Errorf("result line should be marked in the gutter:\n%s", view)
+	}
+}
@@ -0,0 +1,318 @@
+package smartgrep
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// maxHistoryEntries bounds the size of the persisted history file
+const maxHistoryEntries = 500
+
+// maxRecentSearches is how many recent searches the menu lists
+const maxRecentSearches = 5
+
+// historyHalfLife controls how quickly old searches stop counting towards suggestions
+const historyHalfLife = 7 * 24 * time.Hour
+
+// historyEntry is a single persisted search
+type historyEntry struct {
+	Query   string    `json:"query"`
+	Mode    string    `json:"mode"` // "pattern" or "refs"
+	Project string    `json:"project,omitempty"`
+	Time    time.Time `json:"time"`
+	Results *int      `json:"results,omitempty"` // Number of results, when known
+}
+
+// suggestion is a query ranked by recency-weighted frequency
+type suggestion struct {
+	query    string
+	mode     string
+	count    int
+	lastUsed time.Time
+	score    float64
+}
+
+func historyPath() string {
+	return filepath.Join(config.GetDataDir(), "smartgrep_history.json")
+}
+
+// loadHistory reads the persisted search history, oldest first
+func loadHistory() ([]historyEntry, error) {
+	data, err := os.ReadFile(historyPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	
+	var entries []historyEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse history: %w", err)
+	}
+	return entries, nil
+}
+
+// recordSearch appends a search to the persisted history
+func recordSearch(mode, query string) error {
+	return appendHistory(historyEntry{Query: query, Mode: mode})
+}
+
+// recordSearchResults appends a search that found count results
+func recordSearchResults(mode, query string, count int) error {
+	return appendHistory(historyEntry{Query: query, Mode: mode, Results: &count})
+}
+
+// appendHistory stamps entry with the project and time and persists it
+func appendHistory(entry historyEntry) error {
+	if entry.Query == "" {
+		return nil
+	}
+	
+	entries, err := loadHistory()
+	if err != nil {
+		// Start over rather than failing every search on a corrupt file,
+		// but keep the old one for the user to look at
+		backup := historyPath() + ".bad"
+		if renameErr := os.Rename(historyPath(), backup); renameErr != nil {
+			return fmt.Errorf("%w (and could not move it aside: %v)", err, renameErr)
+		}
+		config.Logf("event=history-unreadable err=%q moved-to=%q", err, backup)
+		entries = nil
+	}
+	
+	entry.Project = historyProject()
+	entry.Time = time.Now()
+	entries = append(entries, entry)
+	if len(entries) > maxHistoryEntries {
+		entries = entries[len(entries)-maxHistoryEntries:]
+	}
+	
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(historyPath(), data)
+}
+
+// writeFileAtomic replaces path with data through a temporary file, so a
+// crash or a concurrent reader never sees a half-written file
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// ClearHistory deletes the persisted search history
+func ClearHistory() error {
+	if err := os.Remove(historyPath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// PrintHistory writes the latest limit searches, newest first, as a table or
+// as JSON. Only searches made in the current project are listed unless
+// allProjects is set.
+func PrintHistory(w io.Writer, limit int, allProjects, asJSON bool) error {
+	entries, err := loadHistory()
+	if err != nil {
+		return err
+	}
+	
+	project := historyProject()
+	var shown []historyEntry
+	for i := len(entries) - 1; i >= 0 && (limit <= 0 || len(shown) < limit); i-- {
+		if allProjects || entries[i].Project == project {
+			shown = append(shown, entries[i])
+		}
+	}
+	
+	if asJSON {
+		if shown == nil {
+			shown = []historyEntry{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(shown)
+	}
+	
+	if len(shown) == 0 {
+		fmt.Fprintln(w, "No searches recorded yet")
+		return nil
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, e := range shown {
+		results := "-"
+		if e.Results != nil {
+			results = fmt.Sprintf("%d results", *e.Results)
+		}
+		line := fmt.Sprintf("%s\t%s\t%s\t%s", e.Time.Local().Format("2006-01-02 15:04"), e.Mode, e.Query, results)
+		if allProjects {
+			line += "\t" + e.Project
+		}
+		fmt.Fprintln(tw, line)
+	}
+	return tw.Flush()
+}
+
+// historyProject is the project searches are recorded against
+func historyProject() string {
+	return projectRoot()
+}
+
+// recentSearches returns the latest distinct searches made in project,
+// newest first
+func recentSearches(entries []historyEntry, project string, limit int) []historyEntry {
+	var recent []historyEntry
+	seen := make(map[string]bool)
+	for i := len(entries) - 1; i >= 0 && len(recent) < limit; i-- {
+		e := entries[i]
+		key := e.Mode + "\x00" + e.Query
+		if e.Project != project || seen[key] {
+			continue
+		}
+		seen[key] = true
+		recent = append(recent, e)
+	}
+	return recent
+}
+
+// historyItems builds the menu's "Recent searches" entries for project,
+// followed by frequently used searches not already listed
+func historyItems(entries []historyEntry, project string, now time.Time) []list.Item {
+	var items []list.Item
+	listed := make(map[string]bool)
+	
+	for _, e := range recentSearches(entries, project, maxRecentSearches) {
+		listed[e.Mode+"\x00"+e.Query] = true
+		items = append(items, menuItem{
+			title:       fmt.Sprintf("🕘 %s %s", modeIcon(e.Mode), e.Query),
+			description: fmt.Sprintf("Recent search • %s", formatAge(now.Sub(e.Time))),
+			action:      "suggestion",
+			query:       e.Query,
+			queryMode:   e.Mode,
+		})
+	}
+	
+	for _, sg := range topSuggestions(entries, project, 5, now) {
+		if listed[sg.mode+"\x00"+sg.query] {
+			continue
+		}
+		items = append(items, menuItem{
+			title:       fmt.Sprintf("⭐ %s %s", modeIcon(sg.mode), sg.query),
+			description: fmt.Sprintf("Searched %d× • last %s", sg.count, formatAge(now.Sub(sg.lastUsed))),
+			action:      "suggestion",
+			query:       sg.query,
+			queryMode:   sg.mode,
+		})
+	}
+	return items
+}
+
+// historyWarning is the menu entry shown instead of past searches when the
+// history file can't be read
+func historyWarning(err error) list.Item {
+	return menuItem{
+		title:       "⚠️  Search history unreadable",
+		description: fmt.Sprintf("%v • moved to %s.bad on the next search", err, filepath.Base(historyPath())),
+		action:      "notice",
+	}
+}
+
+// modeIcon is the menu icon for a search mode
+func modeIcon(mode string) string {
+	if mode == "refs" {
+		return "🔗"
+	}
+	return "🔍"
+}
+
+// topSuggestions ranks past queries made in project by frequency, decayed
+// by recency
+func topSuggestions(entries []historyEntry, project string, limit int, now time.Time) []suggestion {
+	byKey := make(map[string]*suggestion)
+	var order []string
+	
+	for _, e := range entries {
+		if e.Project != project {
+			continue
+		}
+		key := e.Mode + "\x00" + e.Query
+		s, ok := byKey[key]
+		if !ok {
+			s = &suggestion{query: e.Query, mode: e.Mode}
+			byKey[key] = s
+			order = append(order, key)
+		}
+		
+		age := now.Sub(e.Time)
+		if age < 0 {
+			age = 0
+		}
+		s.score += math.Pow(0.5, float64(age)/float64(historyHalfLife))
+		s.count++
+		if e.Time.After(s.lastUsed) {
+			s.lastUsed = e.Time
+		}
+	}
+	
+	suggestions := make([]suggestion, 0, len(order))
+	for _, key := range order {
+		suggestions = append(suggestions, *byKey[key])
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].score != suggestions[j].score {
+			return suggestions[i].score > suggestions[j].score
+		}
+		return suggestions[i].lastUsed.After(suggestions[j].lastUsed)
+	})
+	
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+// formatAge renders a duration as a short "time ago" string
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
@@ -0,0 +1,165 @@
+package smartgrep
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecordSearchCapsHistory(t *testing.T) {
+	t.Setenv("CURATOR_DATA_DIR", t.TempDir())
+
+	for i := 0; i < maxHistoryEntries+3; i++ {
+		if err := recordSearch("pattern", "auth"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	recordSearch("pattern", "")
+
+	entries, err := loadHistory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != maxHistoryEntries {
+		t.Errorf("history has %d entries, want %d", len(entries), maxHistoryEntries)
+	}
+	if entries[0].Project != historyProject() {
+		t.Errorf("entry project = %q, want %q", entries[0].Project, historyProject())
+	}
+}
+
+func TestRecentSearches(t *testing.T) {
+	now := time.Now()
+	entries := []historyEntry{
+		{Query: "auth", Mode: "pattern", Project: "/a", Time: now.Add(-3 * time.Hour)},
+		{Query: "login", Mode: "pattern", Project: "/b", Time: now.Add(-2 * time.Hour)},
+		{Query: "auth", Mode: "refs", Project: "/a", Time: now.Add(-time.Hour)},
+		{Query: "auth", Mode: "pattern", Project: "/a", Time: now},
+	}
+
+	recent := recentSearches(entries, "/a", 5)
+	if len(recent) != 2 {
+		t.Fatalf("got %d recent searches, want 2: %+v", len(recent), recent)
+	}
+	if recent[0].Mode != "pattern" || recent[1].Mode != "refs" {
+		t.Errorf("want newest first, deduped per mode: %+v", recent)
+	}
+	if got := recentSearches(entries, "/a", 1); len(got) != 1 {
+		t.Errorf("limit not applied: %+v", got)
+	}
+	if got := recentSearches(entries, "/c", 5); len(got) != 0 {
+		t.Errorf("other projects should not leak in: %+v", got)
+	}
+}
+
+func TestHistoryItemsSkipsRecentSuggestions(t *testing.T) {
+	now := time.Now()
+	var entries []historyEntry
+	for i := 0; i < 3; i++ {
+		entries = append(entries,
+			historyEntry{Query: "signup", Mode: "pattern", Project: "/b", Time: now},
+			historyEntry{Query: "login", Mode: "pattern", Project: "/a", Time: now.Add(-time.Hour)},
+		)
+	}
+	for i := maxRecentSearches - 1; i >= 0; i-- {
+		entries = append(entries, historyEntry{Query: fmt.Sprintf("q%d", i), Mode: "pattern", Project: "/a", Time: now})
+	}
+
+	items := historyItems(entries, "/a", now)
+	if len(items) != maxRecentSearches+1 {
+		t.Fatalf("got %d items, want %d: %+v", len(items), maxRecentSearches+1, items)
+	}
+	recent := items[0].(menuItem)
+	if recent.title != "🕘 🔍 q0" || recent.action != "suggestion" || recent.query != "q0" {
+		t.Errorf("recent item = %+v", recent)
+	}
+	if frequent := items[len(items)-1].(menuItem); frequent.query != "login" {
+		t.Errorf("frequent item = %+v, want login and nothing from other projects", frequent)
+	}
+}
+
+func TestAppendHistoryKeepsUnreadableFile(t *testing.T) {
+	t.Setenv("CURATOR_DATA_DIR", t.TempDir())
+	if err := os.MkdirAll(filepath.Dir(historyPath()), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(historyPath(), []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	
+	if err := recordSearch("pattern", "auth"); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(historyPath() + ".bad"); err != nil || string(data) != "{not json" {
+		t.Errorf("unreadable history not kept: %q, %v", data, err)
+	}
+	entries, err := loadHistory()
+	if err != nil || len(entries) != 1 || entries[0].Query != "auth" {
+		t.Errorf("new history = %+v, %v", entries, err)
+	}
+	if tmp, _ := filepath.Glob(historyPath() + ".*.tmp"); len(tmp) != 0 {
+		t.Errorf("temporary files left behind: %v", tmp)
+	}
+}
+
+func TestPrintHistory(t *testing.T) {
+	t.Setenv("CURATOR_DATA_DIR", t.TempDir())
+	
+	var empty bytes.Buffer
+	if err := PrintHistory(&empty, 10, false, false); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(empty.String(), "No searches") {
+		t.Errorf("empty history output = %q", empty.String())
+	}
+	
+	recordSearch("refs", "login")
+	recordSearchResults("pattern", "auth", 12)
+	
+	var out bytes.Buffer
+	if err := PrintHistory(&out, 10, false, false); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2:\n%s", len(lines), out.String())
+	}
+	if !strings.Contains(lines[0], "auth") || !strings.Contains(lines[0], "12 results") {
+		t.Errorf("newest search not first with its count: %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "login") || strings.Contains(lines[1], "results") {
+		t.Errorf("search without a count = %q", lines[1])
+	}
+	
+	var js bytes.Buffer
+	if err := PrintHistory(&js, 1, false, true); err != nil {
+		t.Fatal(err)
+	}
+	var entries []historyEntry
+	if err := json.Unmarshal(js.Bytes(), &entries); err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Results == nil || *entries[0].Results != 12 {
+		t.Errorf("JSON history = %s", js.String())
+	}
+}
+
+func TestClearHistory(t *testing.T) {
+	t.Setenv("CURATOR_DATA_DIR", t.TempDir())
+	
+	if err := ClearHistory(); err != nil {
+		t.Errorf("clearing missing history: %v", err)
+	}
+	recordSearch("pattern", "auth")
+	if err := ClearHistory(); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ := loadHistory(); len(entries) != 0 {
+		t.Errorf("history not cleared: %+v", entries)
+	}
+}
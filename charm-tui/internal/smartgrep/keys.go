@@ -0,0 +1,220 @@
+package smartgrep
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// resultKeyMap holds the bindings of the search results TUI
+type resultKeyMap struct {
+	Up        key.Binding
+	Down      key.Binding
+	NextView  key.Binding
+	Details   key.Binding
+	Mark      key.Binding
+	Bookmark  key.Binding
+	Compare   key.Binding
+	Focus     key.Binding
+	Filter    key.Binding
+	Types     key.Binding
+	Sort      key.Binding
+	Reverse   key.Binding
+	Compact   key.Binding
+	Paths     key.Binding
+	Split     key.Binding
+	Watch     key.Binding
+	Export    key.Binding
+	Open      key.Binding
+	OpenFile  key.Binding
+	Copy      key.Binding
+	CopySig   key.Binding
+	Retry     key.Binding
+	Refs      key.Binding
+	Layout    key.Binding
+	Expand    key.Binding
+	ExpandAll key.Binding
+	Back      key.Binding
+	Help      key.Binding
+	Quit      key.Binding
+}
+
+var resultKeys = resultKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("up", "k"),
+		key.WithHelp("↑/k", "up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "j"),
+		key.WithHelp("↓/j", "down"),
+	),
+	NextView: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "switch view"),
+	),
+	Details: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "details"),
+	),
+	Mark: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "mark"),
+	),
+	Bookmark: key.NewBinding(
+		key.WithKeys("b"),
+		key.WithHelp("b", "bookmark"),
+	),
+	Compare: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "compare marked"),
+	),
+	Focus: key.NewBinding(
+		key.WithKeys("F"),
+		key.WithHelp("F", "focus file"),
+	),
+	Filter: key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "filter"),
+	),
+	Types: key.NewBinding(
+		key.WithKeys("0", "1", "2", "3", "4", "5", "6", "7", "8", "9"),
+		key.WithHelp("1-9", "toggle type"),
+	),
+	Sort: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "cycle sort"),
+	),
+	Reverse: key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "reverse sort"),
+	),
+	Compact: key.NewBinding(
+		key.WithKeys("C"),
+		key.WithHelp("C", "compact view"),
+	),
+	Paths: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "relative/absolute paths"),
+	),
+	Split: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "split layout"),
+	),
+	Watch: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "watch files"),
+	),
+	Export: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "export"),
+	),
+	Open: key.NewBinding(
+		key.WithKeys("o", "e"),
+		key.WithHelp("o/e", "open in editor"),
+	),
+	OpenFile: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "open file"),
+	),
+	Copy: key.NewBinding(
+		key.WithKeys("y"),
+		key.WithHelp("y", "copy file:line"),
+	),
+	CopySig: key.NewBinding(
+		key.WithKeys("Y"),
+		key.WithHelp("Y", "copy signature"),
+	),
+	Retry: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "retry search"),
+	),
+	Refs: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "find references"),
+	),
+	Layout: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "tree/flat layout"),
+	),
+	Expand: key.NewBinding(
+		key.WithKeys("enter", " "),
+		key.WithHelp("enter", "expand/collapse"),
+	),
+	ExpandAll: key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "expand/collapse all"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "back"),
+	),
+	Help: key.NewBinding(
+		key.WithKeys("?"),
+		key.WithHelp("?", "more keys"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("q", "ctrl+c"),
+		key.WithHelp("q", "quit"),
+	),
+}
+
+// viewHelp adapts the bindings of one view to the bubbles help component
+type viewHelp struct {
+	short []key.Binding
+	full  [][]key.Binding
+}
+
+func (h viewHelp) ShortHelp() []key.Binding  { return h.short }
+func (h viewHelp) FullHelp() [][]key.Binding { return h.full }
+
+// helpKeys returns the bindings that apply to the active view
+func (m resultViewModel) helpKeys() viewHelp {
+	k := resultKeys
+	nav := []key.Binding{k.Up, k.Down, k.NextView}
+	general := []key.Binding{k.Help, k.Quit}
+	
+	if m.canRetry() {
+		return viewHelp{
+			short: []key.Binding{k.Retry, k.Help, k.Quit},
+			full:  [][]key.Binding{nav, {k.Retry}, general},
+		}
+	}
+	
+	switch m.activeView {
+	case "list":
+		actions := []key.Binding{k.Details, k.Filter, k.Types, k.Sort, k.Reverse, k.Compact, k.Paths, k.Split, k.Watch, k.Mark, k.Bookmark, k.Compare, k.Focus, k.Export}
+		return viewHelp{
+			short: []key.Binding{k.Details, k.Filter, k.Mark, k.NextView, k.Help, k.Quit},
+			full:  [][]key.Binding{nav, actions, general},
+		}
+	case "detail":
+		actions := []key.Binding{k.Open, k.Refs, k.Copy, k.CopySig, k.Bookmark, k.Compare, k.Focus, k.Compact, k.Paths}
+		return viewHelp{
+			short: []key.Binding{k.Open, k.Refs, k.Copy, k.NextView, k.Help, k.Quit},
+			full:  [][]key.Binding{nav, actions, {k.Back}, general},
+		}
+	case "stats":
+		return viewHelp{
+			short: []key.Binding{k.Up, k.Down, k.OpenFile, k.NextView, k.Help, k.Quit},
+			full:  [][]key.Binding{nav, {k.OpenFile, k.Types}, general},
+		}
+	case "bookmarks":
+		return viewHelp{
+			short: []key.Binding{k.Up, k.Down, k.OpenFile, k.Bookmark, k.NextView, k.Help, k.Quit},
+			full:  [][]key.Binding{nav, {k.OpenFile, k.Bookmark}, general},
+		}
+	case "graph":
+		return viewHelp{
+			short: []key.Binding{k.Layout, k.NextView, k.Help, k.Quit},
+			full:  [][]key.Binding{nav, {k.Layout}, general},
+		}
+	case "compare":
+		return viewHelp{
+			short: []key.Binding{k.Back, k.NextView, k.Help, k.Quit},
+			full:  [][]key.Binding{nav, {k.Back}, general},
+		}
+	default:
+		return viewHelp{
+			short: []key.Binding{k.NextView, k.Up, k.Down, k.Help, k.Quit},
+			full:  [][]key.Binding{nav, general},
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package smartgrep
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DefaultTokenBudget is the default size of --for-llm output, in tokens
+const DefaultTokenBudget = 4000
+
+// maxLLMRefs is how many references are listed per result
+const maxLLMRefs = 3
+
+// estimateTokens approximates the token count of text (~4 chars per token)
+func estimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// PrintForLLM runs a search and writes the results in a compact, unstyled
+// text format meant to be pasted into an LLM prompt. Cancelling ctx stops
+// the search.
+func PrintForLLM(ctx context.Context, w io.Writer, args []string, budget int) error {
+	results, err := fetchSearchResults(ctx, args...)
+	if err != nil {
+		return err
+	}
+	results = applyThresholds(results, minRelevance, minUsage)
+	
+	_, err = io.WriteString(w, formatForLLM(searchQuery(args), results, budget))
+	return err
+}
+
+// searchQuery returns the pattern of a search's CLI arguments
+func searchQuery(args []string) string {
+	query := ""
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "--") {
+			break
+		}
+		query = strings.TrimSpace(query + " " + arg)
+	}
+	return query
+}
+
+// formatForLLM renders results as structured text, deduplicated and truncated
+// to fit within budget tokens
+func formatForLLM(query string, results []searchResult, budget int) string {
+	// Deduplicate by location; the CLI can report the same symbol more than once
+	seen := make(map[string]bool)
+	var deduped []searchResult
+	for _, r := range results {
+		key := resultKey(r)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		deduped = append(deduped, r)
+	}
+	
+	var out strings.Builder
+	fmt.Fprintf(&out, "# smartgrep results for %q (%d)\n", query, len(deduped))
+	used := estimateTokens(out.String())
+	
+	for i, r := range deduped {
+		entry := formatLLMEntry(r)
+		cost := estimateTokens(entry)
+		if budget > 0 && used+cost > budget {
+			fmt.Fprintf(&out, "\n[%d more results omitted to fit token budget]\n", len(deduped)-i)
+			break
+		}
+		out.WriteString(entry)
+		used += cost
+	}
+	
+	return out.String()
+}
+
+func formatLLMEntry(r searchResult) string {
+	var entry strings.Builder
+	
+	fmt.Fprintf(&entry, "\n## %s %s @ %s:%d", r.typ, r.term, r.location.file, r.location.line)
+	fmt.Fprintf(&entry, " (relevance %.0f%%", r.relevance*100)
+	if r.usageCount > 0 {
+		fmt.Fprintf(&entry, ", %d uses", r.usageCount)
+	}
+	entry.WriteString(")\n")
+	
+	sig := extractSignature(r)
+	if sig == "" {
+		sig = r.context
+	}
+	if sig = strings.TrimSpace(sig); sig != "" {
+		fmt.Fprintf(&entry, "code: %s\n", sig)
+	}
+	
+	if len(r.references) > 0 {
+		var refs []string
+		for i, ref := range r.references {
+			if i >= maxLLMRefs {
+				refs = append(refs, fmt.Sprintf("+%d more", len(r.references)-maxLLMRefs))
+				break
+			}
+			refs = append(refs, fmt.Sprintf("%s:%d (%s)", ref.from.file, ref.from.line, ref.typ))
+		}
+		fmt.Fprintf(&entry, "refs: %s\n", strings.Join(refs, ", "))
+	}
+	
+	if len(r.related) > 0 {
+		fmt.Fprintf(&entry, "related: %s\n", strings.Join(unique(r.related), ", "))
+	}
+	
+	return entry.String()
+}
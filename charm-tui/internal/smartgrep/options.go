@@ -0,0 +1,198 @@
+package smartgrep
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/table"
+)
+
+// TUIOptions are the search flags the TUI honours
+type TUIOptions struct {
+	Query   string // Pattern to search for; empty opens the menu
+	Type    string // Comma-separated result types, as for --type
+	Sort    string // relevance, usage, name or file
+	Max     int    // Most results to show, 0 for no limit
+	Compact bool   // Hide the score and usage columns
+	Watch   bool   // Re-run the search when project files change
+	Select  string // Result to open in the detail view: its number or file:line
+}
+
+// searchArgs returns the CLI arguments of the initial search
+func (o TUIOptions) searchArgs() []string {
+	args := []string{o.Query}
+	if o.Type != "" {
+		args = append(args, "--type", o.Type)
+	}
+	if o.Sort != "" {
+		args = append(args, "--sort", o.Sort)
+	}
+	if o.Max > 0 {
+		args = append(args, "--max", strconv.Itoa(o.Max))
+	}
+	return args
+}
+
+// ChangesOptions choose which changes the changes TUI analyzes. They are
+// forwarded to the CLI as --staged and --since <ref>.
+type ChangesOptions struct {
+	Staged bool   // Only changes staged for commit
+	Since  string // Compare the working tree with this git ref instead of HEAD
+}
+
+// changesArgs returns the CLI arguments of the changes analysis
+func (o ChangesOptions) changesArgs() []string {
+	args := []string{"changes", "--json"}
+	if o.Staged {
+		args = append(args, "--staged")
+	}
+	if o.Since != "" {
+		args = append(args, "--since", o.Since)
+	}
+	return args
+}
+
+// diffArgs returns the git diff arguments showing the same changes. The ref
+// follows --end-of-options so git never reads it as an option.
+func (o ChangesOptions) diffArgs() []string {
+	switch {
+	case o.Staged:
+		return []string{"diff", "--cached"}
+	case o.Since != "":
+		return []string{"diff", "--end-of-options", o.Since, "--"}
+	default:
+		return []string{"diff", "HEAD"}
+	}
+}
+
+// scope describes the changes analyzed, for the view's header
+func (o ChangesOptions) scope() string {
+	switch {
+	case o.Staged:
+		return "staged"
+	case o.Since != "":
+		return "since " + o.Since
+	default:
+		return "uncommitted"
+	}
+}
+
+// noChanges reports that there is nothing to analyze
+func (o ChangesOptions) noChanges() string {
+	switch {
+	case o.Staged:
+		return "No staged changes"
+	case o.Since != "":
+		return "No changes since " + o.Since
+	default:
+		return "No changes in working directory"
+	}
+}
+
+// sortKeys are the orders the list can be sorted in, in the order the sort
+// key cycles through them
+var sortKeys = []string{"relevance", "usage", "name", "file"}
+
+// resultComparators report whether a sorts before b for each sort key
+var resultComparators = map[string]func(a, b searchResult) bool{
+	"relevance": byRelevance,
+	"usage":     byUsage,
+	"name":      byName,
+	"file":      byFile,
+}
+
+func byRelevance(a, b searchResult) bool { return a.relevance > b.relevance }
+func byUsage(a, b searchResult) bool     { return a.usageCount > b.usageCount }
+func byName(a, b searchResult) bool      { return a.term < b.term }
+
+func byFile(a, b searchResult) bool {
+	if a.location.file != b.location.file {
+		return a.location.file < b.location.file
+	}
+	return a.location.line < b.location.line
+}
+
+// sortResults orders results the way the CLI's --sort does: by relevance,
+// usage count, term or file, reversed if asked. Ties keep their original
+// order, and an unknown key keeps the CLI's order.
+func sortResults(results []searchResult, by string, reverse bool) []searchResult {
+	less, ok := resultComparators[by]
+	if !ok {
+		return results
+	}
+	
+	sorted := append([]searchResult(nil), results...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if reverse {
+			return less(sorted[j], sorted[i])
+		}
+		return less(sorted[i], sorted[j])
+	})
+	return sorted
+}
+
+// nextSortKey returns the sort key after by, starting from relevance
+func nextSortKey(by string) string {
+	for i, k := range sortKeys {
+		if k == by {
+			return sortKeys[(i+1)%len(sortKeys)]
+		}
+	}
+	return sortKeys[1]
+}
+
+// cycleSort re-sorts the list by the next sort key, or reverses the current
+// order, without fetching the results again
+func (m *resultViewModel) cycleSort(reverse bool) {
+	if reverse {
+		if m.sortBy == "" {
+			m.sortBy = "relevance"
+		}
+		m.sortDesc = !m.sortDesc
+	} else {
+		m.sortBy = nextSortKey(m.sortBy)
+		m.sortDesc = false
+	}
+	m.setResults(m.results)
+	m.table.SetCursor(0)
+	m.syncSelected()
+}
+
+// sortLabel describes the active sort for the list header
+func (m resultViewModel) sortLabel() string {
+	by := m.sortBy
+	if by == "" {
+		by = "relevance"
+	}
+	if m.sortDesc {
+		return "sorted by " + by + " (reversed)"
+	}
+	return "sorted by " + by
+}
+
+// resultColumns returns the list view's table columns
+func resultColumns(compact bool) []table.Column {
+	columns := []table.Column{
+		{Title: "🎯 Term", Width: 20},
+		{Title: "📦 Type", Width: 10},
+		{Title: "📍 Location", Width: 30},
+	}
+	if compact {
+		return columns
+	}
+	return append(columns,
+		table.Column{Title: "📈 Score", Width: 8},
+		table.Column{Title: "🔢 Uses", Width: 8},
+	)
+}
+
+// applyOptions configures the view for opts before its first search
+func (m *resultViewModel) applyOptions(opts TUIOptions) {
+	m.query = opts.Query
+	m.sortBy = opts.Sort
+	m.maxResults = opts.Max
+	m.compact = opts.Compact
+	m.table.SetColumns(resultColumns(opts.Compact))
+	m.searchArgs = opts.searchArgs()
+	m.watching = opts.Watch
+}
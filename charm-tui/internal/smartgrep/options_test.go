@@ -0,0 +1,127 @@
+package smartgrep
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTUIOptionsSearchArgs(t *testing.T) {
+	opts := TUIOptions{Query: "auth", Type: "function,class", Sort: "usage", Max: 20}
+	want := []string{"auth", "--type", "function,class", "--sort", "usage", "--max", "20"}
+	if got := opts.searchArgs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("searchArgs() = %v, want %v", got, want)
+	}
+	if got := (TUIOptions{Query: "auth"}).searchArgs(); !reflect.DeepEqual(got, []string{"auth"}) {
+		t.Errorf("searchArgs() without flags = %v", got)
+	}
+}
+
+func TestChangesOptionsArgs(t *testing.T) {
+	tests := []struct {
+		opts       ChangesOptions
+		args, diff []string
+	}{
+		{ChangesOptions{}, []string{"changes", "--json"}, []string{"diff", "HEAD"}},
+		{ChangesOptions{Staged: true}, []string{"changes", "--json", "--staged"}, []string{"diff", "--cached"}},
+		{ChangesOptions{Since: "v1.2"}, []string{"changes", "--json", "--since", "v1.2"}, []string{"diff", "--end-of-options", "v1.2", "--"}},
+	}
+	for _, tt := range tests {
+		if got := tt.opts.changesArgs(); !reflect.DeepEqual(got, tt.args) {
+			t.Errorf("%+v changesArgs() = %v, want %v", tt.opts, got, tt.args)
+		}
+		if got := tt.opts.diffArgs(); !reflect.DeepEqual(got, tt.diff) {
+			t.Errorf("%+v diffArgs() = %v, want %v", tt.opts, got, tt.diff)
+		}
+	}
+}
+
+func TestSortResults(t *testing.T) {
+	results := []searchResult{
+		{term: "b", location: location{file: "z.ts", line: 1}, relevance: 0.5, usageCount: 9},
+		{term: "a", location: location{file: "y.ts", line: 5}, relevance: 0.9, usageCount: 1},
+		{term: "c", location: location{file: "y.ts", line: 2}, relevance: 0.7, usageCount: 4},
+	}
+	terms := func(rs []searchResult) string {
+		s := ""
+		for _, r := range rs {
+			s += r.term
+		}
+		return s
+	}
+	
+	tests := map[string]string{"relevance": "acb", "usage": "bca", "name": "abc", "file": "cab", "": "bac"}
+	for by, want := range tests {
+		if got := terms(sortResults(results, by, false)); got != want {
+			t.Errorf("sortResults(%q) = %s, want %s", by, got, want)
+		}
+	}
+	if got := terms(sortResults(results, "name", true)); got != "cba" {
+		t.Errorf("reversed sortResults(name) = %s, want cba", got)
+	}
+	if terms(results) != "bac" {
+		t.Error("sortResults should not reorder its input")
+	}
+}
+
+func TestApplyOptionsLimitsAndCompacts(t *testing.T) {
+	m := newResultViewModel()
+	m.applyOptions(TUIOptions{Query: "auth", Sort: "name", Max: 2, Compact: true})
+	m.setResults([]searchResult{{term: "c"}, {term: "a"}, {term: "b"}})
+	
+	if len(m.results) != 2 || m.results[0].term != "a" || m.results[1].term != "b" {
+		t.Errorf("results = %+v, want a and b", m.results)
+	}
+	if row := m.table.Rows()[0]; len(row) != 3 {
+		t.Errorf("compact row = %v", row)
+	}
+}
+
+func TestComparators(t *testing.T) {
+	a := searchResult{term: "a", location: location{file: "x.ts", line: 9}, relevance: 0.9, usageCount: 1}
+	b := searchResult{term: "b", location: location{file: "x.ts", line: 2}, relevance: 0.4, usageCount: 7}
+	
+	if !byRelevance(a, b) || byRelevance(b, a) {
+		t.Error("byRelevance should put the higher score first")
+	}
+	if !byUsage(b, a) || byUsage(a, b) {
+		t.Error("byUsage should put the most used first")
+	}
+	if !byName(a, b) || byName(b, a) {
+		t.Error("byName should sort terms alphabetically")
+	}
+	if !byFile(b, a) || byFile(a, b) {
+		t.Error("byFile should order by line within a file")
+	}
+	if byRelevance(a, a) {
+		t.Error("comparators must be strict")
+	}
+}
+
+func TestCycleSort(t *testing.T) {
+	m := newResultViewModel()
+	m.setResults([]searchResult{{term: "b", usageCount: 1}, {term: "a", usageCount: 5}})
+	
+	press := func(k string) {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		m = updated.(resultViewModel)
+	}
+	press("s")
+	if m.sortBy != "usage" || m.results[0].term != "a" {
+		t.Errorf("s should sort by usage, got %s %+v", m.sortBy, m.results)
+	}
+	press("S")
+	if !m.sortDesc || m.results[0].term != "b" {
+		t.Errorf("S should reverse, got %+v", m.results)
+	}
+	if label := m.sortLabel(); label != "sorted by usage (reversed)" {
+		t.Errorf("sortLabel() = %q", label)
+	}
+	for i := 0; i < 3; i++ {
+		press("s")
+	}
+	if m.sortBy != "relevance" || m.sortDesc {
+		t.Errorf("sort should cycle back to relevance, got %s desc=%v", m.sortBy, m.sortDesc)
+	}
+}
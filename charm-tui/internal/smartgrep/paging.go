@@ -0,0 +1,67 @@
+package smartgrep
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const (
+	pageSize   = 200 // Table rows built at a time
+	pageMargin = 20  // Rows from a window edge that trigger sliding it
+)
+
+// pageBounds returns the [start, end) range of visible results in the window
+func (m resultViewModel) pageBounds() (int, int) {
+	end := m.pageStart + pageSize
+	if end > len(m.visible) {
+		end = len(m.visible)
+	}
+	return m.pageStart, end
+}
+
+// slidePage re-centers the window on the cursor once it gets near an edge
+// that has more results beyond it, keeping the same result selected
+func (m *resultViewModel) slidePage() {
+	start, end := m.pageBounds()
+	cursor := m.table.Cursor()
+	nearTop := cursor < pageMargin && start > 0
+	nearBottom := cursor >= end-start-pageMargin && end < len(m.visible)
+	if !nearTop && !nearBottom {
+		return
+	}
+	
+	abs := start + cursor
+	m.pageStart = clampPageStart(abs-pageSize/2, len(m.visible))
+	m.refreshRows()
+	m.table.SetCursor(abs - m.pageStart)
+	m.syncSelected()
+}
+
+// clampPageStart keeps a window start inside a list of n results
+func clampPageStart(start, n int) int {
+	if start > n-pageSize {
+		start = n - pageSize
+	}
+	if start < 0 {
+		start = 0
+	}
+	return start
+}
+
+// pageView shows which part of a large result list is loaded in the table
+func (m resultViewModel) pageView() string {
+	if len(m.visible) <= pageSize {
+		return ""
+	}
+	start, end := m.pageBounds()
+	return fmt.Sprintf("showing %s–%s of %s", formatCount(start+1), formatCount(end), formatCount(len(m.visible)))
+}
+
+// formatCount renders n with thousands separators, e.g. 12,034
+func formatCount(n int) string {
+	s := strconv.Itoa(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
@@ -0,0 +1,67 @@
+package smartgrep
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFormatCount(t *testing.T) {
+	tests := map[int]string{0: "0", 200: "200", 1000: "1,000", 12034: "12,034", 1234567: "1,234,567"}
+	for n, want := range tests {
+		if got := formatCount(n); got != want {
+			t.Errorf("formatCount(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func pagedModel(n int) resultViewModel {
+	results := make([]searchResult, n)
+	for i := range results {
+		results[i] = searchResult{term: fmt.Sprintf("term%d", i), location: location{file: "src/a.ts", line: i}}
+	}
+	m := newResultViewModel()
+	m.setResults(results)
+	return m
+}
+
+func TestPageOnlyBuildsWindowRows(t *testing.T) {
+	m := pagedModel(12034)
+	if got := len(m.table.Rows()); got != pageSize {
+		t.Errorf("rows = %d, want %d", got, pageSize)
+	}
+	if got := m.pageView(); got != "showing 1–200 of 12,034" {
+		t.Errorf("pageView() = %q", got)
+	}
+}
+
+func TestSlidePageKeepsSelection(t *testing.T) {
+	m := pagedModel(1000)
+	m.table.SetCursor(pageSize - 5)
+	m.syncSelected()
+	m.slidePage()
+	
+	if m.pageStart == 0 {
+		t.Fatal("window did not slide near the bottom edge")
+	}
+	if m.selected != pageSize-5 {
+		t.Errorf("selected = %d, want %d", m.selected, pageSize-5)
+	}
+	if got := m.results[m.selected].term; m.table.SelectedRow()[0] != got {
+		t.Errorf("table row %v does not match result %q", m.table.SelectedRow(), got)
+	}
+	
+	m.table.SetCursor(0)
+	m.syncSelected()
+	want := m.selected
+	m.slidePage()
+	if m.selected != want || m.pageStart != 0 {
+		t.Errorf("after sliding up selected = %d (want %d), pageStart = %d", m.selected, want, m.pageStart)
+	}
+}
+
+func TestSmallResultSetsAreNotPaged(t *testing.T) {
+	m := pagedModel(50)
+	if got := m.pageView(); got != "" {
+		t.Errorf("pageView() = %q, want empty", got)
+	}
+}
@@ -0,0 +1,122 @@
+package smartgrep
+
+import "io"
+
+// The TS* types mirror the JSON emitted by the TypeScript CLI's --json mode
+// (SearchResult, SemanticInfo and CrossReference in semantic-core). Decode
+// into them and convert with the to* methods rather than redeclaring the
+// schema inline.
+
+// TSLocation is a position in a source file
+type TSLocation struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+func (l TSLocation) toLocation() location {
+	return location{file: l.File, line: l.Line, column: l.Column}
+}
+
+// TSUsage is a reference to a term from elsewhere in the codebase
+type TSUsage struct {
+	TargetTerm    string     `json:"targetTerm"`
+	ReferenceType string     `json:"referenceType"`
+	FromLocation  TSLocation `json:"fromLocation"`
+	Context       string     `json:"context"`
+}
+
+func (u TSUsage) toReference() reference {
+	return reference{
+		typ:     u.ReferenceType,
+		target:  u.TargetTerm,
+		from:    u.FromLocation.toLocation(),
+		context: u.Context,
+	}
+}
+
+func (r reference) toTSUsage() TSUsage {
+	return TSUsage{
+		TargetTerm:    r.target,
+		ReferenceType: r.typ,
+		FromLocation:  TSLocation{r.from.file, r.from.line, r.from.column},
+		Context:       r.context,
+	}
+}
+
+// TSInfo describes the term a result matched
+type TSInfo struct {
+	Term             string                 `json:"term"`
+	Type             string                 `json:"type"`
+	Location         TSLocation             `json:"location"`
+	Context          string                 `json:"context"`
+	SurroundingLines []string               `json:"surroundingLines"`
+	RelatedTerms     []string               `json:"relatedTerms"`
+	Language         string                 `json:"language"`
+	Metadata         map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// TSResult is one element of the search results array
+type TSResult struct {
+	Info           TSInfo    `json:"info"`
+	RelevanceScore float64   `json:"relevanceScore"`
+	UsageCount     int       `json:"usageCount,omitempty"`
+	SampleUsages   []TSUsage `json:"sampleUsages,omitempty"`
+}
+
+// toSearchResult converts the TypeScript schema to our internal format
+func (tr TSResult) toSearchResult() searchResult {
+	result := searchResult{
+		term:        tr.Info.Term,
+		typ:         tr.Info.Type,
+		location:    tr.Info.Location.toLocation(),
+		context:     tr.Info.Context,
+		surrounding: tr.Info.SurroundingLines,
+		related:     tr.Info.RelatedTerms,
+		language:    tr.Info.Language,
+		relevance:   tr.RelevanceScore,
+		usageCount:  tr.UsageCount,
+		metadata:    tr.Info.Metadata,
+	}
+	for _, usage := range tr.SampleUsages {
+		result.references = append(result.references, usage.toReference())
+	}
+	return result
+}
+
+// toTSResult converts a result back to the TypeScript schema, for output
+// that must match the CLI's own --json
+func (r searchResult) toTSResult() TSResult {
+	tr := TSResult{
+		Info: TSInfo{
+			Term:             r.term,
+			Type:             r.typ,
+			Location:         TSLocation{r.location.file, r.location.line, r.location.column},
+			Context:          r.context,
+			SurroundingLines: r.surrounding,
+			RelatedTerms:     r.related,
+			Language:         r.language,
+			Metadata:         r.metadata,
+		},
+		RelevanceScore: r.relevance,
+		UsageCount:     r.usageCount,
+	}
+	for _, ref := range r.references {
+		tr.SampleUsages = append(tr.SampleUsages, ref.toTSUsage())
+	}
+	return tr
+}
+
+// ParseResults decodes search output from `--json` mode, skipping any
+// progress lines printed before the array. Decoding stops after
+// maxStreamResults results.
+func ParseResults(r io.Reader) ([]searchResult, error) {
+	var results []searchResult
+	err := decodeSearchResults(r, maxStreamResults, func(sr searchResult) {
+		results = append(results, sr)
+	})
+	if err == errResultLimit {
+		err = nil
+	}
+	return results, err
+}
@@ -0,0 +1,94 @@
+package smartgrep
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseResultsFull(t *testing.T) {
+	output := `[{
+		"info": {
+			"term": "login",
+			"type": "function",
+			"location": {"file": "src/auth.ts", "line": 3, "column": 7},
+			"context": "export function login()",
+			"surroundingLines": ["// auth", "export function login()"],
+			"relatedTerms": ["logout"],
+			"language": "typescript",
+			"metadata": {"async": true, "params": ["user"], "exported": 1}
+		},
+		"relevanceScore": 0.75,
+		"usageCount": 2,
+		"sampleUsages": [
+			{"targetTerm": "login", "referenceType": "call", "fromLocation": {"file": "src/app.ts", "line": 9, "column": 2}, "context": "login()"}
+		]
+	}]`
+	
+	results, err := ParseResults(strings.NewReader(output))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := searchResult{
+		term:        "login",
+		typ:         "function",
+		location:    location{file: "src/auth.ts", line: 3, column: 7},
+		context:     "export function login()",
+		surrounding: []string{"// auth", "export function login()"},
+		related:     []string{"logout"},
+		language:    "typescript",
+		relevance:   0.75,
+		usageCount:  2,
+		references: []reference{
+			{typ: "call", target: "login", from: location{file: "src/app.ts", line: 9, column: 2}, context: "login()"},
+		},
+		metadata: map[string]interface{}{
+			"async":    true,
+			"params":   []interface{}{"user"},
+			"exported": float64(1),
+		},
+	}
+	if len(results) != 1 || !reflect.DeepEqual(results[0], want) {
+		t.Errorf("ParseResults() = %+v, want %+v", results, want)
+	}
+}
+
+func TestParseResultsMissingFields(t *testing.T) {
+	results, err := ParseResults(strings.NewReader(`[{"info": {"term": "x"}}, {}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	if r := results[0]; r.term != "x" || r.location != (location{}) || r.references != nil || r.metadata != nil {
+		t.Errorf("missing fields should be zero, got %+v", r)
+	}
+	if !reflect.DeepEqual(results[1], searchResult{}) {
+		t.Errorf("empty object should give a zero result, got %+v", results[1])
+	}
+}
+
+func TestParseResultsEmptyArrays(t *testing.T) {
+	results, err := ParseResults(strings.NewReader("Searching...\n[]\n"))
+	if err != nil || len(results) != 0 {
+		t.Errorf("empty array: got %v, %v", results, err)
+	}
+	
+	results, err = ParseResults(strings.NewReader(`[{"info": {"term": "x", "surroundingLines": [], "metadata": {}}, "sampleUsages": []}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := results[0]
+	if len(r.surrounding) != 0 || len(r.references) != 0 || len(r.metadata) != 0 {
+		t.Errorf("empty arrays should stay empty, got %+v", r)
+	}
+}
+
+func TestParseResultsErrors(t *testing.T) {
+	for _, output := range []string{"No results found\n", `[{"info": `, `[{"info": {"term": 5}}]`} {
+		if _, err := ParseResults(strings.NewReader(output)); err == nil {
+			t.Errorf("ParseResults(%q) should fail", output)
+		}
+	}
+}
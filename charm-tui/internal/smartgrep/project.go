@@ -0,0 +1,49 @@
+package smartgrep
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// projectDir is the project root backend processes run in; empty means the
+// current directory
+var projectDir string
+
+// SetProjectDir runs smartgrep and git against dir instead of the current
+// directory
+func SetProjectDir(dir string) {
+	if dir == "" {
+		projectDir = ""
+		return
+	}
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	projectDir = dir
+}
+
+// projectRoot is the directory result paths are relative to
+func projectRoot() string {
+	if projectDir != "" {
+		return projectDir
+	}
+	wd, _ := os.Getwd()
+	return wd
+}
+
+// displayPath shows a result path relative to the project root, or as an
+// absolute path. Paths outside the project stay as the CLI reported them.
+func displayPath(file string, relative bool) string {
+	if !relative {
+		return resolveResultPath(file)
+	}
+	if !filepath.IsAbs(file) {
+		return file
+	}
+	rel, err := filepath.Rel(projectRoot(), file)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return file
+	}
+	return rel
+}
@@ -0,0 +1,369 @@
+package smartgrep
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/style"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// refContextRadius is how many lines around a reference the detail pane shows
+const refContextRadius = 4
+
+// refTypeOrder controls how reference groups are listed
+var refTypeOrder = map[string]int{
+	"call":           0,
+	"import":         1,
+	"extends":        2,
+	"implements":     3,
+	"instantiation":  4,
+	"type-reference": 5,
+}
+
+// parseReferences extracts references from `refs --json` output
+func parseReferences(output []byte) ([]reference, error) {
+	payload, err := findJSONArray(output)
+	if err != nil {
+		return nil, err
+	}
+	
+	var raw []TSUsage
+	if err := json.Unmarshal(payload, &raw); err != nil {
+		config.LogParseError("refs", err)
+		return nil, fmt.Errorf("failed to parse references: %w", err)
+	}
+	
+	refs := make([]reference, len(raw))
+	for i, r := range raw {
+		refs[i] = r.toReference()
+	}
+	return refs, nil
+}
+
+// sortReferences groups references by type, then orders them by location
+func sortReferences(refs []reference) {
+	rank := func(typ string) int {
+		if r, ok := refTypeOrder[typ]; ok {
+			return r
+		}
+		return len(refTypeOrder)
+	}
+	sort.SliceStable(refs, func(i, j int) bool {
+		a, b := refs[i], refs[j]
+		if rank(a.typ) != rank(b.typ) {
+			return rank(a.typ) < rank(b.typ)
+		}
+		if a.typ != b.typ {
+			return a.typ < b.typ
+		}
+		if a.from.file != b.from.file {
+			return a.from.file < b.from.file
+		}
+		return a.from.line < b.from.line
+	})
+}
+
+// readSurrounding returns the lines around line (1-based) in file, along with
+// the number of the first line returned
+func readSurrounding(file string, line, radius int) ([]string, int, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+	
+	first := line - radius
+	if first < 1 {
+		first = 1
+	}
+	
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan() && n <= line+radius; n++ {
+		if n >= first {
+			lines = append(lines, scanner.Text())
+		}
+	}
+	return lines, first, scanner.Err()
+}
+
+type refsLoadedMsg struct {
+	refs []reference
+	err  error
+}
+
+func fetchReferences(symbol string) tea.Cmd {
+	return func() tea.Msg {
+		output, err := runSmartgrep(context.Background(), false, "refs", symbol, "--json")
+		if err != nil {
+			return refsLoadedMsg{err: fmt.Errorf("failed to find references: %w", err)}
+		}
+		// No references is reported as a message rather than an empty array
+		if _, jsonErr := findJSONArray(output); jsonErr != nil {
+			return refsLoadedMsg{}
+		}
+		refs, err := parseReferences(output)
+		return refsLoadedMsg{refs: refs, err: err}
+	}
+}
+
+// termRefsMsg carries the references fetched for one search result
+type termRefsMsg struct {
+	term     string
+	location location
+	refs     []reference
+	err      error
+}
+
+// fetchTermReferences looks up the references to a result's term so the
+// detail pane can show them without leaving the results
+func fetchTermReferences(result searchResult) tea.Cmd {
+	fetch := fetchReferences(result.term)
+	return func() tea.Msg {
+		loaded := fetch().(refsLoadedMsg)
+		sortReferences(loaded.refs)
+		return termRefsMsg{term: result.term, location: result.location, refs: loaded.refs, err: loaded.err}
+	}
+}
+
+// applyTermRefs stores fetched references on the result they were requested
+// for, reporting failures and terms without references as a notice
+func (m *resultViewModel) applyTermRefs(msg termRefsMsg) {
+	switch {
+	case msg.err != nil:
+		m.notice = fmt.Sprintf("⚠️  %v", msg.err)
+		return
+	case len(msg.refs) == 0:
+		m.notice = fmt.Sprintf("No references to %s found", msg.term)
+		return
+	}
+	
+	m.notice = ""
+	for i := range m.results {
+		if m.results[i].term == msg.term && m.results[i].location == msg.location {
+			m.results[i].references = msg.refs
+		}
+	}
+}
+
+// refsModel lists the references to a symbol, grouped by reference type
+type refsModel struct {
+	symbol     string
+	refs       []reference
+	table      table.Model
+	viewport   viewport.Model
+	showDetail bool
+	loading    bool
+	err        error
+	width      int
+	height     int
+	help       help.Model
+}
+
+func newRefsModel(symbol string) refsModel {
+	tbl := table.New(
+		table.WithColumns(refColumns(100)),
+		table.WithFocused(true),
+		table.WithHeight(15),
+	)
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(style.Colors().Border).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = s.Selected.
+		Foreground(style.Colors().Selection).
+		Background(style.Colors().Selected).
+		Bold(false)
+	tbl.SetStyles(s)
+	
+	return refsModel{
+		symbol:   symbol,
+		table:    tbl,
+		viewport: viewport.New(80, 20),
+		loading:  true,
+		help:     help.New(),
+	}
+}
+
+// refColumns sizes the table columns for the terminal width
+func refColumns(width int) []table.Column {
+	typeWidth, locWidth := 18, 36
+	contextWidth := width - typeWidth - locWidth - 8
+	if contextWidth < 20 {
+		contextWidth = 20
+	}
+	return []table.Column{
+		{Title: "🔗 Type", Width: typeWidth},
+		{Title: "📍 Location", Width: locWidth},
+		{Title: "📄 Context", Width: contextWidth},
+	}
+}
+
+func (m refsModel) Init() tea.Cmd {
+	return fetchReferences(m.symbol)
+}
+
+func (m refsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.table.SetColumns(refColumns(msg.Width - 4))
+		m.table.SetWidth(msg.Width - 4)
+		m.table.SetHeight(msg.Height - 8)
+		m.viewport.Width = msg.Width - 4
+		m.viewport.Height = msg.Height - 6
+		m.help.Width = msg.Width
+		m.refreshRows()
+		return m, nil
+	
+	case refsLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		m.refs = msg.refs
+		sortReferences(m.refs)
+		m.refreshRows()
+		return m, nil
+	
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, resultKeys.Quit):
+			return m, tea.Quit
+		case key.Matches(msg, resultKeys.Back) && m.showDetail:
+			m.showDetail = false
+			return m, nil
+		case key.Matches(msg, resultKeys.Details) && !m.showDetail:
+			if m.table.Cursor() < len(m.refs) {
+				m.showDetail = true
+				m.updateDetailView()
+			}
+			return m, nil
+		}
+	}
+	
+	var cmd tea.Cmd
+	if m.showDetail {
+		m.viewport, cmd = m.viewport.Update(msg)
+	} else {
+		m.table, cmd = m.table.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m *refsModel) refreshRows() {
+	locWidth := refColumns(m.width - 4)[1].Width
+	rows := make([]table.Row, len(m.refs))
+	for i, ref := range m.refs {
+		rows[i] = table.Row{
+			getRefIcon(ref.typ) + " " + ref.typ,
+			style.TruncatePath(fmt.Sprintf("%s:%d", ref.from.file, ref.from.line), locWidth),
+			strings.TrimSpace(ref.context),
+		}
+	}
+	m.table.SetRows(rows)
+}
+
+// summary counts the references of each type, in display order
+func (m refsModel) summary() string {
+	counts := make(map[string]int)
+	var types []string
+	for _, ref := range m.refs {
+		if counts[ref.typ] == 0 {
+			types = append(types, ref.typ)
+		}
+		counts[ref.typ]++
+	}
+	
+	parts := make([]string, len(types))
+	for i, typ := range types {
+		parts[i] = getRefStyle(typ).Render(fmt.Sprintf("%s %d %s", getRefIcon(typ), counts[typ], typ))
+	}
+	return strings.Join(parts, " • ")
+}
+
+func (m *refsModel) updateDetailView() {
+	ref := m.refs[m.table.Cursor()]
+	var content strings.Builder
+	
+	content.WriteString(mainTitleStyle.Render(fmt.Sprintf("%s %s → %s", getRefIcon(ref.typ), ref.typ, m.symbol)))
+	content.WriteString("\n\n")
+	
+	content.WriteString(sectionStyle.Render("📍 Location"))
+	content.WriteString("\n")
+	content.WriteString(fmt.Sprintf("📂 File: %s\n", ref.from.file))
+	content.WriteString(fmt.Sprintf("📏 Line %d, Column %d\n", ref.from.line, ref.from.column))
+	
+	content.WriteString("\n")
+	content.WriteString(sectionStyle.Render("📄 Code Context"))
+	content.WriteString("\n")
+	
+	lines, first, err := readSurrounding(ref.from.file, ref.from.line, refContextRadius)
+	if err != nil || len(lines) == 0 {
+		// Fall back to the single line captured in the index
+		content.WriteString(getRefStyle(ref.typ).Render(fmt.Sprintf("%4d: %s", ref.from.line, strings.TrimSpace(ref.context))))
+		content.WriteString("\n")
+	} else {
+		for i, line := range lines {
+			lineNum := first + i
+			if lineNum == ref.from.line {
+				content.WriteString(signatureStyle.Render(fmt.Sprintf("%4d: %s", lineNum, line)))
+			} else {
+				content.WriteString(codeStyle.Render(fmt.Sprintf("%4d: %s", lineNum, line)))
+			}
+			content.WriteString("\n")
+		}
+	}
+	
+	m.viewport.SetContent(content.String())
+	m.viewport.GotoTop()
+}
+
+func (m refsModel) helpKeys() viewHelp {
+	k := resultKeys
+	if m.showDetail {
+		bindings := []key.Binding{k.Up, k.Down, k.Back, k.Quit}
+		return viewHelp{short: bindings, full: [][]key.Binding{bindings}}
+	}
+	bindings := []key.Binding{k.Up, k.Down, k.Details, k.Quit}
+	return viewHelp{short: bindings, full: [][]key.Binding{bindings}}
+}
+
+func (m refsModel) View() string {
+	if m.loading {
+		return fmt.Sprintf("🔍 Finding references to %q...", m.symbol)
+	}
+	if m.err != nil {
+		return fmt.Sprintf("Error: %v\n\nPress 'q' to quit.", m.err)
+	}
+	if len(m.refs) == 0 {
+		return fmt.Sprintf("❌ No references found for %q\n\nPress 'q' to quit.", m.symbol)
+	}
+	
+	var content strings.Builder
+	if m.showDetail {
+		content.WriteString(m.viewport.View())
+	} else {
+		content.WriteString(headerStyle.Render(fmt.Sprintf("🔗 %d references to %q", len(m.refs), m.symbol)))
+		content.WriteString("\n")
+		content.WriteString(m.summary())
+		content.WriteString("\n\n")
+		content.WriteString(m.table.View())
+	}
+	content.WriteString("\n")
+	content.WriteString(m.help.View(m.helpKeys()))
+	return content.String()
+}
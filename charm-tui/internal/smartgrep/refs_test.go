@@ -0,0 +1,76 @@
+package smartgrep
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseAndSortReferences(t *testing.T) {
+	output := "🔍 Analyzing references...\n" + `[
+  {"targetTerm": "Auth", "referenceType": "import", "fromLocation": {"file": "b.ts", "line": 1, "column": 1}, "context": "import { Auth }"},
+  {"targetTerm": "Auth", "referenceType": "call", "fromLocation": {"file": "b.ts", "line": 9, "column": 3}, "context": "new Auth()"},
+  {"targetTerm": "Auth", "referenceType": "call", "fromLocation": {"file": "a.ts", "line": 20, "column": 3}, "context": "Auth.login()"},
+  {"targetTerm": "Auth", "referenceType": "extends", "fromLocation": {"file": "c.ts", "line": 4, "column": 1}, "context": "class X extends Auth"}
+]`
+	refs, err := parseReferences([]byte(output))
+	if err != nil {
+		t.Fatalf("parseReferences: %v", err)
+	}
+	sortReferences(refs)
+	
+	var got []string
+	for _, r := range refs {
+		got = append(got, r.typ+" "+r.from.file)
+	}
+	want := []string{"call a.ts", "call b.ts", "import b.ts", "extends c.ts"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sorted references = %v, want %v", got, want)
+	}
+}
+
+func TestReadSurrounding(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "f.go")
+	content := "one\ntwo\nthree\nfour\nfive\nsix\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	
+	lines, first, err := readSurrounding(path, 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != 1 || !reflect.DeepEqual(lines, []string{"one", "two", "three", "four"}) {
+		t.Errorf("readSurrounding = %v from %d", lines, first)
+	}
+}
+
+func TestApplyTermRefs(t *testing.T) {
+	m := newResultViewModel()
+	loc := location{file: "auth.ts", line: 3, column: 1}
+	m.results = []searchResult{{term: "Auth", location: loc}, {term: "Auth", location: location{file: "other.ts"}}}
+	m.activeView = "detail"
+	
+	updated, _ := m.Update(termRefsMsg{term: "Auth", location: loc})
+	rv := updated.(resultViewModel)
+	if !strings.Contains(rv.notice, "No references to Auth") {
+		t.Errorf("notice = %q, want a no-references message", rv.notice)
+	}
+	
+	rv.applyTermRefs(termRefsMsg{term: "Auth", location: loc, err: errors.New("boom")})
+	if !strings.Contains(rv.notice, "boom") {
+		t.Errorf("notice = %q, want the error", rv.notice)
+	}
+	
+	refs := []reference{{typ: "call", from: location{file: "b.ts", line: 9}, context: "new Auth()"}}
+	rv.applyTermRefs(termRefsMsg{term: "Auth", location: loc, refs: refs})
+	if rv.notice != "" || len(rv.results[0].references) != 1 || len(rv.results[1].references) != 0 {
+		t.Errorf("references should land on the requested result only: %+v", rv.results)
+	}
+	if out := renderReferences(refs, detailRefLimit, true); !strings.Contains(out, "call (1)") || !strings.Contains(out, "b.ts:9") {
+		t.Errorf("renderReferences() = %q", out)
+	}
+}
@@ -0,0 +1,231 @@
+package smartgrep
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+	"golang.org/x/sync/singleflight"
+)
+
+// backendLimiter caps how many smartgrep backend processes run at once.
+// Live search, watch re-runs and related-term jumps can all fire requests
+// faster than bun can serve them, so excess callers queue here and
+// identical in-flight invocations are coalesced into a single process.
+type backendLimiter struct {
+	mu       sync.Mutex // Guards inFlight, debug and flights
+	sem      chan struct{}
+	inFlight int
+	debug    bool
+	group    singleflight.Group
+	flights  map[string]*flight
+}
+
+// flight is a coalesced backend run. It has its own context so that one
+// caller giving up doesn't fail the others; it is cancelled once every
+// caller waiting on it has gone.
+type flight struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	waiters int
+}
+
+var (
+	limiterMu sync.Mutex // Guards swapping limiter
+	limiter   = newBackendLimiter(config.GetMaxBackendProcs())
+)
+
+func newBackendLimiter(n int) *backendLimiter {
+	if n < 1 {
+		n = 1
+	}
+	return &backendLimiter{sem: make(chan struct{}, n), flights: make(map[string]*flight)}
+}
+
+// currentLimiter returns the limiter new backend runs go through
+func currentLimiter() *backendLimiter {
+	limiterMu.Lock()
+	defer limiterMu.Unlock()
+	return limiter
+}
+
+// SetMaxConcurrency sets the maximum number of concurrent backend processes.
+// Runs already started finish under the previous limit.
+func SetMaxConcurrency(n int) {
+	limiterMu.Lock()
+	defer limiterMu.Unlock()
+	
+	l := newBackendLimiter(n)
+	limiter.mu.Lock()
+	l.debug = limiter.debug
+	limiter.mu.Unlock()
+	limiter = l
+}
+
+// SetDebug enables logging of backend concurrency to stderr
+func SetDebug(enabled bool) {
+	l := currentLimiter()
+	l.mu.Lock()
+	l.debug = enabled
+	l.mu.Unlock()
+}
+
+// InFlight returns the number of backend processes currently running
+func InFlight() int {
+	l := currentLimiter()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inFlight
+}
+
+func (l *backendLimiter) acquire(ctx context.Context) error {
+	select {
+	case l.sem <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	
+	l.mu.Lock()
+	l.inFlight++
+	l.logf("backend started (in-flight: %d/%d)", l.inFlight, cap(l.sem))
+	l.mu.Unlock()
+	return nil
+}
+
+func (l *backendLimiter) release() {
+	l.mu.Lock()
+	l.inFlight--
+	l.logf("backend finished (in-flight: %d/%d)", l.inFlight, cap(l.sem))
+	l.mu.Unlock()
+	<-l.sem
+}
+
+// logf logs under --debug. l.mu must be held.
+func (l *backendLimiter) logf(format string, args ...interface{}) {
+	if l.debug {
+		config.Debugf(format, args...)
+	}
+}
+
+// Command builds the command that invokes the TypeScript smartgrep CLI
+func Command(args ...string) *exec.Cmd {
+	return smartgrepCommand(context.Background(), args...)
+}
+
+// CommandContext is like Command but kills the CLI when ctx is cancelled
+func CommandContext(ctx context.Context, args ...string) *exec.Cmd {
+	return smartgrepCommand(ctx, args...)
+}
+
+// smartgrepCommand builds the command that invokes the TypeScript smartgrep CLI
+func smartgrepCommand(ctx context.Context, args ...string) *exec.Cmd {
+	line := config.CLICommandLine(config.GetSmartgrepPath(), args...)
+	cmd := exec.CommandContext(ctx, line[0], line[1:]...)
+	cmd.Dir = projectDir
+	config.LogCommand(cmd)
+	return cmd
+}
+
+// runSmartgrep runs the smartgrep CLI through the shared limiter and returns
+// its output. When combined is true stderr is included in the output.
+func runSmartgrep(ctx context.Context, combined bool, args ...string) ([]byte, error) {
+	l := currentLimiter()
+	key := fmt.Sprintf("%t\x00%s", combined, strings.Join(args, "\x00"))
+	
+	f := l.join(key)
+	ch := l.group.DoChan(key, func() (interface{}, error) {
+		if err := l.acquire(f.ctx); err != nil {
+			return nil, err
+		}
+		defer l.release()
+		
+		cmd := smartgrepCommand(f.ctx, args...)
+		if combined {
+			return cmd.CombinedOutput()
+		}
+		return cmd.Output()
+	})
+	
+	select {
+	case res := <-ch:
+		l.leave(key, f)
+		if res.Shared {
+			l.mu.Lock()
+			l.logf("coalesced duplicate backend request: %s", strings.Join(args, " "))
+			l.mu.Unlock()
+		}
+		out, _ := res.Val.([]byte)
+		return out, res.Err
+	case <-ctx.Done():
+		if l.leave(key, f) {
+			// The run was cancelled with this caller; wait for it to kill
+			// the CLI so nothing it started outlives the call
+			<-ch
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// join registers a caller waiting on the backend run for key
+func (l *backendLimiter) join(key string) *flight {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	
+	f := l.flights[key]
+	if f == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		f = &flight{ctx: ctx, cancel: cancel}
+		l.flights[key] = f
+	}
+	f.waiters++
+	return f
+}
+
+// leave unregisters a caller. The last one out cancels the run and makes
+// later callers start a fresh one rather than join a cancelled run; leave
+// reports whether it did.
+func (l *backendLimiter) leave(key string, f *flight) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	
+	f.waiters--
+	if f.waiters > 0 {
+		return false
+	}
+	f.cancel()
+	if l.flights[key] == f {
+		delete(l.flights, key)
+		l.group.Forget(key)
+	}
+	return true
+}
+
+// startSmartgrep starts the smartgrep CLI through the shared limiter and
+// returns its stdout for streaming. wait must be called once stdout is consumed.
+func startSmartgrep(ctx context.Context, args ...string) (io.ReadCloser, func() error, error) {
+	l := currentLimiter()
+	if err := l.acquire(ctx); err != nil {
+		return nil, nil, err
+	}
+	
+	cmd := smartgrepCommand(ctx, args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		l.release()
+		return nil, nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		l.release()
+		return nil, nil, err
+	}
+	
+	wait := func() error {
+		defer l.release()
+		return cmd.Wait()
+	}
+	return stdout, wait, nil
+}
@@ -0,0 +1,150 @@
+package smartgrep
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// limitTo caps the backend at n processes for the rest of the test
+func limitTo(t *testing.T, n int) {
+	prev := cap(currentLimiter().sem)
+	SetMaxConcurrency(n)
+	t.Cleanup(func() { SetMaxConcurrency(prev) })
+}
+
+// runs counts the backend processes a fakeCLI script started
+func runs(t *testing.T, argsFile string) int {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(argsFile), "runs"))
+	if err != nil {
+		return 0
+	}
+	return strings.Count(string(data), "\n")
+}
+
+const slowCLI = `echo run >> "$(dirname "$0")/runs"
+sleep 0.3
+echo "$@"
+`
+
+func TestRunSmartgrepCapsConcurrency(t *testing.T) {
+	argsFile := fakeCLI(t, slowCLI)
+	limitTo(t, 2)
+	
+	var wg sync.WaitGroup
+	for _, q := range []string{"a", "b", "c", "d", "e"} {
+		wg.Add(1)
+		go func(q string) {
+			defer wg.Done()
+			if _, err := runSmartgrep(context.Background(), false, q); err != nil {
+				t.Error(err)
+			}
+		}(q)
+	}
+	
+	done := make(chan struct{})
+	go func() { wg.Wait(); close(done) }()
+	peak := 0
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+		case <-time.After(5 * time.Millisecond):
+			if n := InFlight(); n > peak {
+				peak = n
+			}
+		}
+	}
+	
+	if peak != 2 {
+		t.Errorf("peak in-flight = %d, want 2", peak)
+	}
+	if n := runs(t, argsFile); n != 5 {
+		t.Errorf("backend ran %d times, want 5", n)
+	}
+}
+
+func TestRunSmartgrepCoalescesDuplicates(t *testing.T) {
+	argsFile := fakeCLI(t, slowCLI)
+	limitTo(t, 4)
+	
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			out, err := runSmartgrep(context.Background(), false, "login", "--json")
+			if err != nil || strings.TrimSpace(string(out)) != "login --json" {
+				t.Errorf("runSmartgrep() = %q, %v", out, err)
+			}
+		}()
+	}
+	wg.Wait()
+	
+	if n := runs(t, argsFile); n != 1 {
+		t.Errorf("backend ran %d times, want 1", n)
+	}
+}
+
+func TestRunSmartgrepCallerCancelDoesNotFailOthers(t *testing.T) {
+	argsFile := fakeCLI(t, slowCLI)
+	limitTo(t, 4)
+	
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := runSmartgrep(ctx, false, "login")
+		first <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	
+	second := make(chan error, 1)
+	go func() {
+		out, err := runSmartgrep(context.Background(), false, "login")
+		if err == nil && strings.TrimSpace(string(out)) != "login" {
+			t.Errorf("output = %q", out)
+		}
+		second <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled caller got %v, want context.Canceled", err)
+	}
+	if err := <-second; err != nil {
+		t.Errorf("other caller failed: %v", err)
+	}
+	if n := runs(t, argsFile); n != 1 {
+		t.Errorf("backend ran %d times, want 1", n)
+	}
+}
+
+func TestRunSmartgrepLastCallerCancelsRun(t *testing.T) {
+	argsFile := fakeCLI(t, slowCLI)
+	limitTo(t, 4)
+	
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+	if _, err := runSmartgrep(ctx, false, "login"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("runSmartgrep() error = %v, want context.Canceled", err)
+	}
+	
+	// A new caller starts a fresh run instead of joining the cancelled one
+	out, err := runSmartgrep(context.Background(), false, "login")
+	if err != nil || strings.TrimSpace(string(out)) != "login" {
+		t.Errorf("runSmartgrep() = %q, %v", out, err)
+	}
+	if n := runs(t, argsFile); n != 2 {
+		t.Errorf("backend ran %d times, want 2", n)
+	}
+}
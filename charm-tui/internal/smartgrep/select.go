@@ -0,0 +1,92 @@
+package smartgrep
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// resultSelection is a result to open in the detail view once the search
+// has loaded, given with --select as a 1-based position in the list or as
+// file:line
+type resultSelection struct {
+	index int
+	file  string
+	line  int
+}
+
+// parseSelection reads a --select value
+func parseSelection(value string) (*resultSelection, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if n, err := strconv.Atoi(value); err == nil {
+		if n < 1 {
+			return nil, fmt.Errorf("--select %s: results are numbered from 1", value)
+		}
+		return &resultSelection{index: n}, nil
+	}
+	if i := strings.LastIndex(value, ":"); i > 0 {
+		if line, err := strconv.Atoi(value[i+1:]); err == nil && line > 0 {
+			return &resultSelection{file: value[:i], line: line}, nil
+		}
+	}
+	return nil, fmt.Errorf("--select %q: want a result number or file:line", value)
+}
+
+func (s resultSelection) String() string {
+	if s.file != "" {
+		return fmt.Sprintf("%s:%d", s.file, s.line)
+	}
+	return strconv.Itoa(s.index)
+}
+
+// find returns the index of the selected result, or -1
+func (s resultSelection) find(results []searchResult) int {
+	if s.file == "" {
+		if s.index <= len(results) {
+			return s.index - 1
+		}
+		return -1
+	}
+	
+	file := filepath.Clean(resolveResultPath(s.file))
+	for i, r := range results {
+		if r.location.line == s.line && filepath.Clean(resolveResultPath(r.location.file)) == file {
+			return i
+		}
+	}
+	return -1
+}
+
+// applySelection opens the --select result in the detail view once the
+// results have loaded. A selection that matches nothing leaves the list
+// open with a notice.
+func (m *resultViewModel) applySelection() {
+	sel := m.selection
+	m.selection = nil
+	if sel == nil || m.loadErr != nil || len(m.results) == 0 {
+		return
+	}
+	
+	i := sel.find(m.results)
+	pos := -1
+	for p, v := range m.visible {
+		if v == i {
+			pos = p
+			break
+		}
+	}
+	if pos < 0 {
+		m.notice = fmt.Sprintf("⚠️  No result %s to select among %s", sel, plural(len(m.visible), "result"))
+		return
+	}
+	
+	m.pageStart = clampPageStart(pos-pageSize/2, len(m.visible))
+	m.refreshRows()
+	m.table.SetCursor(pos - m.pageStart)
+	m.syncSelected()
+	m.activeView = "detail"
+	m.updateDetailView()
+}
@@ -0,0 +1,87 @@
+package smartgrep
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseSelection(t *testing.T) {
+	tests := []struct {
+		value string
+		want  *resultSelection
+	}{
+		{"", nil},
+		{"3", &resultSelection{index: 3}},
+		{"src/auth.ts:42", &resultSelection{file: "src/auth.ts", line: 42}},
+		{`C:\src\auth.ts:7`, &resultSelection{file: `C:\src\auth.ts`, line: 7}},
+	}
+	for _, tt := range tests {
+		got, err := parseSelection(tt.value)
+		if err != nil {
+			t.Errorf("parseSelection(%q): %v", tt.value, err)
+			continue
+		}
+		if (got == nil) != (tt.want == nil) || got != nil && *got != *tt.want {
+			t.Errorf("parseSelection(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+	
+	for _, bad := range []string{"0", "-2", "auth.ts", "auth.ts:", "auth.ts:0", ":12"} {
+		if _, err := parseSelection(bad); err == nil {
+			t.Errorf("parseSelection(%q) should fail", bad)
+		}
+	}
+}
+
+func TestApplySelection(t *testing.T) {
+	root := t.TempDir()
+	SetProjectDir(root)
+	t.Cleanup(func() { SetProjectDir("") })
+	
+	results := []searchResult{
+		{term: "login", location: location{file: "src/auth.ts", line: 10}, relevance: 0.9},
+		{term: "logout", location: location{file: "src/auth.ts", line: 30}, relevance: 0.8},
+		{term: "session", location: location{file: filepath.Join(root, "src/session.ts"), line: 5}, relevance: 0.7},
+	}
+	load := func(selection string) resultViewModel {
+		t.Helper()
+		sel, err := parseSelection(selection)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := newResultViewModel()
+		m.viewport.Height = 100
+		m.loading = true
+		m.selection = sel
+		updated, _ := m.Update(resultsBatchMsg{results: results})
+		updated, _ = updated.Update(resultsDoneMsg{})
+		return updated.(resultViewModel)
+	}
+	
+	tests := []struct {
+		selection string
+		want      string
+	}{
+		{"2", "logout"},
+		{"src/auth.ts:10", "login"},
+		{"src/session.ts:5", "session"},
+		{filepath.Join(root, "src/auth.ts") + ":30", "logout"},
+	}
+	for _, tt := range tests {
+		m := load(tt.selection)
+		if m.activeView != "detail" || m.results[m.selected].term != tt.want {
+			t.Errorf("--select %s: view %s on %q, want the detail of %q", tt.selection, m.activeView, m.results[m.selected].term, tt.want)
+		}
+		if !strings.Contains(m.viewport.View(), tt.want) {
+			t.Errorf("--select %s: detail view does not show %q", tt.selection, tt.want)
+		}
+	}
+	
+	for _, missing := range []string{"4", "src/auth.ts:11"} {
+		m := load(missing)
+		if m.activeView != "list" || !strings.Contains(m.notice, "No result "+missing) {
+			t.Errorf("--select %s: view %s, notice %q", missing, m.activeView, m.notice)
+		}
+	}
+}
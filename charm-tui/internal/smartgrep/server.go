@@ -0,0 +1,322 @@
+package smartgrep
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ServerOptions configures the HTTP/JSON server
+type ServerOptions struct {
+	Addr       string // TCP address, e.g. "127.0.0.1:7777"
+	UnixSocket string // Optional unix socket path (takes precedence over Addr)
+}
+
+// removeStaleSocket removes a socket left behind by a previous run. Anything
+// else at path is left alone.
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%s exists and is not a socket", path)
+	}
+	return os.Remove(path)
+}
+
+// rpcRequest is a JSON-RPC 2.0 request. Params may be strings, numbers or
+// booleans; handlers validate them like query parameters.
+type rpcRequest struct {
+	JSONRPC string                 `json:"jsonrpc"`
+	ID      json.RawMessage        `json:"id"`
+	Method  string                 `json:"method"`
+	Params  map[string]interface{} `json:"params"`
+}
+
+// rpcError is a JSON-RPC 2.0 error object
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// paramError is a missing or invalid request parameter, as opposed to a
+// failure of the CLI
+type paramError string
+
+func (e paramError) Error() string { return string(e) }
+
+// Serve exposes search, refs and changes over HTTP/JSON until interrupted
+func Serve(opts ServerOptions) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/search", handleREST(serveSearch))
+	mux.HandleFunc("/refs", handleREST(serveRefs))
+	mux.HandleFunc("/changes", handleREST(serveChanges))
+	mux.HandleFunc("/rpc", handleRPC)
+	
+	var listener net.Listener
+	var err error
+	if opts.UnixSocket != "" {
+		if err := removeStaleSocket(opts.UnixSocket); err != nil {
+			return err
+		}
+		listener, err = net.Listen("unix", opts.UnixSocket)
+		if err == nil {
+			defer os.Remove(opts.UnixSocket)
+		}
+	} else {
+		listener, err = net.Listen("tcp", opts.Addr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	
+	srv := &http.Server{Handler: mux}
+	
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.Serve(listener)
+	}()
+	
+	fmt.Fprintf(os.Stderr, "smartgrep server listening on %s\n", listener.Addr())
+	
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+	}
+	
+	// Give in-flight requests a moment to finish
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
+}
+
+// serverHandler answers a single request given its parameters
+type serverHandler func(ctx context.Context, params map[string]string) (interface{}, error)
+
+func handleREST(h serverHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := make(map[string]string)
+		for key := range r.URL.Query() {
+			params[key] = r.URL.Query().Get(key)
+		}
+		
+		result, err := h(r.Context(), params)
+		w.Header().Set("Content-Type", "application/json")
+		if err != nil {
+			status := http.StatusInternalServerError
+			var pe paramError
+			if errors.As(err, &pe) {
+				status = http.StatusBadRequest
+			}
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+		json.NewEncoder(w).Encode(result)
+	}
+}
+
+func handleRPC(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	
+	var req rpcRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      nil,
+			"error":   rpcError{Code: -32700, Message: "parse error"},
+		})
+		return
+	}
+	
+	handlers := map[string]serverHandler{
+		"search":  serveSearch,
+		"refs":    serveRefs,
+		"changes": serveChanges,
+	}
+	
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+	h, ok := handlers[req.Method]
+	params, paramsErr := rpcParams(req.Params)
+	var pe paramError
+	if !ok {
+		resp["error"] = rpcError{Code: -32601, Message: "method not found: " + req.Method}
+	} else if paramsErr != nil {
+		resp["error"] = rpcError{Code: -32602, Message: paramsErr.Error()}
+	} else if result, err := h(r.Context(), params); errors.As(err, &pe) {
+		resp["error"] = rpcError{Code: -32602, Message: err.Error()}
+	} else if err != nil {
+		resp["error"] = rpcError{Code: -32000, Message: err.Error()}
+	} else {
+		resp["result"] = result
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+// rpcParams converts JSON-RPC params to the string form of query parameters
+func rpcParams(raw map[string]interface{}) (map[string]string, error) {
+	params := make(map[string]string, len(raw))
+	for name, value := range raw {
+		switch v := value.(type) {
+		case string:
+			params[name] = v
+		case float64:
+			params[name] = strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			params[name] = strconv.FormatBool(v)
+		default:
+			return nil, paramError(fmt.Sprintf("invalid %s: want a string, number or boolean", name))
+		}
+	}
+	return params, nil
+}
+
+// intParam reads an optional non-negative integer parameter, 0 if unset
+func intParam(params map[string]string, name string) (int, error) {
+	value := params[name]
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, paramError(fmt.Sprintf("invalid %s: %q is not a non-negative integer", name, value))
+	}
+	return n, nil
+}
+
+// boolParam reads an optional boolean parameter, false if unset
+func boolParam(params map[string]string, name string) (bool, error) {
+	value := params[name]
+	if value == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, paramError(fmt.Sprintf("invalid %s: %q is not true or false", name, value))
+	}
+	return b, nil
+}
+
+// argParam reads a parameter passed on to the CLI as an argument, which must
+// not look like a flag
+func argParam(params map[string]string, name string) (string, error) {
+	value := params[name]
+	if strings.HasPrefix(value, "-") {
+		return "", paramError(fmt.Sprintf("invalid %s: %q", name, value))
+	}
+	return value, nil
+}
+
+func serveSearch(ctx context.Context, params map[string]string) (interface{}, error) {
+	query, err := argParam(params, "q")
+	if err != nil {
+		return nil, err
+	}
+	if query == "" {
+		return nil, paramError("search pattern required")
+	}
+	
+	args := []string{query}
+	for _, flag := range []string{"type", "sort", "file"} {
+		value, err := argParam(params, flag)
+		if err != nil {
+			return nil, err
+		}
+		if value != "" {
+			args = append(args, "--"+flag, value)
+		}
+	}
+	max, err := intParam(params, "max")
+	if err != nil {
+		return nil, err
+	}
+	if max > 0 {
+		args = append(args, "--max", strconv.Itoa(max))
+	}
+	
+	results, err := fetchSearchResults(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	// Answer in the CLI's --json schema rather than the TUI's flat export
+	converted := make([]TSResult, 0, len(results))
+	for _, r := range results {
+		converted = append(converted, r.toTSResult())
+	}
+	return converted, nil
+}
+
+func serveRefs(ctx context.Context, params map[string]string) (interface{}, error) {
+	symbol, err := argParam(params, "symbol")
+	if err != nil {
+		return nil, err
+	}
+	if symbol == "" {
+		return nil, paramError("symbol name required")
+	}
+	
+	output, err := runSmartgrep(ctx, false, "refs", symbol, "--json")
+	if err != nil {
+		return nil, fmt.Errorf("command failed: %w", err)
+	}
+	refs, err := parseReferences(output)
+	if err != nil {
+		return nil, err
+	}
+	usages := make([]TSUsage, 0, len(refs))
+	for _, ref := range refs {
+		usages = append(usages, ref.toTSUsage())
+	}
+	return usages, nil
+}
+
+func serveChanges(ctx context.Context, params map[string]string) (interface{}, error) {
+	staged, err := boolParam(params, "staged")
+	if err != nil {
+		return nil, err
+	}
+	since, err := argParam(params, "since")
+	if err != nil {
+		return nil, err
+	}
+	if staged && since != "" {
+		return nil, paramError("staged and since cannot be used together")
+	}
+	
+	opts := ChangesOptions{Staged: staged, Since: since}
+	output, err := runSmartgrep(ctx, false, opts.changesArgs()...)
+	if err != nil {
+		return nil, fmt.Errorf("command failed: %w", err)
+	}
+	report, err := parseChanges(output)
+	if err != nil {
+		return nil, err
+	}
+	if report.Files == nil {
+		report.Files = []fileChange{}
+	}
+	if report.HighImpactSymbols == nil {
+		report.HighImpactSymbols = []impactSymbol{}
+	}
+	return report, nil
+}
@@ -0,0 +1,193 @@
+package smartgrep
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// serverCLI answers search, refs and changes like the TypeScript CLI's --json
+const serverCLI = `case "$1" in
+refs)
+	echo '[{"targetTerm":"login","referenceType":"call","fromLocation":{"file":"app.ts","line":7,"column":3},"context":"login()"}]'
+	;;
+changes)
+	echo '📊 analyzing'
+	echo '{"branch":"main","files":[{"status":"M","path":"src/auth.ts"}],"totalImpact":4,"affectedSymbols":1,"highImpactSymbols":[]}'
+	;;
+*)
+	echo '[{"info":{"term":"login","type":"function","location":{"file":"a.ts","line":1}},"relevanceScore":0.9}]'
+	;;
+esac
+`
+
+// get requests path from h and decodes the JSON answer into v
+func get(t *testing.T, h http.HandlerFunc, path string, v interface{}) int {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+		t.Fatalf("GET %s: answer is not JSON: %v\n%s", path, err, rec.Body.String())
+	}
+	return rec.Code
+}
+
+func readArgs(t *testing.T, argsFile string) string {
+	t.Helper()
+	data, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Join(strings.Fields(string(data)), " ")
+}
+
+func TestServeSearch(t *testing.T) {
+	argsFile := fakeCLI(t, serverCLI)
+	
+	var results []map[string]interface{}
+	if code := get(t, handleREST(serveSearch), "/search?q=login&type=function&max=5", &results); code != http.StatusOK {
+		t.Fatalf("status = %d", code)
+	}
+	if len(results) != 1 || results[0]["relevanceScore"] != 0.9 {
+		t.Fatalf("results = %v", results)
+	}
+	if info, _ := results[0]["info"].(map[string]interface{}); info == nil || info["term"] != "login" {
+		t.Errorf("results should use the CLI's --json schema: %v", results)
+	}
+	if got, want := readArgs(t, argsFile), "login --type function --max 5 --json"; got != want {
+		t.Errorf("CLI args = %q, want %q", got, want)
+	}
+	
+	for _, bad := range []string{"/search", "/search?q=x&max=ten", "/search?q=x&max=-1", "/search?q=--index"} {
+		var answer map[string]string
+		if code := get(t, handleREST(serveSearch), bad, &answer); code != http.StatusBadRequest || answer["error"] == "" {
+			t.Errorf("GET %s = %d %v, want a 400 with an error", bad, code, answer)
+		}
+	}
+}
+
+func TestServeRefs(t *testing.T) {
+	argsFile := fakeCLI(t, serverCLI)
+	
+	var usages []TSUsage
+	if code := get(t, handleREST(serveRefs), "/refs?symbol=login", &usages); code != http.StatusOK {
+		t.Fatalf("status = %d", code)
+	}
+	want := TSUsage{TargetTerm: "login", ReferenceType: "call", FromLocation: TSLocation{"app.ts", 7, 3}, Context: "login()"}
+	if len(usages) != 1 || usages[0] != want {
+		t.Errorf("refs = %+v, want [%+v]", usages, want)
+	}
+	if got := readArgs(t, argsFile); got != "refs login --json" {
+		t.Errorf("CLI args = %q", got)
+	}
+	
+	for _, bad := range []string{"/refs", "/refs?symbol=-h"} {
+		var answer map[string]string
+		if code := get(t, handleREST(serveRefs), bad, &answer); code != http.StatusBadRequest {
+			t.Errorf("GET %s = %d, want 400", bad, code)
+		}
+	}
+}
+
+func TestServeChanges(t *testing.T) {
+	argsFile := fakeCLI(t, serverCLI)
+	
+	var report changesReport
+	if code := get(t, handleREST(serveChanges), "/changes?staged=true", &report); code != http.StatusOK {
+		t.Fatalf("status = %d", code)
+	}
+	if report.Branch != "main" || len(report.Files) != 1 || report.TotalImpact != 4 || report.HighImpactSymbols == nil {
+		t.Errorf("report = %+v", report)
+	}
+	if got := readArgs(t, argsFile); got != "changes --json --staged" {
+		t.Errorf("CLI args = %q", got)
+	}
+	
+	get(t, handleREST(serveChanges), "/changes?since=v1.2", &report)
+	if got := readArgs(t, argsFile); got != "changes --json --since v1.2" {
+		t.Errorf("CLI args = %q", got)
+	}
+	
+	for _, bad := range []string{"/changes?staged=maybe", "/changes?since=--output=x", "/changes?staged=1&since=main"} {
+		var answer map[string]string
+		if code := get(t, handleREST(serveChanges), bad, &answer); code != http.StatusBadRequest {
+			t.Errorf("GET %s = %d, want 400", bad, code)
+		}
+	}
+}
+
+func TestServeRPC(t *testing.T) {
+	argsFile := fakeCLI(t, serverCLI)
+	rpc := func(body string) map[string]interface{} {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		handleRPC(rec, httptest.NewRequest(http.MethodPost, "/rpc", strings.NewReader(body)))
+		var resp map[string]interface{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("answer is not JSON: %v\n%s", err, rec.Body.String())
+		}
+		return resp
+	}
+	
+	resp := rpc(`{"jsonrpc":"2.0","id":1,"method":"search","params":{"q":"login","max":3}}`)
+	if resp["error"] != nil || resp["result"] == nil {
+		t.Errorf("search = %v", resp)
+	}
+	if got := readArgs(t, argsFile); got != "login --max 3 --json" {
+		t.Errorf("CLI args = %q", got)
+	}
+	
+	resp = rpc(`{"jsonrpc":"2.0","id":2,"method":"changes","params":{"staged":true}}`)
+	if result, ok := resp["result"].(map[string]interface{}); !ok || result["branch"] != "main" {
+		t.Errorf("changes = %v", resp)
+	}
+	
+	for _, body := range []string{
+		`{"jsonrpc":"2.0","id":3,"method":"search","params":{"q":"x","max":1.5}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"changes","params":{"staged":"maybe"}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"refs","params":{"symbol":["a"]}}`,
+	} {
+		resp := rpc(body)
+		if e, ok := resp["error"].(map[string]interface{}); !ok || e["code"] != float64(-32602) {
+			t.Errorf("%s = %v, want invalid params", body, resp)
+		}
+	}
+}
+
+func TestRemoveStaleSocket(t *testing.T) {
+	dir := t.TempDir()
+	
+	if err := removeStaleSocket(filepath.Join(dir, "missing.sock")); err != nil {
+		t.Errorf("missing path: %v", err)
+	}
+	
+	file := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(file, []byte("keep me"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := removeStaleSocket(file); err == nil {
+		t.Error("expected an error for a regular file")
+	}
+	if _, err := os.Stat(file); err != nil {
+		t.Errorf("regular file was removed: %v", err)
+	}
+	
+	sock := filepath.Join(dir, "stale.sock")
+	l, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+	l.(*net.UnixListener).SetUnlinkOnClose(false)
+	l.Close()
+	if err := removeStaleSocket(sock); err != nil {
+		t.Fatalf("stale socket: %v", err)
+	}
+	if _, err := os.Lstat(sock); !os.IsNotExist(err) {
+		t.Errorf("stale socket was not removed: %v", err)
+	}
+}
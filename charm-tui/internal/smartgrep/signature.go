@@ -0,0 +1,196 @@
+package smartgrep
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Declarations whose signature can be extracted. Each pattern matches up to
+// the opening parenthesis of the parameter list (or the end of a class
+// header), with the part of the declaration kept in the signature captured.
+var (
+	goFuncPattern    = regexp.MustCompile(`^func\s+(\([^)]*\)\s*)?\w+(\[[^\]]*\])?\s*\(`)
+	goTypePattern    = regexp.MustCompile(`^type\s+\w+(\[[^\]]*\])?\s+(struct|interface)\b`)
+	pyFuncPattern    = regexp.MustCompile(`^((?:async\s+)?def\s+\w+)\s*\(`)
+	pyClassPattern   = regexp.MustCompile(`^class\s+\w+\s*(\([^)]*\))?\s*:`)
+	tsFuncPattern    = regexp.MustCompile(`^(?:export\s+)?(?:default\s+)?((?:async\s+)?function\s*\*?\s*\w*\s*(<[^(]*>)?)\s*\(`)
+	tsArrowPattern   = regexp.MustCompile(`^(?:export\s+)?(?:const|let|var)\s+(\w+)\s*(?::[^=]+)?=\s*(async\s+)?(<[^(]*>)?\s*\(`)
+	tsMethodPattern  = regexp.MustCompile(`^((?:(?:public|private|protected|static|abstract|readonly|override|async)\s+)*\*?\s*\w+\s*(<[^(]*>)?)\s*\(`)
+	tsClassPattern   = regexp.MustCompile(`^(?:export\s+)?(?:default\s+)?((?:abstract\s+)?class\s+\w+[^{]*)`)
+	nonMethodKeyword = regexp.MustCompile(`^(if|for|while|switch|catch|return|await|new|throw|typeof|do|else|super|this)\b`)
+)
+
+// maxSignatureLines bounds how many lines a signature split over several
+// lines is gathered from
+const maxSignatureLines = 8
+
+// extractSignature returns the declaration of a function or class result:
+// its name, parameters and return type where they can be found, without the
+// body. Go, TypeScript/JavaScript and Python are recognized; anything else
+// gives an empty string.
+func extractSignature(result searchResult) string {
+	if result.typ != "function" && result.typ != "class" {
+		return ""
+	}
+
+	line := strings.TrimSpace(result.context)
+	if sig, ok := parseSignature(line); ok {
+		return sig
+	}
+	// Parameters may continue on the following lines
+	if joined := joinDeclaration(result.surrounding, line); joined != "" && joined != line {
+		if sig, ok := parseSignature(joined); ok {
+			return sig
+		}
+	}
+	return ""
+}
+
+// joinDeclaration joins the line of surrounding holding the declaration
+// (the middle one unless found elsewhere) with the lines after it
+func joinDeclaration(surrounding []string, line string) string {
+	if len(surrounding) == 0 {
+		return ""
+	}
+	start := len(surrounding) / 2
+	for i, l := range surrounding {
+		if strings.TrimSpace(l) == line {
+			start = i
+			break
+		}
+	}
+	end := min(start+maxSignatureLines, len(surrounding))
+	joined := strings.Join(strings.Fields(strings.Join(surrounding[start:end], " ")), " ")
+	return strings.NewReplacer("( ", "(", " )", ")").Replace(joined)
+}
+
+// parseSignature extracts the signature declared on line, reporting whether
+// line held a complete declaration it recognized
+func parseSignature(line string) (string, bool) {
+	switch {
+	case goFuncPattern.MatchString(line):
+		return withParams(line, len(goFuncPattern.FindString(line))-1, "", goReturn)
+	case goTypePattern.MatchString(line):
+		return goTypePattern.FindString(line), true
+
+	case pyFuncPattern.MatchString(line):
+		m := pyFuncPattern.FindStringSubmatchIndex(line)
+		return withParams(line, m[1]-1, line[m[2]:m[3]], pyReturn)
+	case pyClassPattern.MatchString(line):
+		return strings.TrimSuffix(strings.TrimSpace(pyClassPattern.FindString(line)), ":"), true
+
+	case tsFuncPattern.MatchString(line):
+		m := tsFuncPattern.FindStringSubmatchIndex(line)
+		return withParams(line, m[1]-1, strings.TrimSpace(line[m[2]:m[3]]), tsReturn)
+	case tsArrowPattern.MatchString(line):
+		m := tsArrowPattern.FindStringSubmatchIndex(line)
+		prefix := line[m[2]:m[3]]
+		if m[4] >= 0 {
+			prefix = "async " + prefix
+		}
+		return withParams(line, m[1]-1, prefix, tsReturn)
+	case tsClassPattern.MatchString(line):
+		header := tsClassPattern.FindStringSubmatch(line)[1]
+		return strings.TrimSpace(header), true
+	case tsMethodPattern.MatchString(line) && !nonMethodKeyword.MatchString(line):
+		m := tsMethodPattern.FindStringSubmatchIndex(line)
+		return withParams(line, m[1]-1, strings.TrimSpace(line[m[2]:m[3]]), tsMethodReturn)
+	}
+	return "", false
+}
+
+// withParams completes a signature whose parameter list opens at line[open].
+// prefix replaces everything before the parenthesis when set, and returns
+// extracts the return type from what follows the parameters.
+func withParams(line string, open int, prefix string, returns func(rest string) (string, bool)) (string, bool) {
+	closing := matchingParen(line, open)
+	if closing < 0 {
+		return "", false
+	}
+	if prefix == "" {
+		prefix = line[:open]
+	}
+	sig := strings.TrimSpace(prefix) + line[open:closing+1]
+
+	ret, ok := returns(strings.TrimSpace(line[closing+1:]))
+	if !ok {
+		return "", false
+	}
+	return sig + ret, true
+}
+
+// matchingParen returns the index of the parenthesis closing the one at
+// open, or -1 when the line ends first
+func matchingParen(line string, open int) int {
+	depth := 0
+	for i := open; i < len(line); i++ {
+		switch line[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// trimBody drops a trailing opening brace, or a body written on the same line
+func trimBody(rest string) string {
+	if strings.HasSuffix(rest, "{") {
+		return strings.TrimSpace(strings.TrimSuffix(rest, "{"))
+	}
+	if i := strings.Index(rest, " {"); i >= 0 {
+		return strings.TrimSpace(rest[:i])
+	}
+	if strings.HasPrefix(rest, "{") {
+		return ""
+	}
+	return rest
+}
+
+// goReturn keeps a Go result list, e.g. "error" or "(int, error)"
+func goReturn(rest string) (string, bool) {
+	if ret := trimBody(rest); ret != "" {
+		return " " + ret, true
+	}
+	return "", true
+}
+
+// pyReturn keeps a "-> type" annotation and requires the closing colon
+func pyReturn(rest string) (string, bool) {
+	if !strings.HasPrefix(rest, "->") {
+		return "", strings.HasPrefix(rest, ":")
+	}
+	colon := strings.LastIndex(rest, ":")
+	if colon < 0 {
+		return "", false
+	}
+	return " -> " + strings.TrimSpace(rest[2:colon]), true
+}
+
+// tsReturn keeps a ": type" annotation, dropping the body or arrow
+func tsReturn(rest string) (string, bool) {
+	if i := strings.Index(rest, "=>"); i >= 0 {
+		rest = strings.TrimSpace(rest[:i])
+	}
+	rest = trimBody(rest)
+	if rest == "" {
+		return "", true
+	}
+	if !strings.HasPrefix(rest, ":") {
+		return "", false
+	}
+	return ": " + strings.TrimSpace(rest[1:]), true
+}
+
+// tsMethodReturn is tsReturn for class methods, which must be followed by a
+// body or type to tell them apart from calls
+func tsMethodReturn(rest string) (string, bool) {
+	if !strings.HasPrefix(rest, ":") && !strings.HasPrefix(rest, "{") {
+		return "", false
+	}
+	return tsReturn(rest)
+}
@@ -0,0 +1,68 @@
+package smartgrep
+
+import "testing"
+
+func TestExtractSignature(t *testing.T) {
+	tests := []struct {
+		name    string
+		typ     string
+		context string
+		want    string
+	}{
+		// Go
+		{"go function", "function", "func Login(user string) error {", "func Login(user string) error"},
+		{"go multiple results", "function", "func parse(b []byte) (int, error) {", "func parse(b []byte) (int, error)"},
+		{"go method", "function", "func (s *Server) Start(ctx context.Context) {", "func (s *Server) Start(ctx context.Context)"},
+		{"go generic", "function", "func Map[T any](xs []T, f func(T) T) []T {", "func Map[T any](xs []T, f func(T) T) []T"},
+		{"go interface result", "function", "func load() interface{} {", "func load() interface{}"},
+		{"go one-line body", "function", "func add(a, b int) int { return a + b }", "func add(a, b int) int"},
+		{"go struct", "class", "type Server struct {", "type Server struct"},
+		
+		// TypeScript and JavaScript
+		{"ts function", "function", "export async function login(user: User): Promise<boolean> {", "async function login(user: User): Promise<boolean>"},
+		{"ts generic", "function", "function first<T>(xs: T[]): T | undefined {", "function first<T>(xs: T[]): T | undefined"},
+		{"js function", "function", "function handler(req, res) {", "function handler(req, res)"},
+		{"ts arrow", "function", "export const fetchUser = async (id: string): Promise<User> => {", "async fetchUser(id: string): Promise<User>"},
+		{"js arrow", "function", "const add = (a, b) => a + b", "add(a, b)"},
+		{"ts method", "function", "  private async validate(token: string): Promise<void> {", "private async validate(token: string): Promise<void>"},
+		{"ts class", "class", "export class AuthService extends BaseService implements Auth {", "class AuthService extends BaseService implements Auth"},
+		
+		// Python
+		{"python function", "function", "def login(user):", "def login(user)"},
+		{"python annotated", "function", "async def fetch(url: str, *, retries: int = 3) -> Dict[str, Any]:", "async def fetch(url: str, *, retries: int = 3) -> Dict[str, Any]"},
+		{"python one-liner", "function", "def double(x): return x * 2", "def double(x)"},
+		{"python class", "class", "class UserRepo(BaseRepo):", "class UserRepo(BaseRepo)"},
+		
+		// Not confidently a declaration
+		{"call", "function", "if (isValid(token)) {", ""},
+		{"unbalanced", "function", "function login(user: User,", ""},
+		{"plain statement", "function", "return login(user)", ""},
+		{"variable", "variable", "const token = ''", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := extractSignature(searchResult{typ: tt.typ, context: tt.context})
+			if got != tt.want {
+				t.Errorf("extractSignature(%q) = %q, want %q", tt.context, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractSignatureSpanningLines(t *testing.T) {
+	result := searchResult{
+		typ:     "function",
+		context: "export function login(",
+		surrounding: []string{
+			"// Logs a user in",
+			"export function login(",
+			"  user: User,",
+			"  options?: Options",
+			"): Promise<Session> {",
+		},
+	}
+	want := "function login(user: User, options?: Options): Promise<Session>"
+	if got := extractSignature(result); got != want {
+		t.Errorf("extractSignature() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,86 @@
+package smartgrep
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// splitActive reports whether the list view shows the selected result's
+// detail beside the table. Narrow terminals fall back to tabs.
+func (m resultViewModel) splitActive() bool {
+	return m.split && m.width >= minSplitWidth
+}
+
+// splitColumns returns the list columns for the left pane of the split
+// layout: the term and location, sharing width cells between them
+func splitColumns(width int) []table.Column {
+	// Each cell is padded by one column on both sides
+	usable := max(width-4, 20)
+	term := usable * 2 / 5
+	return []table.Column{
+		{Title: "🎯 Term", Width: term},
+		{Title: "📍 Location", Width: usable - term},
+	}
+}
+
+// columns returns the table columns for the current layout
+func (m resultViewModel) columns() []table.Column {
+	if m.splitActive() {
+		return splitColumns(m.table.Width())
+	}
+	return resultColumns(m.compact)
+}
+
+// resize lays the table and viewport out for the terminal size and layout
+func (m *resultViewModel) resize() {
+	width := m.width - 4
+	m.viewport.Height = m.height - 10
+	if m.splitActive() {
+		left := width / 2
+		m.table.SetWidth(left)
+		m.table.SetHeight(m.viewport.Height)
+		m.viewport.Width = width - left - 3
+	} else {
+		m.table.SetWidth(width)
+		m.table.SetHeight(m.height / 2)
+		m.viewport.Width = width
+	}
+	
+	// Drop the rows first: the table renders them against the new columns
+	m.table.SetRows(nil)
+	m.table.SetColumns(m.columns())
+	m.refreshRows()
+}
+
+// toggleSplit switches the list view between the tabbed and split layouts
+func (m *resultViewModel) toggleSplit() {
+	m.split = !m.split
+	if m.split && !m.splitActive() {
+		m.notice = "Terminal too narrow for the split layout"
+	}
+	m.resize()
+}
+
+// syncSplitDetail renders the selected result into the right pane of the
+// split layout
+func (m *resultViewModel) syncSplitDetail() {
+	if m.activeView == "list" && m.splitActive() {
+		m.updateDetailView()
+	}
+}
+
+// splitView renders the table and the selected result's detail side by side
+func (m resultViewModel) splitView() string {
+	left := m.table.View()
+	if page := m.pageView(); page != "" {
+		left += "\n" + metaStyle.Render(page)
+	}
+	left = lipgloss.NewStyle().Width(m.table.Width()).Render(left)
+	right := m.viewport.View()
+	
+	height := max(lipgloss.Height(left), lipgloss.Height(right))
+	divider := graphEdgeStyle.Render(strings.TrimSuffix(strings.Repeat(" │ \n", height), "\n"))
+	return lipgloss.JoinHorizontal(lipgloss.Top, left, divider, right)
+}
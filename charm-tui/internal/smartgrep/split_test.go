@@ -0,0 +1,36 @@
+package smartgrep
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSplitLayoutFollowsCursor(t *testing.T) {
+	m := newResultViewModel()
+	m.setResults([]searchResult{
+		{term: "authenticate", typ: "function", context: "function authenticate() {", location: location{file: "auth.ts", line: 12}},
+		{term: "logout", typ: "function", context: "function logout() {", location: location{file: "auth.ts", line: 40}},
+	})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	rv := updated.(resultViewModel)
+	if !rv.splitActive() || len(rv.table.Rows()[0]) != 2 {
+		t.Fatalf("split layout not active, rows = %v", rv.table.Rows())
+	}
+	if view := rv.View(); !strings.Contains(view, "│") || !strings.Contains(rv.viewport.View(), "authenticate") {
+		t.Errorf("detail pane missing:\n%s", view)
+	}
+	
+	updated, _ = rv.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if rv = updated.(resultViewModel); !strings.Contains(rv.viewport.View(), "logout") {
+		t.Errorf("detail pane did not follow the cursor:\n%s", rv.viewport.View())
+	}
+	
+	// Narrow terminals fall back to the tabbed layout
+	updated, _ = rv.Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	if rv = updated.(resultViewModel); rv.splitActive() || len(rv.table.Rows()[0]) != 5 {
+		t.Errorf("split layout should be off at 80 columns, rows = %v", rv.table.Rows())
+	}
+}
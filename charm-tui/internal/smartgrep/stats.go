@@ -0,0 +1,50 @@
+package smartgrep
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// moveStatCursor moves the stats view's file selection by delta, scrolling
+// the viewport to keep it visible
+func (m *resultViewModel) moveStatCursor(delta int) {
+	if len(m.statFiles) == 0 {
+		return
+	}
+	m.statCursor = min(max(m.statCursor+delta, 0), len(m.statFiles)-1)
+	m.updateStatsView()
+	
+	line := m.statFilesLine + m.statCursor
+	if line < m.viewport.YOffset {
+		m.viewport.SetYOffset(line)
+	} else if m.viewport.Height > 0 && line >= m.viewport.YOffset+m.viewport.Height {
+		m.viewport.SetYOffset(line - m.viewport.Height + 1)
+	}
+}
+
+// openStatFile opens the file selected in the stats view at its first result
+func (m *resultViewModel) openStatFile() tea.Cmd {
+	if m.statCursor >= len(m.statFiles) {
+		return nil
+	}
+	file := m.statFiles[m.statCursor].file
+	
+	cmd, err := editFile(file, firstResultLine(m.results, file))
+	if err != nil {
+		m.notice = fmt.Sprintf("⚠️  Cannot open %s: %v", file, err)
+		m.updateStatsView()
+	}
+	return cmd
+}
+
+// firstResultLine returns the earliest line of any result in file, or 1
+func firstResultLine(results []searchResult, file string) int {
+	line := 0
+	for _, r := range results {
+		if r.location.file == file && r.location.line > 0 && (line == 0 || r.location.line < line) {
+			line = r.location.line
+		}
+	}
+	return max(line, 1)
+}
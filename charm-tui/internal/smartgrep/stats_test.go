@@ -0,0 +1,49 @@
+package smartgrep
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestStatsFileSelection(t *testing.T) {
+	m := newResultViewModel()
+	m.setResults([]searchResult{
+		{term: "a", location: location{file: "hot.ts", line: 40}},
+		{term: "b", location: location{file: "hot.ts", line: 12}},
+		{term: "c", location: location{file: "cold.ts", line: 3}},
+	})
+	m.activeView = "stats"
+	m.updateStatsView()
+	
+	down := tea.KeyMsg{Type: tea.KeyDown}
+	updated, _ := m.Update(down)
+	updated, _ = updated.Update(down)
+	rv := updated.(resultViewModel)
+	if rv.statCursor != 1 || rv.statFiles[rv.statCursor].file != "cold.ts" {
+		t.Fatalf("cursor = %d, want clamped on cold.ts", rv.statCursor)
+	}
+	
+	// Missing files are reported rather than handed to the editor
+	SetProjectDir(t.TempDir())
+	t.Cleanup(func() { SetProjectDir("") })
+	updated, cmd := rv.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil || !strings.Contains(updated.(resultViewModel).notice, "Cannot open cold.ts") {
+		t.Errorf("notice = %q", updated.(resultViewModel).notice)
+	}
+}
+
+func TestFirstResultLine(t *testing.T) {
+	results := []searchResult{
+		{location: location{file: "a.ts", line: 40}},
+		{location: location{file: "a.ts", line: 12}},
+		{location: location{file: "b.ts", line: 3}},
+	}
+	if got := firstResultLine(results, "a.ts"); got != 12 {
+		t.Errorf("firstResultLine(a.ts) = %d, want 12", got)
+	}
+	if got := firstResultLine(results, "c.ts"); got != 1 {
+		t.Errorf("firstResultLine(c.ts) = %d, want 1", got)
+	}
+}
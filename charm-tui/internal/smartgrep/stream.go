@@ -0,0 +1,232 @@
+package smartgrep
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/style"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxStreamResults caps how many results are decoded from a single search so
+// multi-megabyte payloads can't grow memory without bound
+const maxStreamResults = 10000
+
+// streamSearchResults runs a search and hands each result to onResult as soon
+// as it is decoded, without buffering the whole payload. It returns
+// errResultLimit when it stopped at maxStreamResults.
+func streamSearchResults(ctx context.Context, args []string, onResult func(searchResult)) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	
+	stdout, wait, err := startSmartgrep(ctx, append(args, "--json")...)
+	if err != nil {
+		return fmt.Errorf("failed to run smartgrep: %w", err)
+	}
+	
+	decodeErr := decodeSearchResults(stdout, maxStreamResults, onResult)
+	if decodeErr != nil {
+		// Stop the process rather than waiting on output we won't read
+		cancel()
+	}
+	
+	// Drain anything left so the process can exit cleanly
+	io.Copy(io.Discard, stdout)
+	waitErr := wait()
+	
+	if decodeErr != nil && decodeErr != errResultLimit {
+		config.LogParseError("search", decodeErr)
+		return decodeErr
+	}
+	if waitErr != nil && decodeErr == nil {
+		return fmt.Errorf("failed to run smartgrep: %w", waitErr)
+	}
+	return decodeErr
+}
+
+// errResultLimit signals that decoding stopped at maxStreamResults
+var errResultLimit = fmt.Errorf("result limit reached")
+
+// decodeSearchResults skips any progress output preceding the JSON array and
+// then decodes its elements one at a time
+func decodeSearchResults(r io.Reader, limit int, onResult func(searchResult)) error {
+	// Colored CLI output would otherwise hide the array start and break decoding
+	br := bufio.NewReader(&ansiStripReader{br: bufio.NewReader(r)})
+	
+	// Find the line where the JSON array starts
+	var first []byte
+	found := false
+	for {
+		line, err := br.ReadBytes('\n')
+		if trimmed := bytes.TrimSpace(line); bytes.HasPrefix(trimmed, []byte("[")) {
+			first = line
+			found = true
+			break
+		}
+		if err != nil {
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("no JSON output found")
+	}
+	
+	dec := json.NewDecoder(io.MultiReader(bytes.NewReader(first), br))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+		return fmt.Errorf("failed to parse JSON: expected array")
+	}
+	
+	count := 0
+	for dec.More() {
+		if count >= limit {
+			return errResultLimit
+		}
+		
+		var tr TSResult
+		if err := dec.Decode(&tr); err != nil {
+			return fmt.Errorf("failed to parse JSON: %w", err)
+		}
+		onResult(tr.toSearchResult())
+		count++
+	}
+	
+	// Consume the closing bracket so truncated output is reported
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	return nil
+}
+
+// streamBatchSize is how many results are delivered to the TUI at a time
+const streamBatchSize = 50
+
+// Messages for progressively populating a resultViewModel. A batch carries
+// the channel the rest of its search arrives on.
+type resultsBatchMsg struct {
+	results []searchResult
+	next    <-chan tea.Msg
+}
+type resultsDoneMsg struct {
+	err    error
+	capped bool // The search stopped at maxStreamResults
+}
+
+// searchResultsCmd runs a search in the background and delivers its results
+// in batches, followed by a resultsDoneMsg. Cancelling ctx stops the search
+// and the delivery, so nothing is left blocked once the TUI has quit.
+func searchResultsCmd(ctx context.Context, args []string) tea.Cmd {
+	return func() tea.Msg {
+		ch := make(chan tea.Msg)
+		go func() {
+			defer close(ch)
+			send := func(msg tea.Msg) {
+				select {
+				case ch <- msg:
+				case <-ctx.Done():
+				}
+			}
+			
+			var batch []searchResult
+			err := streamSearchResults(ctx, args, func(r searchResult) {
+				batch = append(batch, r)
+				if len(batch) >= streamBatchSize {
+					send(resultsBatchMsg{results: batch})
+					batch = nil
+				}
+			})
+			if len(batch) > 0 {
+				send(resultsBatchMsg{results: batch})
+			}
+			if err == errResultLimit {
+				send(resultsDoneMsg{capped: true})
+			} else {
+				send(resultsDoneMsg{err: err})
+			}
+		}()
+		return waitForResults(ch)()
+	}
+}
+
+// waitForResults delivers the next message of a running search
+func waitForResults(ch <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return nil
+		}
+		if batch, ok := msg.(resultsBatchMsg); ok {
+			batch.next = ch
+			return batch
+		}
+		return msg
+	}
+}
+
+// findJSONArray returns the JSON array in CLI output, skipping any status
+// lines printed before it
+func findJSONArray(output []byte) ([]byte, error) {
+	output = style.StripANSIBytes(output)
+	
+	offset := 0
+	for offset < len(output) {
+		line := output[offset:]
+		if i := bytes.IndexByte(line, '\n'); i >= 0 {
+			line = line[:i+1]
+		}
+		if bytes.HasPrefix(bytes.TrimSpace(line), []byte("[")) {
+			return output[offset:], nil
+		}
+		offset += len(line)
+	}
+	return nil, fmt.Errorf("no JSON output found")
+}
+
+// ansiStripReader removes terminal escape sequences line by line. Lines
+// without escapes are passed through without copying.
+type ansiStripReader struct {
+	br      *bufio.Reader
+	pending []byte
+	carry   []byte // Start of an escape sequence split by a long line
+	err     error
+}
+
+// maxEscapeLen bounds how far back a split escape sequence is looked for
+const maxEscapeLen = 32
+
+func (a *ansiStripReader) Read(p []byte) (int, error) {
+	for len(a.pending) == 0 {
+		if a.err != nil {
+			if len(a.carry) > 0 {
+				a.pending, a.carry = a.carry, nil
+				break
+			}
+			return 0, a.err
+		}
+		
+		var line []byte
+		line, a.err = a.br.ReadSlice('\n')
+		if len(a.carry) > 0 {
+			line = append(a.carry, line...)
+			a.carry = nil
+		}
+		if a.err == bufio.ErrBufferFull {
+			// Very long line: process it in chunks, holding back a trailing
+			// escape sequence that may continue in the next chunk
+			a.err = nil
+			if i := bytes.LastIndexByte(line, 0x1b); i >= 0 && len(line)-i < maxEscapeLen {
+				a.carry = append([]byte(nil), line[i:]...)
+				line = line[:i]
+			}
+		}
+		a.pending = style.StripANSIBytes(line)
+	}
+	
+	n := copy(p, a.pending)
+	a.pending = a.pending[n:]
+	return n, nil
+}
@@ -0,0 +1,184 @@
+package smartgrep
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const sampleResult = `{"info":{"term":"authenticateUser","type":"function","location":{"file":"src/auth.ts","line":12,"column":1}},"relevanceScore":0.9}`
+
+func decodeAll(t *testing.T, output string) []searchResult {
+	t.Helper()
+	var results []searchResult
+	err := decodeSearchResults(strings.NewReader(output), maxStreamResults, func(r searchResult) {
+		results = append(results, r)
+	})
+	if err != nil {
+		t.Fatalf("decodeSearchResults: %v", err)
+	}
+	return results
+}
+
+func TestDecodeJSONOnFirstLine(t *testing.T) {
+	results := decodeAll(t, "["+sampleResult+"]\n")
+	if len(results) != 1 || results[0].term != "authenticateUser" {
+		t.Fatalf("got %+v, want one authenticateUser result", results)
+	}
+}
+
+func TestDecodeJSONAfterBanner(t *testing.T) {
+	output := "🔍 Searching...\nLoaded index\n[\n  " + sampleResult + "\n]\n"
+	results := decodeAll(t, output)
+	if len(results) != 1 || results[0].location.file != "src/auth.ts" || results[0].location.line != 12 {
+		t.Fatalf("got %+v", results)
+	}
+}
+
+func TestDecodeNoJSON(t *testing.T) {
+	err := decodeSearchResults(strings.NewReader("No results found\n"), maxStreamResults, func(searchResult) {})
+	if err == nil {
+		t.Fatal("expected an error when there is no JSON array")
+	}
+}
+
+func TestDecodeColoredOutput(t *testing.T) {
+	output := "\x1b[1m\x1b[36m🔍 Searching...\x1b[0m\n" +
+		"\x1b[32m[\x1b[0m\n  " + sampleResult + "\x1b[32m\n]\x1b[0m\n"
+	results := decodeAll(t, output)
+	if len(results) != 1 || results[0].term != "authenticateUser" {
+		t.Fatalf("got %+v", results)
+	}
+}
+
+func TestANSIStripReaderLongLines(t *testing.T) {
+	// Longer than bufio's default buffer so escapes land on chunk boundaries
+	var in, want strings.Builder
+	for i := 0; i < 2000; i++ {
+		in.WriteString("\x1b[32mok\x1b[0m ")
+		want.WriteString("ok ")
+	}
+	
+	var out strings.Builder
+	if _, err := io.Copy(&out, &ansiStripReader{br: bufio.NewReader(strings.NewReader(in.String()))}); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != want.String() {
+		t.Errorf("escape sequences leaked through: %q", out.String()[:80])
+	}
+}
+
+func TestWaitForResultsChainsBatches(t *testing.T) {
+	ch := make(chan tea.Msg, 3)
+	ch <- resultsBatchMsg{results: []searchResult{{term: "a"}}}
+	ch <- resultsDoneMsg{}
+	close(ch)
+	
+	batch, ok := waitForResults(ch)().(resultsBatchMsg)
+	if !ok || len(batch.results) != 1 || batch.next == nil {
+		t.Fatalf("first message = %+v, want a batch carrying the channel", batch)
+	}
+	if _, ok := waitForResults(batch.next)().(resultsDoneMsg); !ok {
+		t.Error("second message should be resultsDoneMsg")
+	}
+	if msg := waitForResults(batch.next)(); msg != nil {
+		t.Errorf("closed channel should yield nil, got %v", msg)
+	}
+}
+
+func TestRetryAfterError(t *testing.T) {
+	m := newResultViewModel()
+	m.query = "auth"
+	m.searchArgs = []string{"auth"}
+	m.loadErr = errors.New("smartgrep exited")
+	
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	rv := updated.(resultViewModel)
+	if !rv.loading || rv.loadErr != nil || cmd == nil {
+		t.Errorf("r should restart the search, got loading=%v err=%v", rv.loading, rv.loadErr)
+	}
+	
+	// Without a search to repeat the key does nothing
+	m.searchArgs = nil
+	if updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")}); updated.(resultViewModel).loading {
+		t.Error("r should not retry a view that has no search")
+	}
+}
+
+// printResults is a fakeCLI script fragment printing n search results
+func printResults(n int) string {
+	return `echo '['
+i=1
+while [ $i -lt ` + strconv.Itoa(n) + ` ]; do echo '` + sampleResult + `,'; i=$((i+1)); done
+echo '` + sampleResult + `]'
+`
+}
+
+// drain reads the messages of a streaming search until it is done or its
+// channel closes
+func drain(t *testing.T, msg tea.Msg, timeout time.Duration) (int, *resultsDoneMsg) {
+	t.Helper()
+	deadline := time.After(timeout)
+	results := 0
+	for {
+		switch m := msg.(type) {
+		case resultsBatchMsg:
+			results += len(m.results)
+			next := make(chan tea.Msg, 1)
+			go func() { next <- waitForResults(m.next)() }()
+			select {
+			case msg = <-next:
+			case <-deadline:
+				t.Fatal("search still running")
+			}
+		case resultsDoneMsg:
+			return results, &m
+		default:
+			return results, nil
+		}
+	}
+}
+
+func TestSearchResultsCmdCapped(t *testing.T) {
+	fakeCLI(t, printResults(maxStreamResults+1))
+	
+	results, done := drain(t, searchResultsCmd(context.Background(), []string{"auth"})(), 10*time.Second)
+	if results != maxStreamResults || done == nil || !done.capped || done.err != nil {
+		t.Fatalf("got %d results, done %+v, want %d and capped", results, done, maxStreamResults)
+	}
+	
+	m := newResultViewModel()
+	m.loading = true
+	updated, _ := m.Update(*done)
+	if view := updated.(resultViewModel).View(); !strings.Contains(view, "Showing the first 10000 results") {
+		t.Errorf("capped search not reported:\n%s", view)
+	}
+}
+
+func TestSearchResultsCmdStopsWhenCancelled(t *testing.T) {
+	// More results than one batch, then a CLI that never finishes
+	fakeCLI(t, printResults(streamBatchSize+10)+"exec sleep 10\n")
+	
+	ctx, cancel := context.WithCancel(context.Background())
+	first, ok := searchResultsCmd(ctx, []string{"auth"})().(resultsBatchMsg)
+	if !ok {
+		t.Fatal("expected a first batch")
+	}
+	
+	// Nothing reads the rest: quitting must not leave the search blocked
+	cancel()
+	time.Sleep(100 * time.Millisecond)
+	if _, done := drain(t, first, 2*time.Second); done != nil {
+		t.Errorf("cancelled search still delivered %+v", *done)
+	}
+	if n := InFlight(); n != 0 {
+		t.Errorf("%d backend processes still running", n)
+	}
+}
@@ -0,0 +1,70 @@
+package smartgrep
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+// Result thresholds set via --min-relevance and --min-usage
+var (
+	minRelevance float64
+	minUsage     int
+)
+
+// SetThresholds drops results scoring below relevance (0-1) or used fewer
+// than usage times
+func SetThresholds(relevance float64, usage int) {
+	minRelevance = relevance
+	minUsage = usage
+}
+
+// Thresholded reports whether results are filtered by SetThresholds
+func Thresholded() bool {
+	return minRelevance > 0 || minUsage > 0
+}
+
+// PrintThresholded runs a search and writes the results that meet the
+// thresholds, at most max of them (0 for no limit): as JSON with asJSON,
+// otherwise in the plain text format of --for-llm. The TypeScript CLI has no
+// thresholds, so it is asked for every result and they are filtered here.
+// Cancelling ctx stops the search.
+func PrintThresholded(ctx context.Context, w io.Writer, args []string, max int, asJSON bool) error {
+	args = append(args, "--max", strconv.Itoa(maxStreamResults))
+	results, err := fetchSearchResults(ctx, args...)
+	if err != nil {
+		return err
+	}
+	results = applyThresholds(results, minRelevance, minUsage)
+	if max > 0 && len(results) > max {
+		results = results[:max]
+	}
+	
+	if asJSON {
+		// Same shape as the CLI's own --json output
+		out := make([]TSResult, 0, len(results))
+		for _, r := range results {
+			out = append(out, r.toTSResult())
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+	_, err = io.WriteString(w, formatForLLM(searchQuery(args), results, 0))
+	return err
+}
+
+// applyThresholds returns the results that meet both thresholds, in order
+func applyThresholds(results []searchResult, relevance float64, usage int) []searchResult {
+	if relevance <= 0 && usage <= 0 {
+		return results
+	}
+	kept := make([]searchResult, 0, len(results))
+	for _, r := range results {
+		if r.relevance >= relevance && r.usageCount >= usage {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,154 @@
+package smartgrep
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+)
+
+func TestApplyThresholds(t *testing.T) {
+	results := []searchResult{
+		{term: "a", relevance: 0.9, usageCount: 10},
+		{term: "b", relevance: 0.4, usageCount: 10},
+		{term: "c", relevance: 0.9, usageCount: 1},
+		{term: "d", relevance: 0.5, usageCount: 3},
+	}
+	
+	tests := []struct {
+		relevance float64
+		usage     int
+		want      string
+	}{
+		{0, 0, "abcd"},
+		{0.5, 0, "acd"},
+		{0, 3, "abd"},
+		{0.5, 3, "ad"},
+		{1, 0, ""},
+	}
+	for _, tt := range tests {
+		var got string
+		for _, r := range applyThresholds(results, tt.relevance, tt.usage) {
+			got += r.term
+		}
+		if got != tt.want {
+			t.Errorf("applyThresholds(%v, %d) = %q, want %q", tt.relevance, tt.usage, got, tt.want)
+		}
+	}
+}
+
+func TestSetResultsAppliesThresholds(t *testing.T) {
+	m := newResultViewModel()
+	m.minRelevance = 0.5
+	m.setResults([]searchResult{{term: "kept", relevance: 0.8}, {term: "dropped", relevance: 0.2}})
+	
+	if len(m.results) != 1 || m.results[0].term != "kept" {
+		t.Errorf("results = %v", m.results)
+	}
+	if len(m.table.Rows()) != 1 {
+		t.Errorf("rows = %v", m.table.Rows())
+	}
+}
+
+// fakeCLI installs a shell script as the smartgrep CLI for the test. Its
+// arguments are written to the returned file, one per line.
+func fakeCLI(t *testing.T, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the CLI")
+	}
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	
+	dir := t.TempDir()
+	cli := filepath.Join(dir, "smartgrep")
+	argsFile := cli + ".args"
+	script = "#!/bin/sh\nprintf '%s\\n' \"$@\" > \"$0.args\"\n" + script
+	if err := os.WriteFile(cli, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CURATOR_CONFIG", filepath.Join(dir, "config.yaml"))
+	t.Setenv("SMARTGREP_CLI_PATH", cli)
+	config.ResetCache()
+	t.Cleanup(config.ResetCache)
+	return argsFile
+}
+
+func TestPrintThresholded(t *testing.T) {
+	argsFile := fakeCLI(t, `echo '📚 Loading semantic index...'
+cat <<'JSON'
+[
+  {"info":{"term":"login","type":"function","location":{"file":"a.ts","line":1}},"relevanceScore":0.9,"usageCount":12},
+  {"info":{"term":"logout","type":"function","location":{"file":"a.ts","line":9}},"relevanceScore":0.8,"usageCount":1},
+  {"info":{"term":"loginForm","type":"class","location":{"file":"b.ts","line":3}},"relevanceScore":0.7,"usageCount":5},
+  {"info":{"term":"logo","type":"variable","location":{"file":"c.ts","line":2}},"relevanceScore":0.2,"usageCount":40}
+]
+JSON
+`)
+	SetThresholds(0.5, 5)
+	t.Cleanup(func() { SetThresholds(0, 0) })
+	
+	var out bytes.Buffer
+	if err := PrintThresholded(context.Background(), &out, []string{"log", "--type", "function,class"}, 1, true); err != nil {
+		t.Fatalf("PrintThresholded: %v", err)
+	}
+	var results []TSResult
+	if err := json.Unmarshal(out.Bytes(), &results); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+	}
+	if len(results) != 1 || results[0].Info.Term != "login" {
+		t.Errorf("results = %+v, want only login", results)
+	}
+	
+	data, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	args := strings.Fields(string(data))
+	if want := []string{"log", "--type", "function,class", "--max", "10000", "--json"}; !reflect.DeepEqual(args, want) {
+		t.Errorf("CLI args = %q, want %q", args, want)
+	}
+	
+	out.Reset()
+	if err := PrintThresholded(context.Background(), &out, []string{"log"}, 0, false); err != nil {
+		t.Fatalf("PrintThresholded: %v", err)
+	}
+	if text := out.String(); !strings.Contains(text, "login @ a.ts:1") || !strings.Contains(text, "loginForm @ b.ts:3") || strings.Contains(text, "logo ") {
+		t.Errorf("text output not filtered:\n%s", text)
+	}
+}
+
+func TestPrintTimesOut(t *testing.T) {
+	fakeCLI(t, "exec sleep 10\n")
+	SetThresholds(0.5, 0)
+	t.Cleanup(func() { SetThresholds(0, 0) })
+	
+	for name, print := range map[string]func(context.Context) error{
+		"for-llm":     func(ctx context.Context) error { return PrintForLLM(ctx, io.Discard, []string{"auth"}, 0) },
+		"thresholded": func(ctx context.Context) error { return PrintThresholded(ctx, io.Discard, []string{"auth"}, 0, true) },
+	} {
+		timeout := 100 * time.Millisecond
+		ctx, cancel := config.TimeoutContext(timeout)
+		start := time.Now()
+		err := config.TimeoutError(ctx, timeout, print(ctx))
+		cancel()
+		if !errors.Is(err, config.ErrTimeout) {
+			t.Errorf("%s: error = %v, want a timeout", name, err)
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("%s: returned after %s", name, elapsed)
+		}
+	}
+}
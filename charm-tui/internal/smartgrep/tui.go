@@ -1,34 +1,37 @@
 package smartgrep
 
 import (
-	"encoding/json"
+	"bytes"
+	"context"
 	"fmt"
-	"os"
-	"os/exec"
 	"strings"
+	"time"
 
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/style"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
-	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
 )
 
 // Styles
 var (
 	titleStyle = lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("212")).
+			Foreground(style.Colors().Title).
 			MarginBottom(1)
 			
 	selectedStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("120"))
+			Foreground(style.Colors().Added)
 			
 	headerStyle = lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("33"))
+			Foreground(style.Colors().Accent)
+			
+	inputErrStyle = lipgloss.NewStyle().
+			Foreground(style.Colors().Deleted)
 )
 
 // Key bindings
@@ -68,6 +71,8 @@ type menuItem struct {
 	title       string
 	description string
 	action      string
+	query       string // For history suggestions: the query to re-run
+	queryMode   string // For history suggestions: "pattern" or "refs"
 }
 
 func (i menuItem) Title() string       { return i.title }
@@ -79,8 +84,13 @@ type model struct {
 	mode        string
 	mainMenu    list.Model
 	searchInput textinput.Model
+	inputErr    string // Why the input was not submitted, shown below it
 	results     string
 	err         error
+	batch       resultViewModel // Combined results of a Claude batch run
+	running     bool
+	width       int
+	height      int
 }
 
 func initialModel() model {
@@ -113,6 +123,13 @@ func initialModel() model {
 		},
 	}
 	
+	// Recent and frequent searches from history
+	if entries, err := loadHistory(); err == nil {
+		items = append(items, historyItems(entries, historyProject(), time.Now())...)
+	} else {
+		items = append(items, historyWarning(err))
+	}
+	
 	// Create list
 	mainMenu := list.New(items, list.NewDefaultDelegate(), 0, 0)
 	mainMenu.Title = "SmartGrep - Semantic Search"
@@ -140,8 +157,13 @@ func (m model) Init() tea.Cmd {
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
 		m.mainMenu.SetWidth(msg.Width)
 		m.mainMenu.SetHeight(msg.Height - 4)
+		if m.mode == "batch" {
+			return m.updateBatch(msg)
+		}
 		return m, nil
 		
 	case tea.KeyMsg:
@@ -152,8 +174,17 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, tea.Quit
 			case key.Matches(msg, keys.Select):
 				selected := m.mainMenu.SelectedItem().(menuItem)
+				if selected.action == "notice" {
+					return m, nil
+				}
+				if selected.action == "suggestion" {
+					// Re-run a past search
+					m.mode = selected.queryMode
+					m.searchInput.SetValue(selected.query)
+					return m, m.executeSearch()
+				}
 				m.mode = selected.action
-				if m.mode == "pattern" || m.mode == "refs" {
+				if m.mode == "pattern" || m.mode == "refs" || m.mode == "claude" {
 					m.searchInput.Focus()
 					return m, textinput.Blink
 				}
@@ -161,18 +192,44 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, m.executeSearch()
 			}
 			
-		case "pattern", "refs":
+		case "pattern", "refs", "claude":
+			if m.running {
+				if key.Matches(msg, keys.Quit) {
+					return m, tea.Quit
+				}
+				return m, nil
+			}
 			switch {
 			case key.Matches(msg, keys.Back):
 				m.mode = "menu"
+				m.inputErr = ""
 				m.searchInput.Blur()
 				m.searchInput.SetValue("")
 				return m, nil
 			case key.Matches(msg, keys.Quit):
 				return m, tea.Quit
 			case msg.Type == tea.KeyEnter:
+				if strings.TrimSpace(m.searchInput.Value()) == "" {
+					// Stay in the input rather than fail the search
+					m.inputErr = emptyInputError(m.mode)
+					return m, nil
+				}
+				m.inputErr = ""
+				if m.mode == "claude" {
+					m.running = true
+				}
 				return m, m.executeSearch()
 			}
+			m.inputErr = ""
+			
+		case "batch":
+			// Esc leaves the report unless the result view uses it itself
+			if key.Matches(msg, keys.Back) && !m.batch.capturesEsc() {
+				m.mode = "menu"
+				m.searchInput.SetValue("")
+				return m, nil
+			}
+			return m.updateBatch(msg)
 			
 		case "results":
 			switch {
@@ -190,6 +247,19 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.mode = "results"
 		return m, nil
 		
+	case batchResult:
+		m.running = false
+		m.searchInput.Blur()
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.batch = newResultViewModel()
+		m.batch.title = fmt.Sprintf("🤖 Batch report for %q • %d searches • %d unique results", msg.topic, msg.searches, len(msg.results))
+		m.batch.setResults(msg.results)
+		m.mode = "batch"
+		return m.updateBatch(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+		
 	case errMsg:
 		m.err = msg
 		return m, nil
@@ -201,15 +271,25 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		var cmd tea.Cmd
 		m.mainMenu, cmd = m.mainMenu.Update(msg)
 		return m, cmd
-	case "pattern", "refs":
+	case "pattern", "refs", "claude":
 		var cmd tea.Cmd
 		m.searchInput, cmd = m.searchInput.Update(msg)
 		return m, cmd
+	case "batch":
+		// References, blame, editor exits, watch and spinner ticks
+		return m.updateBatch(msg)
 	}
 	
 	return m, nil
 }
 
+// updateBatch forwards a message to the batch report view
+func (m model) updateBatch(msg tea.Msg) (tea.Model, tea.Cmd) {
+	updated, cmd := m.batch.Update(msg)
+	m.batch = updated.(resultViewModel)
+	return m, cmd
+}
+
 func (m model) View() string {
 	if m.err != nil {
 		return fmt.Sprintf("Error: %v\n\nPress 'q' to quit.", m.err)
@@ -222,15 +302,28 @@ func (m model) View() string {
 	case "pattern":
 		return titleStyle.Render("Pattern Search") + "\n\n" +
 			"Enter search pattern (use | for OR, & for AND, ! for NOT):\n\n" +
-			m.searchInput.View() + "\n\n" +
+			m.searchInput.View() + m.inputErrView() + "\n\n" +
 			"Press Enter to search, Esc to go back"
 			
 	case "refs":
 		return titleStyle.Render("Find References") + "\n\n" +
 			"Enter symbol name:\n\n" +
-			m.searchInput.View() + "\n\n" +
+			m.searchInput.View() + m.inputErrView() + "\n\n" +
 			"Press Enter to search, Esc to go back"
 			
+	case "claude":
+		if m.running {
+			return titleStyle.Render("Claude Batch Mode") + "\n\n" +
+				fmt.Sprintf("⏳ Running related searches for %q...", m.searchInput.Value())
+		}
+		return titleStyle.Render("Claude Batch Mode") + "\n\n" +
+			"Enter a topic to explore (e.g. auth, payment):\n\n" +
+			m.searchInput.View() + m.inputErrView() + "\n\n" +
+			"Press Enter to run, Esc to go back"
+			
+	case "batch":
+		return m.batch.View()
+		
 	case "results":
 		return titleStyle.Render("Search Results") + "\n\n" +
 			m.results + "\n\n" +
@@ -241,6 +334,25 @@ func (m model) View() string {
 	}
 }
 
+// emptyInputError asks for the input mode needs before it can run
+func emptyInputError(mode string) string {
+	switch mode {
+	case "refs":
+		return "Enter a symbol name to find its references"
+	case "claude":
+		return "Enter a topic to explore"
+	}
+	return "Enter a pattern to search for"
+}
+
+// inputErrView shows why the input was not submitted, if it wasn't
+func (m model) inputErrView() string {
+	if m.inputErr == "" {
+		return ""
+	}
+	return "\n" + inputErrStyle.Render("⚠️  "+m.inputErr)
+}
+
 // Commands
 type searchResultMsg string
 type errMsg error
@@ -254,223 +366,146 @@ func (m model) executeSearch() tea.Cmd {
 			if m.searchInput.Value() == "" {
 				return errMsg(fmt.Errorf("search pattern required"))
 			}
-			cmdArgs = []string{"run", "../../src/tools/smartgrep/cli.ts", m.searchInput.Value()}
+			cmdArgs = []string{m.searchInput.Value()}
 			
 		case "refs":
 			if m.searchInput.Value() == "" {
 				return errMsg(fmt.Errorf("symbol name required"))
 			}
-			cmdArgs = []string{"run", "../../src/tools/smartgrep/cli.ts", "refs", m.searchInput.Value()}
+			cmdArgs = []string{"refs", m.searchInput.Value()}
 			
 		case "group":
 			// For now, just list groups
-			cmdArgs = []string{"run", "../../src/tools/smartgrep/cli.ts", "group", "list"}
+			cmdArgs = []string{"group", "list"}
 			
 		case "changes":
-			cmdArgs = []string{"run", "../../src/tools/smartgrep/cli.ts", "changes"}
+			cmdArgs = []string{"changes"}
 			
 		case "claude":
-			// Special handling for Claude batch mode
-			topic := "general"
-			if m.searchInput.Value() != "" {
-				topic = m.searchInput.Value()
+			// Run a set of related searches and combine them into one report
+			topic := strings.TrimSpace(m.searchInput.Value())
+			if topic == "" {
+				return batchResult{err: fmt.Errorf("topic required")}
 			}
-			return searchResultMsg(fmt.Sprintf("Claude Batch Mode for topic: %s\n\nThis would run multiple searches and create a comprehensive report.\n(Full implementation pending)", topic))
+			recordSearch("pattern", topic)
+			return runBatch(context.Background(), topic)
 		}
 		
 		// Execute command
-		executor := config.GetExecutor()
-		cliPath := config.GetSmartgrepPath()
-		
-		var execCmd *exec.Cmd
-		if executor != "" {
-			execCmd = exec.Command(executor, cmdArgs...)
-		} else {
-			// Adjust command for production
-			if len(cmdArgs) > 2 && cmdArgs[0] == "run" {
-				cmdArgs = cmdArgs[2:] // Remove "run" and script path
-			}
-			execCmd = exec.Command(cliPath, cmdArgs...)
-		}
-		
-		output, err := execCmd.CombinedOutput()
+		output, err := runSmartgrep(context.Background(), true, cmdArgs...)
 		if err != nil {
 			return errMsg(fmt.Errorf("command failed: %w\n%s", err, string(output)))
 		}
 		
+		if m.mode == "pattern" || m.mode == "refs" {
+			recordSearch(m.mode, m.searchInput.Value())
+		}
+		
 		return searchResultMsg(string(output))
 	}
 }
 
-// RunTUI launches the main TUI
-func RunTUI() error {
-	// Check if we have arguments for direct search
-	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "--") {
+// RunTUI launches the results TUI for opts.Query, or the main menu when no
+// query is given
+func RunTUI(opts TUIOptions) error {
+	if opts.Query != "" {
 		// Direct search mode - launch results TUI
-		query := strings.Join(os.Args[1:], " ")
-		return runSearchTUI(query)
+		return runSearchTUI(opts)
 	}
 	
 	// Interactive menu mode
-	p := tea.NewProgram(initialModel(), tea.WithAltScreen())
-	_, err := p.Run()
+	_, err := style.Run(initialModel())
 	return err
 }
 
 // runSearchTUI runs the beautiful Claude TUI with search results
-func runSearchTUI(query string) error {
-	// Get search results from TypeScript CLI
-	results, err := getSearchResultsJSON(query)
-	if err != nil {
-		return err
-	}
-	
+func runSearchTUI(opts TUIOptions) error {
 	// Create and run the Claude-optimized TUI
 	m := newResultViewModel()
-	m.results = results
-	
-	// Update table with results
-	var rows []table.Row
-	for _, r := range results {
-		rows = append(rows, table.Row{
-			r.term,
-			r.typ,
-			fmt.Sprintf("%s:%d", r.location.file, r.location.line),
-			fmt.Sprintf("%.0f%%", r.relevance*100),
-			fmt.Sprintf("%d", r.usageCount),
-		})
+	m.focus = focusFile
+	m.minRelevance = minRelevance
+	m.minUsage = minUsage
+	m.loading = true
+	selection, err := parseSelection(opts.Select)
+	if err != nil {
+		return err
 	}
-	m.table.SetRows(rows)
+	// Results stream in after the program starts, so the selection is
+	// applied once they have all loaded
+	m.selection = selection
 	
-	// Run TUI
-	p := tea.NewProgram(m, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+	// Init populates the table progressively as results stream in from the TypeScript CLI
+	m.applyOptions(opts)
+	final, err := style.Run(m)
+	m.cancel() // However the program ended, stop a search still running
+	if err != nil {
+		recordSearch("pattern", opts.Query)
 		return err
 	}
-	
-	return nil
+	rv, _ := final.(resultViewModel)
+	if !rv.loading && rv.loadErr == nil {
+		recordSearchResults("pattern", opts.Query, len(rv.results))
+	} else {
+		recordSearch("pattern", opts.Query)
+	}
+	if rv.backToMenu {
+		// Let the user edit the pattern that found nothing
+		menu := initialModel()
+		menu.mode = "pattern"
+		menu.searchInput.SetValue(opts.Query)
+		menu.searchInput.Focus()
+		_, err = style.Run(menu)
+	}
+	return err
 }
 
-// RunGroupTUI launches group-specific TUI
+// RunGroupTUI launches the concept group browser
 func RunGroupTUI() error {
-	// For now, redirect to main TUI
-	return RunTUI()
+	_, err := style.Run(newGroupModel())
+	return err
 }
 
-// RunRefsTUI launches refs-specific TUI
-func RunRefsTUI() error {
-	// For now, redirect to main TUI
-	return RunTUI()
+// RunRefsTUI explores the references to symbol, prompting for one if empty
+func RunRefsTUI(symbol string) error {
+	var m tea.Model
+	if symbol == "" {
+		menu := initialModel()
+		menu.mode = "refs"
+		menu.searchInput.Focus()
+		m = menu
+	} else {
+		m = newRefsModel(symbol)
+	}
+	
+	final, err := style.Run(m)
+	if rm, ok := final.(refsModel); ok {
+		if !rm.loading && rm.err == nil {
+			recordSearchResults("refs", symbol, len(rm.refs))
+		} else {
+			recordSearch("refs", symbol)
+		}
+	}
+	return err
 }
 
-// RunChangesTUI launches changes-specific TUI
-func RunChangesTUI() error {
-	// For now, redirect to main TUI
-	return RunTUI()
+// RunChangesTUI browses the changes opts select with their affected
+// symbols and diffs
+func RunChangesTUI(opts ChangesOptions) error {
+	_, err := style.Run(newChangesModel(opts))
+	return err
 }
 
-// getSearchResultsJSON calls TypeScript CLI and parses JSON results
-func getSearchResultsJSON(query string) ([]searchResult, error) {
-	executor := config.GetExecutor()
-	cliPath := config.GetSmartgrepPath()
-	
-	var cmd *exec.Cmd
-	if executor != "" {
-		cmd = exec.Command(executor, "run", cliPath, query, "--json")
-	} else {
-		cmd = exec.Command(cliPath, query, "--json")
-	}
-	
-	output, err := cmd.Output()
+// fetchSearchResults runs a search with the given CLI arguments and parses the JSON results
+func fetchSearchResults(ctx context.Context, args ...string) ([]searchResult, error) {
+	output, err := runSmartgrep(ctx, false, append(args, "--json")...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to run smartgrep: %w", err)
 	}
 	
-	// Parse JSON output
-	var tsResults []struct {
-		Info struct {
-			Term     string `json:"term"`
-			Type     string `json:"type"`
-			Location struct {
-				File   string `json:"file"`
-				Line   int    `json:"line"`
-				Column int    `json:"column"`
-			} `json:"location"`
-			Context         string   `json:"context"`
-			SurroundingLines []string `json:"surroundingLines"`
-			RelatedTerms    []string `json:"relatedTerms"`
-			Language        string   `json:"language"`
-			Metadata        map[string]interface{} `json:"metadata,omitempty"`
-		} `json:"info"`
-		RelevanceScore float64 `json:"relevanceScore"`
-		UsageCount     int     `json:"usageCount,omitempty"`
-		SampleUsages   []struct {
-			TargetTerm     string `json:"targetTerm"`
-			ReferenceType  string `json:"referenceType"`
-			FromLocation   struct {
-				File   string `json:"file"`
-				Line   int    `json:"line"`
-				Column int    `json:"column"`
-			} `json:"fromLocation"`
-			Context string `json:"context"`
-		} `json:"sampleUsages,omitempty"`
-	}
-	
-	// Remove ANSI codes and extract JSON
-	outputStr := string(output)
-	lines := strings.Split(outputStr, "\n")
-	var jsonStart int
-	for i, line := range lines {
-		if strings.HasPrefix(strings.TrimSpace(line), "[") {
-			jsonStart = i
-			break
-		}
-	}
-	
-	if jsonStart == 0 {
-		return nil, fmt.Errorf("no JSON output found")
-	}
-	
-	jsonData := strings.Join(lines[jsonStart:], "\n")
-	if err := json.Unmarshal([]byte(jsonData), &tsResults); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON: %w", err)
-	}
-	
-	// Convert to our internal format
-	var results []searchResult
-	for _, tr := range tsResults {
-		result := searchResult{
-			term:         tr.Info.Term,
-			typ:          tr.Info.Type,
-			location:     location{
-				file:   tr.Info.Location.File,
-				line:   tr.Info.Location.Line,
-				column: tr.Info.Location.Column,
-			},
-			context:      tr.Info.Context,
-			surrounding:  tr.Info.SurroundingLines,
-			related:      tr.Info.RelatedTerms,
-			language:     tr.Info.Language,
-			relevance:    tr.RelevanceScore,
-			usageCount:   tr.UsageCount,
-			metadata:     tr.Info.Metadata,
-		}
-		
-		// Convert references
-		for _, usage := range tr.SampleUsages {
-			result.references = append(result.references, reference{
-				typ: usage.ReferenceType,
-				from: location{
-					file:   usage.FromLocation.File,
-					line:   usage.FromLocation.Line,
-					column: usage.FromLocation.Column,
-				},
-				context: usage.Context,
-			})
-		}
-		
-		results = append(results, result)
+	results, err := ParseResults(bytes.NewReader(output))
+	if err != nil {
+		config.LogParseError("search", err)
+		return nil, err
 	}
-	
 	return results, nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,52 @@
+package smartgrep
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestEmptyInputStaysInInput(t *testing.T) {
+	t.Setenv("CURATOR_DATA_DIR", t.TempDir())
+	for _, mode := range []string{"pattern", "refs", "claude"} {
+		m := initialModel()
+		m.mode = mode
+		m.searchInput.Focus()
+		m.searchInput.SetValue("   ")
+	
+		updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		m = updated.(model)
+		if cmd != nil || m.running || m.err != nil || m.mode != mode {
+			t.Fatalf("%s: empty input was submitted (running=%v, err=%v, mode=%q)", mode, m.running, m.err, m.mode)
+		}
+		if view := m.View(); !strings.Contains(view, emptyInputError(mode)) {
+			t.Errorf("%s: no inline message:\n%s", mode, view)
+		}
+	
+		// Typing clears the message
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+		m = updated.(model)
+		if m.inputErr != "" || m.searchInput.Value() != "   a" {
+			t.Errorf("%s: after typing inputErr = %q, value = %q", mode, m.inputErr, m.searchInput.Value())
+		}
+	}
+}
+
+func TestBatchReportReceivesResultViewMessages(t *testing.T) {
+	t.Setenv("CURATOR_DATA_DIR", t.TempDir())
+	loc := location{file: "auth.ts", line: 3, column: 1}
+	m := initialModel()
+	updated, _ := m.Update(batchResult{topic: "auth", searches: 1, results: []searchResult{{term: "Auth", location: loc}}})
+	m = updated.(model)
+	if m.mode != "batch" {
+		t.Fatalf("mode = %q, want batch", m.mode)
+	}
+	
+	refs := []reference{{typ: "call", from: location{file: "b.ts", line: 9}, context: "new Auth()"}}
+	updated, _ = m.Update(termRefsMsg{term: "Auth", location: loc, refs: refs})
+	m = updated.(model)
+	if got := m.batch.results[0].references; len(got) != 1 {
+		t.Errorf("references not applied to the batch report: %+v", m.batch.results[0])
+	}
+}
@@ -0,0 +1,83 @@
+package smartgrep
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/style"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxTypeToggles is how many result types get a number key
+const maxTypeToggles = 9
+
+// resultTypes returns the distinct result types, alphabetically so each
+// keeps its number key as more results stream in
+func resultTypes(results []searchResult) []string {
+	seen := make(map[string]bool)
+	var types []string
+	for _, r := range results {
+		if !seen[r.typ] {
+			seen[r.typ] = true
+			types = append(types, r.typ)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+// toggleType shows or hides the results of the type bound to number key n
+// (1-based). 0 shows every type again.
+func (m *resultViewModel) toggleType(n int) {
+	if n == 0 {
+		m.hiddenTypes = nil
+	} else {
+		types := resultTypes(m.results)
+		if n > len(types) || n > maxTypeToggles {
+			return
+		}
+		if m.hiddenTypes == nil {
+			m.hiddenTypes = make(map[string]bool)
+		}
+		typ := types[n-1]
+		if m.hiddenTypes[typ] {
+			delete(m.hiddenTypes, typ)
+		} else {
+			m.hiddenTypes[typ] = true
+		}
+	}
+	
+	m.pageStart = 0
+	m.refreshRows()
+	m.table.SetCursor(0)
+	m.syncSelected()
+	m.refreshView()
+}
+
+// typeToggleView renders the type toggle bar shown under the tabs, dimming
+// hidden types. It is empty when all results share one type.
+func (m resultViewModel) typeToggleView() string {
+	types := resultTypes(m.results)
+	if len(types) < 2 {
+		return ""
+	}
+	
+	var parts []string
+	for i, typ := range types {
+		if i >= maxTypeToggles {
+			break
+		}
+		label := fmt.Sprintf("%d %s %s", i+1, getTypeIcon(typ), typ)
+		if m.hiddenTypes[typ] {
+			parts = append(parts, lipgloss.NewStyle().Foreground(style.Colors().Border).Strikethrough(true).Render(label))
+		} else {
+			parts = append(parts, lipgloss.NewStyle().Foreground(style.Colors().Title).Render(label))
+		}
+	}
+	bar := strings.Join(parts, metaStyle.Render(" • "))
+	if len(m.hiddenTypes) > 0 {
+		bar += metaStyle.Render(" • 0 show all")
+	}
+	return lipgloss.PlaceHorizontal(m.width, lipgloss.Center, bar) + "\n"
+}
@@ -0,0 +1,143 @@
+package smartgrep
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/style"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Watch mode polls the project for changes rather than subscribing to file
+// system events, so it needs no platform-specific watcher
+const (
+	watchPollInterval = time.Second     // How often the project is checked
+	watchMinRefresh   = 3 * time.Second // Shortest time between two re-runs
+	maxWatchedFiles   = 20000           // Files looked at per check
+)
+
+// watchSkipDirs are directories whose changes never affect search results
+var watchSkipDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"dist":         true,
+	"build":        true,
+	"coverage":     true,
+	"__pycache__":  true,
+	"venv":         true,
+}
+
+// projectFingerprint hashes the path, size and modification time of the
+// files under root, skipping hidden and generated directories
+func projectFingerprint(root string) uint64 {
+	h := fnv.New64a()
+	files := 0
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != root && (strings.HasPrefix(d.Name(), ".") || watchSkipDirs[d.Name()]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if files++; files > maxWatchedFiles {
+			return filepath.SkipAll
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00%d\n", path, info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	return h.Sum64()
+}
+
+// watchTickMsg carries the project fingerprint taken by a watch check. gen
+// tells checks started before watching was last toggled apart.
+type watchTickMsg struct {
+	gen         int
+	fingerprint uint64
+}
+
+// watchProject checks the project for changes after the poll interval
+func watchProject(gen int) tea.Cmd {
+	return tea.Tick(watchPollInterval, func(time.Time) tea.Msg {
+		return watchTickMsg{gen: gen, fingerprint: projectFingerprint(historyProject())}
+	})
+}
+
+// toggleWatch starts or stops re-running the search when files change
+func (m *resultViewModel) toggleWatch() tea.Cmd {
+	if m.searchArgs == nil {
+		m.notice = "⚠️  Only a single search can be watched"
+		return nil
+	}
+	m.watching = !m.watching
+	m.watchPending = false
+	m.watchGen++
+	if !m.watching {
+		return nil
+	}
+	m.watchSeen = 0
+	return watchProject(m.watchGen)
+}
+
+// updateWatch handles a watch check. A change is only acted on once the
+// project has stopped changing for a poll interval, no search is running and
+// the last re-run was long enough ago.
+func (m *resultViewModel) updateWatch(msg watchTickMsg) tea.Cmd {
+	if !m.watching || msg.gen != m.watchGen {
+		return nil
+	}
+	
+	switch {
+	case m.watchSeen == 0:
+		// First check: record the starting state
+	case msg.fingerprint != m.watchSeen:
+		m.watchPending = true
+		m.watchSeen = msg.fingerprint
+		return watchProject(m.watchGen)
+	}
+	m.watchSeen = msg.fingerprint
+	
+	if m.watchPending && !m.loading && time.Since(m.watchRefreshed) >= watchMinRefresh {
+		m.watchPending = false
+		m.watchRefreshed = time.Now()
+		m.watchReplace = true
+		m.loading = true
+		m.loadErr = nil
+		m.capped = false
+		return tea.Batch(watchProject(m.watchGen), style.SpinnerTick(m.spinner), searchResultsCmd(m.ctx, m.searchArgs))
+	}
+	return watchProject(m.watchGen)
+}
+
+// watchView describes the watch state for the footer
+func (m resultViewModel) watchView() string {
+	if !m.watching {
+		return ""
+	}
+	label := "👁  Watching for changes"
+	if m.watchPending {
+		label += " • change detected"
+	}
+	if !m.watchRefreshed.IsZero() {
+		label += " • refreshed " + m.watchRefreshed.Format("15:04:05")
+	}
+	return label
+}
+
+// applyWatchResults replaces the results shown with the first batch of a
+// watch re-run, so the list doesn't empty while the search runs
+func (m *resultViewModel) applyWatchResults(results []searchResult) {
+	m.watchReplace = false
+	m.setResults(results)
+	m.refreshView()
+}
@@ -0,0 +1,75 @@
+package smartgrep
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestProjectFingerprint(t *testing.T) {
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "node_modules", "dep"), 0755)
+	os.MkdirAll(filepath.Join(root, ".git"), 0755)
+	os.WriteFile(filepath.Join(root, "auth.ts"), []byte("export {}"), 0644)
+	
+	before := projectFingerprint(root)
+	os.WriteFile(filepath.Join(root, "node_modules", "dep", "index.js"), []byte("x"), 0644)
+	os.WriteFile(filepath.Join(root, ".git", "index"), []byte("x"), 0644)
+	if got := projectFingerprint(root); got != before {
+		t.Error("changes in skipped directories should not change the fingerprint")
+	}
+	
+	os.WriteFile(filepath.Join(root, "auth.ts"), []byte("export const x = 1"), 0644)
+	if got := projectFingerprint(root); got == before {
+		t.Error("editing a source file should change the fingerprint")
+	}
+}
+
+func TestUpdateWatchDebounces(t *testing.T) {
+	m := newResultViewModel()
+	m.searchArgs = []string{"auth"}
+	m.setResults([]searchResult{{term: "old"}})
+	m.toggleWatch()
+	gen := m.watchGen
+	
+	m.updateWatch(watchTickMsg{gen: gen, fingerprint: 1})
+	m.updateWatch(watchTickMsg{gen: gen, fingerprint: 2})
+	if !m.watchPending || m.loading {
+		t.Fatalf("a change should wait for the project to settle (pending %v, loading %v)", m.watchPending, m.loading)
+	}
+	
+	m.updateWatch(watchTickMsg{gen: gen, fingerprint: 2})
+	if m.watchPending || !m.loading || !m.watchReplace {
+		t.Fatalf("settled change should re-run the search (pending %v, loading %v)", m.watchPending, m.loading)
+	}
+	
+	// Results stay until the re-run delivers its first batch
+	if len(m.results) != 1 {
+		t.Errorf("results cleared before the re-run returned: %+v", m.results)
+	}
+	updated, _ := m.Update(resultsBatchMsg{results: []searchResult{{term: "new"}, {term: "newer"}}})
+	rv := updated.(resultViewModel)
+	if len(rv.results) != 2 || rv.results[0].term != "new" {
+		t.Errorf("results = %+v, want the re-run's", rv.results)
+	}
+	
+	// Another change within the minimum refresh interval waits
+	rv.loading = false
+	rv.updateWatch(watchTickMsg{gen: gen, fingerprint: 3})
+	rv.updateWatch(watchTickMsg{gen: gen, fingerprint: 3})
+	if rv.loading || !rv.watchPending {
+		t.Error("re-runs should be rate limited")
+	}
+	rv.watchRefreshed = time.Now().Add(-watchMinRefresh)
+	rv.updateWatch(watchTickMsg{gen: gen, fingerprint: 3})
+	if !rv.loading {
+		t.Error("pending change should re-run once the interval has passed")
+	}
+	
+	// Checks from before watching was toggled are dropped
+	rv.toggleWatch()
+	if cmd := rv.updateWatch(watchTickMsg{gen: gen, fingerprint: 4}); cmd != nil || rv.watchView() != "" {
+		t.Error("stopped watch should not poll")
+	}
+}
@@ -0,0 +1,33 @@
+package style
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	checkPassStyle   = lipgloss.NewStyle().Foreground(Colors().Added).Bold(true)
+	checkFailStyle   = lipgloss.NewStyle().Foreground(Colors().Deleted).Bold(true)
+	checkDetailStyle = lipgloss.NewStyle().Foreground(Colors().Meta)
+)
+
+// Checklist renders doctor check results one per line with pass/fail marks
+func Checklist(results []config.CheckResult) string {
+	width := 0
+	for _, r := range results {
+		width = max(width, len(r.Name))
+	}
+
+	var b strings.Builder
+	for _, r := range results {
+		mark := checkPassStyle.Render("✓")
+		if !r.OK {
+			mark = checkFailStyle.Render("✗")
+		}
+		fmt.Fprintf(&b, "%s %-*s  %s\n", mark, width, r.Name, checkDetailStyle.Render(r.Detail))
+	}
+	return b.String()
+}
@@ -0,0 +1,220 @@
+package style
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-isatty"
+	"github.com/muesli/termenv"
+)
+
+var (
+	profile     termenv.Profile
+	profileOnce sync.Once
+)
+
+// Init detects the terminal's color capability and configures lipgloss to
+// match. Call it once at startup, before any styles are rendered.
+func Init() {
+	lipgloss.SetColorProfile(Profile())
+}
+
+// Profile returns the detected color profile: TrueColor, ANSI256, ANSI, or
+// Ascii (no color) for dumb terminals, non-TTY output such as CI logs, and
+// when NO_COLOR is set
+func Profile() termenv.Profile {
+	profileOnce.Do(func() {
+		profile = termenv.NewOutput(os.Stdout).EnvColorProfile()
+	})
+	return profile
+}
+
+// HasColor reports whether the terminal can render colors at all
+func HasColor() bool {
+	return Profile() != termenv.Ascii
+}
+
+// IsTerminal reports whether stdout is a terminal rather than a file or pipe
+func IsTerminal() bool {
+	return isatty.IsTerminal(os.Stdout.Fd()) || isatty.IsCygwinTerminal(os.Stdout.Fd())
+}
+
+// OpenLog appends the debug log to the --log file at path, prefixing lines
+// with tool. The file stays open until the process exits.
+func OpenLog(path, tool string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	config.SetLogOutput(f, tool)
+	return nil
+}
+
+// ProgramOptions returns the Bubble Tea options for a full-screen TUI. The
+// alternate screen is only used on a terminal, so output redirected to a
+// file or CI log keeps the final frame instead of screen switching codes.
+// With a log file open, key presses are logged too.
+func ProgramOptions() []tea.ProgramOption {
+	var opts []tea.ProgramOption
+	if config.Logging() {
+		opts = append(opts, tea.WithFilter(logKeys))
+	}
+	if IsTerminal() {
+		opts = append(opts, tea.WithAltScreen())
+	}
+	return opts
+}
+
+// Run runs model as a full-screen TUI with ProgramOptions, holding --debug
+// output off stderr until it exits
+func Run(model tea.Model) (tea.Model, error) {
+	config.SetTUIRunning(true)
+	defer config.SetTUIRunning(false)
+	return tea.NewProgram(model, ProgramOptions()...).Run()
+}
+
+// logKeys records every key press in the log file on its way to the model
+func logKeys(_ tea.Model, msg tea.Msg) tea.Msg {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		config.Logf("event=key key=%q", key.String())
+	}
+	return msg
+}
+
+// DefaultWordWrap is the markdown wrap width when none is configured
+const DefaultWordWrap = 80
+
+// WordWrap returns the configured markdown wrap width
+func WordWrap() int {
+	if width := config.GetWordWrap(); width > 0 {
+		return width
+	}
+	return DefaultWordWrap
+}
+
+// MinWordWrap keeps markdown readable on very narrow terminals
+const MinWordWrap = 40
+
+// MarkdownWidth returns the wrap width for markdown shown in a pane that is
+// available columns wide. A configured wordWrap acts as an upper bound.
+func MarkdownWidth(available int) int {
+	width := available
+	if configured := config.GetWordWrap(); configured > 0 && width > configured {
+		width = configured
+	}
+	if width < MinWordWrap {
+		width = MinWordWrap
+	}
+	return width
+}
+
+// GlamourOptions returns markdown renderer options that match the terminal
+func GlamourOptions(wordWrap int) []glamour.TermRendererOption {
+	opts := []glamour.TermRendererOption{
+		glamour.WithWordWrap(wordWrap),
+		glamour.WithColorProfile(Profile()),
+	}
+	switch {
+	case !HasColor():
+		opts = append(opts, glamour.WithStandardStyle("notty"))
+	case themeMode != "auto":
+		opts = append(opts, glamour.WithStandardStyle(themeMode))
+	default:
+		opts = append(opts, glamour.WithAutoStyle())
+	}
+	return opts
+}
+
+var (
+	spinnerName   = "dot"
+	reducedMotion = envEnabled("REDUCE_MOTION")
+)
+
+// spinners maps --spinner names to bubbles spinner styles
+var spinners = map[string]spinner.Spinner{
+	"dot":   spinner.Dot,
+	"line":  spinner.Line,
+	"pulse": spinner.Pulse,
+}
+
+// SetSpinner selects the loading spinner: dot, line, pulse, or none
+func SetSpinner(name string) error {
+	if _, ok := spinners[name]; !ok && name != "none" {
+		return fmt.Errorf("unknown spinner %q (expected dot, line, pulse, or none)", name)
+	}
+	spinnerName = name
+	return nil
+}
+
+// SetReducedMotion replaces animated spinners with a static indicator
+func SetReducedMotion(enabled bool) {
+	reducedMotion = reducedMotion || enabled
+}
+
+// Animated reports whether loading states should animate
+func Animated() bool {
+	return !reducedMotion && spinnerName != "none"
+}
+
+// NewSpinner returns a spinner configured from the user's preferences
+func NewSpinner(color lipgloss.TerminalColor) spinner.Model {
+	sp := spinner.New()
+	if s, ok := spinners[spinnerName]; ok {
+		sp.Spinner = s
+	}
+	sp.Style = lipgloss.NewStyle().Foreground(color)
+	return sp
+}
+
+// Loading renders a loading indicator with a label, static when motion is reduced
+func Loading(sp spinner.Model, label string) string {
+	if !Animated() {
+		return "⋯ " + label
+	}
+	return sp.View() + " " + label
+}
+
+// SpinnerTick starts the spinner animation, or does nothing when motion is reduced
+func SpinnerTick(sp spinner.Model) tea.Cmd {
+	if !Animated() {
+		return nil
+	}
+	return sp.Tick
+}
+
+func envEnabled(name string) bool {
+	switch strings.ToLower(os.Getenv(name)) {
+	case "", "0", "false", "no":
+		return false
+	}
+	return true
+}
+
+// ansiPattern matches CSI escape sequences such as colors and cursor movement
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+
+// StripANSI removes terminal escape sequences from s
+func StripANSI(s string) string {
+	if !strings.Contains(s, "\x1b") {
+		return s
+	}
+	return ansiPattern.ReplaceAllString(s, "")
+}
+
+// StripANSIBytes is StripANSI for byte slices. Input without escape
+// sequences is returned as is, without allocating.
+func StripANSIBytes(b []byte) []byte {
+	if bytes.IndexByte(b, 0x1b) < 0 {
+		return b
+	}
+	return ansiPattern.ReplaceAll(b, nil)
+}
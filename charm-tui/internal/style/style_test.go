@@ -0,0 +1,80 @@
+package style
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+)
+
+func TestMarkdownWidth(t *testing.T) {
+	// Keep a user's own config file out of the test
+	t.Setenv("CURATOR_CONFIG", filepath.Join(t.TempDir(), "config.yaml"))
+	t.Setenv("CURATOR_WORD_WRAP", "")
+	
+	tests := []struct {
+		available int
+		want      int
+	}{
+		{available: 120, want: 120},
+		{available: 76, want: 76},
+		{available: 40, want: 40},
+		{available: 20, want: MinWordWrap},
+		{available: -4, want: MinWordWrap},
+	}
+	
+	for _, tt := range tests {
+		if got := MarkdownWidth(tt.available); got != tt.want {
+			t.Errorf("MarkdownWidth(%d) = %d, want %d", tt.available, got, tt.want)
+		}
+	}
+}
+
+func TestChecklist(t *testing.T) {
+	out := StripANSI(Checklist([]config.CheckResult{
+		{Name: "Executor", OK: true, Detail: "/usr/bin/bun"},
+		{Name: "Index", OK: false, Detail: "not found"},
+	}))
+	want := "✓ Executor  /usr/bin/bun\n✗ Index     not found\n"
+	if out != want {
+		t.Errorf("Checklist() = %q, want %q", out, want)
+	}
+}
+
+func TestProgramOptionsWithoutTerminal(t *testing.T) {
+	// go test captures stdout, so it is never a terminal here
+	if IsTerminal() {
+		t.Skip("stdout is a terminal")
+	}
+	if opts := ProgramOptions(); len(opts) != 0 {
+		t.Errorf("redirected output should not use the alternate screen, got %d options", len(opts))
+	}
+}
+
+func TestOpenLog(t *testing.T) {
+	t.Cleanup(func() { config.SetLogOutput(nil, "") })
+	
+	path := filepath.Join(t.TempDir(), "tui.log")
+	if err := os.WriteFile(path, []byte("earlier run\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := OpenLog(path, "monitor "); err != nil {
+		t.Fatalf("OpenLog: %v", err)
+	}
+	config.Logf("event=key key=%q", "q")
+	
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"earlier run\n", "monitor ", "event=start", `event=key key="q"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("log missing %q:\n%s", want, data)
+		}
+	}
+	if len(ProgramOptions()) == 0 {
+		t.Error("key presses are not logged with a log file open")
+	}
+}
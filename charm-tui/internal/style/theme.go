@@ -0,0 +1,151 @@
+package style
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/RLabs-Inc/codebase-curator/charm-tui/internal/config"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme names the colors the TUIs draw with by the role they play. Each
+// color has a value for light and for dark terminal backgrounds.
+type Theme struct {
+	Title     lipgloss.AdaptiveColor // Headings, focused borders, the spinner
+	Accent    lipgloss.AdaptiveColor // Secondary headings and panels
+	Added     lipgloss.AdaptiveColor // Successes, new items, counts and bars
+	Modified  lipgloss.AdaptiveColor // Changes and warnings
+	Deleted   lipgloss.AdaptiveColor // Removals and errors
+	Meta      lipgloss.AdaptiveColor // Secondary text such as paths and hints
+	Border    lipgloss.AdaptiveColor // Unfocused borders and disabled items
+	Selection lipgloss.AdaptiveColor // Text of the selected table row
+	Selected  lipgloss.AdaptiveColor // Background of the selected table row
+	Surface   lipgloss.AdaptiveColor // Background of code and highlighted lines
+	Text      lipgloss.AdaptiveColor // Text drawn on a Surface background
+	Match     lipgloss.AdaptiveColor // Background of search matches
+}
+
+// DefaultTheme is used for every role the config leaves unset
+var DefaultTheme = Theme{
+	Title:     lipgloss.AdaptiveColor{Light: "162", Dark: "212"},
+	Accent:    lipgloss.AdaptiveColor{Light: "25", Dark: "33"},
+	Added:     lipgloss.AdaptiveColor{Light: "28", Dark: "120"},
+	Modified:  lipgloss.AdaptiveColor{Light: "130", Dark: "220"},
+	Deleted:   lipgloss.AdaptiveColor{Light: "160", Dark: "196"},
+	Meta:      lipgloss.AdaptiveColor{Light: "242", Dark: "244"},
+	Border:    lipgloss.AdaptiveColor{Light: "250", Dark: "240"},
+	Selection: lipgloss.AdaptiveColor{Light: "231", Dark: "229"},
+	Selected:  lipgloss.AdaptiveColor{Light: "62", Dark: "57"},
+	Surface:   lipgloss.AdaptiveColor{Light: "254", Dark: "236"},
+	Text:      lipgloss.AdaptiveColor{Light: "235", Dark: "252"},
+	Match:     lipgloss.AdaptiveColor{Light: "229", Dark: "58"},
+}
+
+// roles maps the names used under theme: in the config file to their colors
+func (t *Theme) roles() map[string]*lipgloss.AdaptiveColor {
+	return map[string]*lipgloss.AdaptiveColor{
+		"title":     &t.Title,
+		"accent":    &t.Accent,
+		"added":     &t.Added,
+		"modified":  &t.Modified,
+		"deleted":   &t.Deleted,
+		"meta":      &t.Meta,
+		"border":    &t.Border,
+		"selection": &t.Selection,
+		"selected":  &t.Selected,
+		"surface":   &t.Surface,
+		"text":      &t.Text,
+		"match":     &t.Match,
+	}
+}
+
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// validColor reports whether s is an ANSI color number or a hex color
+func validColor(s string) bool {
+	if n, err := strconv.Atoi(s); err == nil {
+		return n >= 0 && n <= 255
+	}
+	return hexColorPattern.MatchString(s)
+}
+
+// NewTheme overlays configured colors on the default theme, using each for
+// both light and dark backgrounds. Unknown roles and invalid colors are
+// reported and otherwise ignored.
+func NewTheme(colors map[string]string) (Theme, []error) {
+	theme := DefaultTheme
+	roles := theme.roles()
+	
+	names := make([]string, 0, len(colors))
+	for name := range colors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	
+	var errs []error
+	for _, name := range names {
+		color := colors[name]
+		role, ok := roles[name]
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("unknown theme color %q", name))
+		case !validColor(color):
+			errs = append(errs, fmt.Errorf("invalid theme color %s: %q (expected 0-255 or #rrggbb)", name, color))
+		default:
+			*role = lipgloss.AdaptiveColor{Light: color, Dark: color}
+		}
+	}
+	return theme, errs
+}
+
+var (
+	colors     Theme
+	colorsOnce sync.Once
+)
+
+// Colors returns the theme from the config file, loaded on first use
+func Colors() Theme {
+	colorsOnce.Do(func() {
+		var errs []error
+		colors, errs = NewTheme(config.GetTheme())
+		for _, err := range errs {
+			fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+		}
+	})
+	return colors
+}
+
+// themeMode is the background the colors are chosen for: light, dark, or
+// auto to ask the terminal
+var themeMode = "auto"
+
+// SetTheme selects the light or dark variant of the theme's colors. With
+// auto, the terminal's background is detected when colors are first used.
+func SetTheme(mode string) error {
+	switch mode {
+	case "light":
+		lipgloss.SetHasDarkBackground(false)
+	case "dark":
+		lipgloss.SetHasDarkBackground(true)
+	case "auto":
+	default:
+		return fmt.Errorf("unknown theme %q (expected light, dark, or auto)", mode)
+	}
+	themeMode = mode
+	return nil
+}
+
+// DarkBackground reports whether colors are chosen for a dark background
+func DarkBackground() bool {
+	switch themeMode {
+	case "light":
+		return false
+	case "dark":
+		return true
+	}
+	return lipgloss.HasDarkBackground()
+}
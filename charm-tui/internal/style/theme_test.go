@@ -0,0 +1,53 @@
+package style
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestNewTheme(t *testing.T) {
+	theme, errs := NewTheme(map[string]string{
+		"title":   "99",
+		"deleted": "#ff5f5f",
+		"meta":    "grey",
+		"sparkle": "1",
+	})
+	if theme.Title != (lipgloss.AdaptiveColor{Light: "99", Dark: "99"}) || theme.Deleted.Dark != "#ff5f5f" {
+		t.Errorf("configured colors not applied: %+v", theme)
+	}
+	if theme.Meta != DefaultTheme.Meta {
+		t.Errorf("invalid color should keep the default, got Meta = %v", theme.Meta)
+	}
+	if theme.Accent != DefaultTheme.Accent {
+		t.Errorf("unset roles should keep the default, got Accent = %v", theme.Accent)
+	}
+	if len(errs) != 2 {
+		t.Errorf("expected errors for the invalid color and unknown role, got %v", errs)
+	}
+}
+
+func TestValidColor(t *testing.T) {
+	for color, want := range map[string]bool{
+		"0": true, "255": true, "256": false, "-1": false,
+		"#fff": true, "#00afff": true, "#00afffff": false, "blue": false, "": false,
+	} {
+		if got := validColor(color); got != want {
+			t.Errorf("validColor(%q) = %v, want %v", color, got, want)
+		}
+	}
+}
+
+func TestSetTheme(t *testing.T) {
+	defer SetTheme("auto")
+	
+	if err := SetTheme("light"); err != nil || DarkBackground() {
+		t.Errorf("light theme: err = %v, DarkBackground() = %v", err, DarkBackground())
+	}
+	if err := SetTheme("dark"); err != nil || !DarkBackground() {
+		t.Errorf("dark theme: err = %v, DarkBackground() = %v", err, DarkBackground())
+	}
+	if err := SetTheme("solarized"); err == nil {
+		t.Error("expected an error for an unknown theme")
+	}
+}
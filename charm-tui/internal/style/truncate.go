@@ -0,0 +1,62 @@
+package style
+
+import (
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// pathSeparators are both split on whatever the host OS, so Windows paths
+// reported by the CLI are abbreviated everywhere. filepath only knows the
+// host's separator.
+const pathSeparators = `/\`
+
+// ellipsis marks where a truncated path was cut
+const ellipsis = "..."
+
+// TruncatePath shortens a path to at most maxLen display columns, keeping the
+// file name visible. Widths are measured in terminal cells so wide (CJK, emoji)
+// runes are never split or miscounted.
+func TruncatePath(path string, maxLen int) string {
+	if runewidth.StringWidth(path) <= maxLen {
+		return path
+	}
+	if maxLen <= len(ellipsis) {
+		return truncateLeft(path, maxLen)
+	}
+	
+	first := strings.IndexAny(path, pathSeparators)
+	last := strings.LastIndexAny(path, pathSeparators)
+	if first == last {
+		return ellipsis + truncateLeft(path, maxLen-len(ellipsis))
+	}
+	
+	// Show first and last parts, joined with the path's own separator
+	sep := path[last : last+1]
+	name := path[last+1:]
+	result := path[:first] + sep + ellipsis + sep + name
+	if runewidth.StringWidth(result) > maxLen {
+		return ellipsis + truncateLeft(name, maxLen-len(ellipsis))
+	}
+	return result
+}
+
+// truncateLeft keeps the rightmost runes of s that fit in width display columns
+func truncateLeft(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	
+	runes := []rune(s)
+	used := 0
+	start := len(runes)
+	for start > 0 {
+		w := runewidth.RuneWidth(runes[start-1])
+		if used+w > width {
+			break
+		}
+		used += w
+		start--
+	}
+	return string(runes[start:])
+}
@@ -0,0 +1,84 @@
+package style
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+func TestTruncatePathWideRunes(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		maxLen int
+	}{
+		{"ascii", "src/tools/smartgrep/displays/compactSummary.ts", 20},
+		{"cjk file", "src/组件/用户界面/登录页面组件.ts", 12},
+		{"cjk dirs", "项目/源代码/工具/搜索/索引.go", 15},
+		{"emoji", "docs/🚀launch/🎉party/notes✨.md", 10},
+		{"short two part", "目录/非常长的文件名称测试用例.ts", 9},
+		{"odd width boundary", "a/b/漢字漢字漢字.go", 8},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TruncatePath(tt.path, tt.maxLen)
+			if !utf8.ValidString(got) {
+				t.Fatalf("TruncatePath(%q, %d) = %q is not valid UTF-8", tt.path, tt.maxLen, got)
+			}
+			if w := runewidth.StringWidth(got); w > tt.maxLen {
+				t.Errorf("TruncatePath(%q, %d) = %q has width %d", tt.path, tt.maxLen, got, w)
+			}
+		})
+	}
+}
+
+func TestTruncatePathKeepsShortPaths(t *testing.T) {
+	path := "src/组件.ts"
+	if got := TruncatePath(path, 40); got != path {
+		t.Errorf("TruncatePath(%q, 40) = %q, want unchanged", path, got)
+	}
+}
+
+func TestTruncatePathKeepsFileName(t *testing.T) {
+	got := TruncatePath("src/a/b/c/d/ファイル.ts", 18)
+	if !strings.HasSuffix(got, "ファイル.ts") {
+		t.Errorf("expected file name to be kept, got %q", got)
+	}
+}
+
+func TestTruncatePathSeparatorsAndEdges(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		maxLen int
+		want   string
+	}{
+		{"unix", "src/tools/smartgrep/cli.ts", 16, "src/.../cli.ts"},
+		{"absolute unix", "/home/me/project/main.go", 12, "/.../main.go"},
+		{"windows", `C:\Users\me\project\main.go`, 16, `C:\...\main.go`},
+		{"windows relative", `src\tools\smartgrep\cli.ts`, 16, `src\...\cli.ts`},
+		{"mixed separators", `C:\work/src/app.go`, 14, `C:/.../app.go`},
+		{"windows file name too long", `C:\a\b\averyveryverylongname.go`, 12, "...ngname.go"},
+		{"single separator", `dir\longfilename.go`, 10, "...name.go"},
+		{"no separator", "longfilename.go", 8, "...me.go"},
+		{"fits exactly", `a\b.go`, 6, `a\b.go`},
+		{"one over", "abcdef", 5, "...ef"},
+		{"room for ellipsis only", "abcdef", 3, "def"},
+		{"narrower than ellipsis", `C:\a\b.go`, 2, "go"},
+		{"one column", "abcdef", 1, "f"},
+		{"zero", "abcdef", 0, ""},
+		{"negative", "abcdef", -4, ""},
+		{"empty", "", 0, ""},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TruncatePath(tt.path, tt.maxLen); got != tt.want {
+				t.Errorf("TruncatePath(%q, %d) = %q, want %q", tt.path, tt.maxLen, got, tt.want)
+			}
+		})
+	}
+}
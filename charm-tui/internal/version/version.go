@@ -0,0 +1,53 @@
+// Package version reports the build version of the TUIs alongside the
+// version of the TypeScript CLI they drive.
+package version
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Version is the TUI build version, injected at build time with
+// -ldflags "-X github.com/RLabs-Inc/codebase-curator/charm-tui/internal/version.Version=..."
+var Version = "dev"
+
+// notFound is reported when the TypeScript CLI can't be run at all
+const notFound = "TS CLI not found"
+
+// TSVersion runs cmd, the TypeScript CLI invoked with --version, and returns
+// the version it prints
+func TSVersion(cmd *exec.Cmd) string {
+	// A missing .ts entry point only shows up as a failing executor otherwise
+	for _, arg := range cmd.Args[1:] {
+		if strings.HasSuffix(arg, ".ts") {
+			if _, err := os.Stat(arg); err != nil {
+				return notFound
+			}
+		}
+	}
+	
+	output, err := cmd.Output()
+	if errors.Is(err, exec.ErrNotFound) || errors.Is(err, fs.ErrNotExist) {
+		return notFound
+	}
+	if err != nil {
+		return fmt.Sprintf("unknown (%v)", err)
+	}
+	
+	version, _, _ := strings.Cut(strings.TrimSpace(string(output)), "\n")
+	if version == "" {
+		return "unknown"
+	}
+	return version
+}
+
+// Print writes the TUI and TypeScript CLI versions for tool
+func Print(w io.Writer, tool string, cmd *exec.Cmd) {
+	fmt.Fprintf(w, "%s TUI: %s\n", tool, Version)
+	fmt.Fprintf(w, "%s CLI: %s\n", tool, TSVersion(cmd))
+}
@@ -0,0 +1,42 @@
+package version
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTSVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  *exec.Cmd
+		want string
+	}{
+		{"prints version", exec.Command("echo", "1.0.0"), "1.0.0"},
+		{"first line only", exec.Command("printf", "1.2.0\nextra\n"), "1.2.0"},
+		{"missing binary", exec.Command("curator-cli-that-does-not-exist", "--version"), notFound},
+		{"missing entry point", exec.Command("bun", "run", filepath.Join(t.TempDir(), "cli.ts"), "--version"), notFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TSVersion(tt.cmd); got != tt.want {
+				t.Errorf("TSVersion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTSVersionFailingCLI(t *testing.T) {
+	if got := TSVersion(exec.Command("false")); !strings.HasPrefix(got, "unknown") {
+		t.Errorf("TSVersion() = %q", got)
+	}
+}
+
+func TestPrint(t *testing.T) {
+	var b strings.Builder
+	Print(&b, "smartgrep", exec.Command("echo", "1.0.0"))
+	if want := "smartgrep TUI: dev\nsmartgrep CLI: 1.0.0\n"; b.String() != want {
+		t.Errorf("Print() = %q, want %q", b.String(), want)
+	}
+}
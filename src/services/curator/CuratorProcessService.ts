@@ -258,6 +258,11 @@ ${COMMON_CURATOR_INSTRUCTIONS}
       this.getClaudeToClaudeSystemPrompt()
     )
 
+    // Model chosen with the Go CLI's --model flag
+    if (process.env.CURATOR_MODEL) {
+      args.push('--model', process.env.CURATOR_MODEL)
+    }
+
     return args
   }
 
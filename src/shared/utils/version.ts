@@ -0,0 +1,15 @@
+/**
+ * Package version shared by the CLIs' --version flag
+ */
+
+import { readFileSync } from 'fs'
+import { join } from 'path'
+
+export function getPackageVersion(): string {
+  try {
+    const packagePath = join(import.meta.dir, '..', '..', '..', 'package.json')
+    return JSON.parse(readFileSync(packagePath, 'utf-8')).version ?? 'unknown'
+  } catch {
+    return 'unknown'
+  }
+}
@@ -9,6 +9,7 @@ import { createCuratorService } from '../../services/curator/CuratorService'
 import { resolve, join } from 'path'
 import { existsSync, mkdirSync } from 'fs'
 import * as readline from 'readline'
+import { getPackageVersion } from '../../shared/utils/version'
 
 interface CLIArgs {
   path: string
@@ -27,6 +28,7 @@ interface CLIArgs {
   change?: string
   newSession?: boolean
   interactive?: boolean
+  json?: boolean
 }
 
 function parseArgs(): CLIArgs {
@@ -40,6 +42,7 @@ function parseArgs(): CLIArgs {
     feature: '',
     change: '',
     newSession: false,
+    json: false,
   }
 
   // First, extract all flags and options
@@ -84,6 +87,9 @@ function parseArgs(): CLIArgs {
         case '--new-session':
           result.newSession = true
           break
+        case '--json':
+          result.json = true
+          break
       }
     } else {
       // Collect positional arguments
@@ -178,6 +184,7 @@ Commands:
 Options:
   -o, --output <format>  Output format: summary (default), detailed, or json
   --new-session         Start fresh without previous context
+  --json                Print a single JSON object with the result to stdout
   -i, --interactive     Interactive mode for multi-turn conversations
   -h, --help            Show this help message
 
@@ -304,6 +311,11 @@ async function startInteractiveChat(
 }
 
 async function main() {
+  if (process.argv[2] === '--version') {
+    console.log(getPackageVersion())
+    process.exit(0)
+  }
+
   const args = parseArgs()
 
   if (args.help || (!args.command && !args.path)) {
@@ -320,6 +332,14 @@ async function main() {
     process.exit(1)
   }
 
+  if (args.json && args.command === 'chat') {
+    console.error('❌ Error: chat is interactive and has no --json output')
+    process.exit(1)
+  }
+
+  // Progress messages would corrupt the JSON on stdout
+  const progress = args.json ? (..._: unknown[]) => {} : console.log
+
   // Create curator service
   const curator = createCuratorService({})
 
@@ -331,9 +351,9 @@ async function main() {
     // Handle different commands
     switch (args.command) {
       case 'overview':
-        console.log(`\n🔍 Analyzing ${resolvedPath}...\n`)
+        progress(`\n🔍 Analyzing ${resolvedPath}...\n`)
         output = await curator.getOverview(resolvedPath, args.newSession)
-        console.log(output)
+        progress(output)
         break
 
       case 'ask':
@@ -342,13 +362,14 @@ async function main() {
           console.log('\nExample: curator ask "How does authentication work?"')
           process.exit(1)
         }
-        console.log(`\n🔍 Analyzing ${resolvedPath}...\n`)
+        progress(`\n🔍 Analyzing ${resolvedPath}...\n`)
         const response = await curator.askCurator({
           question: args.question,
           projectPath: resolvedPath,
           newSession: args.newSession,
         })
-        console.log(response.content)
+        output = response.content
+        progress(output)
         break
 
       case 'feature':
@@ -357,12 +378,12 @@ async function main() {
           console.log('\nExample: curator feature "Add user notifications"')
           process.exit(1)
         }
-        console.log(`\n🔍 Planning feature for ${resolvedPath}...\n`)
+        progress(`\n🔍 Planning feature for ${resolvedPath}...\n`)
         output = await curator.addNewFeature({
           feature: args.feature,
           projectPath: resolvedPath,
         })
-        console.log(output)
+        progress(output)
         break
 
       case 'change':
@@ -373,12 +394,12 @@ async function main() {
           )
           process.exit(1)
         }
-        console.log(`\n🔍 Planning change for ${resolvedPath}...\n`)
+        progress(`\n🔍 Planning change for ${resolvedPath}...\n`)
         output = await curator.implementChange({
           change: args.change,
           projectPath: resolvedPath,
         })
-        console.log(output)
+        progress(output)
         break
 
       case 'chat':
@@ -386,15 +407,15 @@ async function main() {
         return // Don't cleanup, let chat handle it
 
       case 'memory':
-        console.log(`\n🧠 Curator Memory for ${resolvedPath}\n`)
-        const memory = await curator.getCuratorMemory(resolvedPath)
-        console.log(memory)
+        progress(`\n🧠 Curator Memory for ${resolvedPath}\n`)
+        output = await curator.getCuratorMemory(resolvedPath)
+        progress(output)
         break
 
       case 'clear':
-        console.log(`\n🧹 Clearing curator memory for ${resolvedPath}...`)
+        progress(`\n🧹 Clearing curator memory for ${resolvedPath}...`)
         await curator.clearSession(resolvedPath)
-        console.log('✅ Memory cleared! Next interaction will start fresh.')
+        progress('✅ Memory cleared! Next interaction will start fresh.')
         break
 
       default:
@@ -403,6 +424,21 @@ async function main() {
         process.exit(1)
     }
 
+    if (args.json) {
+      console.log(
+        JSON.stringify(
+          {
+            command: args.command,
+            path: resolvedPath,
+            output,
+            timestamp: new Date().toISOString(),
+          },
+          null,
+          2
+        )
+      )
+    }
+
     // Save output if requested
     if (output && args.output === 'json') {
       const saved = await saveToCurator(
@@ -11,6 +11,7 @@ import {
   type HashTreeDiff
 } from '@codebase-curator/semantic-core'
 import * as path from 'path'
+import { getPackageVersion } from '../../shared/utils/version'
 
 interface MonitorStats {
   totalChanges: number
@@ -35,8 +36,11 @@ class IndexingMonitor {
   private isUpdatingOverview = false
   private recentChanges: string[] = []
   private changeLogTimer?: Timer
+  private jsonOutput = false
+  private projectPath: string
 
   constructor(projectPath: string) {
+    this.projectPath = path.resolve(projectPath)
     this.indexer = new IncrementalIndexer(projectPath)
     this.stats = {
       totalChanges: 0,
@@ -52,7 +56,10 @@ class IndexingMonitor {
     }
   }
 
-  async start(withOverview: boolean = false): Promise<void> {
+  async start(
+    withOverview: boolean = false,
+    jsonOutput: boolean = false
+  ): Promise<void> {
     console.log('🔍 Initializing incremental indexer...')
 
     // Initialize the indexer
@@ -85,9 +92,16 @@ class IndexingMonitor {
     })
 
     this.isRunning = true
-    this.showLiveOverview = withOverview
+    this.showLiveOverview = withOverview && !jsonOutput
+    this.jsonOutput = jsonOutput
 
-    if (withOverview) {
+    if (jsonOutput) {
+      // One JSON object per line for the Go dashboard
+      this.updateInterval = setInterval(() => {
+        this.emitStatusEvent()
+      }, 2000)
+      await this.emitStatusEvent()
+    } else if (withOverview) {
       console.log('👀 Monitoring file changes with live overview...\n')
       await this.updateOverviewData()
 
@@ -124,6 +138,11 @@ class IndexingMonitor {
     diff.modified.forEach((file) => this.stats.uniqueFilesModified.add(file))
     diff.deleted.forEach((file) => this.stats.uniqueFilesDeleted.add(file))
 
+    if (this.jsonOutput) {
+      this.emitChangeEvents(diff)
+      return
+    }
+
     // Create change logs for each file that changed
     const timestamp = new Date().toLocaleTimeString()
 
@@ -170,6 +189,62 @@ class IndexingMonitor {
     }
   }
 
+  private emitChangeEvents(diff: HashTreeDiff): void {
+    const timestamp = new Date().toISOString()
+    const changes: Array<[string[], string]> = [
+      [diff.added, 'added'],
+      [diff.modified, 'modified'],
+      [diff.deleted, 'deleted'],
+    ]
+    for (const [files, kind] of changes) {
+      for (const filePath of files) {
+        if (
+          filePath.includes('/.curator/') ||
+          filePath.includes('\\.curator\\')
+        ) {
+          continue
+        }
+        console.log(
+          JSON.stringify({
+            type: 'change',
+            path: this.relativePath(filePath),
+            kind,
+            timestamp,
+          })
+        )
+      }
+    }
+  }
+
+  async emitStatusEvent(detailed: boolean = false): Promise<void> {
+    const status = await this.indexer.getStatus()
+    const event: Record<string, unknown> = {
+      type: 'status',
+      totalFiles: status.totalFiles,
+      indexedFiles: status.indexedFiles,
+      timestamp: new Date().toISOString(),
+    }
+
+    if (detailed) {
+      // Standalone status: report on the index files and their integrity
+      const indexFile = Bun.file(
+        path.join(this.projectPath, '.curator', 'semantic-index.json')
+      )
+      event.indexExists = await indexFile.exists()
+      if (event.indexExists) {
+        event.indexSize = indexFile.size
+        event.lastIndexed = new Date(indexFile.lastModified).toISOString()
+      }
+      const integrity = await this.indexer.checkIntegrity()
+      event.consistent = integrity.consistent
+      event.issues = integrity.issues
+    } else {
+      event.watching = status.isWatching
+    }
+
+    console.log(JSON.stringify(event))
+  }
+
   private async displayStatus(): Promise<void> {
     if (!this.isRunning) return
 
@@ -674,6 +749,11 @@ async function main() {
   const args = process.argv.slice(2)
   const command = args[0]
 
+  if (command === '--version') {
+    console.log(getPackageVersion())
+    process.exit(0)
+  }
+
   // Filter out flags to get the actual project path
   const pathArgs = args.filter((arg) => !arg.startsWith('-'))
   const projectPath = pathArgs[1] || process.cwd()
@@ -696,14 +776,19 @@ async function main() {
 
       // Check if user wants live overview mode
       const withOverview = args.includes('--overview') || args.includes('-o')
-      await monitor.start(withOverview)
+      await monitor.start(withOverview, args.includes('--json'))
 
       // Keep running until interrupted
       await new Promise(() => {})
       break
 
     case 'status':
-      await monitor.showDetailedStatus()
+      if (args.includes('--json')) {
+        await monitor.indexer.initialize()
+        await monitor.emitStatusEvent(true)
+      } else {
+        await monitor.showDetailedStatus()
+      }
       break
 
     case 'overview':
@@ -24,11 +24,17 @@ import type {
 import { displayResultsForClaude } from './displays/claude-display.js'
 import { CompactSummaryGenerator } from './displays/compactSummary.js'
 // import { StoryDisplay } from './commands/story/storyCommand.js' // REMOVED
-import { execSync } from 'child_process'
+import { execFileSync, execSync } from 'child_process'
+import { getPackageVersion } from '../../shared/utils/version'
 
 async function main() {
   const args = process.argv.slice(2)
 
+  if (args[0] === '--version') {
+    console.log(getPackageVersion())
+    process.exit(0)
+  }
+
   if (args.length === 0 || args[0] === '--help' || args[0] === '-h') {
     showHelp()
     process.exit(0)
@@ -71,7 +77,12 @@ async function main() {
       console.error('Please provide a term to find references for')
       process.exit(1)
     }
-    await handleReferences(service, projectPath, args[1])
+    await handleReferences(
+      service,
+      projectPath,
+      args[1],
+      args.includes('--json')
+    )
     return
   }
 
@@ -113,7 +124,7 @@ async function handleGroupCommand(
 
   switch (action) {
     case 'list':
-      await handleGroupList(projectPath)
+      await handleGroupList(projectPath, args.includes('--json'))
       break
 
     case 'add':
@@ -140,7 +151,12 @@ async function handleGroupCommand(
   }
 }
 
-async function handleGroupList(projectPath: string) {
+async function handleGroupList(projectPath: string, json = false) {
+  if (json) {
+    console.log(JSON.stringify(getAvailableGroups(projectPath), null, 2))
+    return
+  }
+
   const config = loadConfig(projectPath)
   const customGroups = parseCustomGroups(config.customGroups || {})
   console.log(getFormattedGroupList(customGroups))
@@ -539,7 +555,8 @@ async function handleGroupSearch(
 async function handleReferences(
   service: SemanticService,
   projectPath: string,
-  term: string
+  term: string,
+  json = false
 ) {
   // Load index
   const loaded = await service.loadIndex(projectPath)
@@ -549,6 +566,12 @@ async function handleReferences(
   }
 
   // Get impact analysis
+  if (json) {
+    const analysis = await service.getImpactAnalysis(term)
+    console.log(JSON.stringify(analysis.directReferences, null, 2))
+    return
+  }
+
   process.stdout.write(`🔍 Analyzing references to "${term}"...`)
   const analysis = await service.getImpactAnalysis(term)
   process.stdout.write(' ✓\n')
@@ -1083,7 +1106,9 @@ async function handleChangesImpact(
   projectPath: string,
   args: string[]
 ) {
-  const isCompact = args.includes('--compact') || args.includes('-c')
+  const isJSON = args.includes('--json')
+  // JSON output skips the same progress lines as compact mode
+  const isCompact = isJSON || args.includes('--compact') || args.includes('-c')
 
   // Load index first
   if (!isCompact) {
@@ -1100,24 +1125,51 @@ async function handleChangesImpact(
     process.stdout.write(' ✓\n')
   }
 
+  // --staged limits the analysis to the index; --since <ref> compares the
+  // working tree with a commit or branch instead of HEAD
+  const stagedOnly = args.includes('--staged')
+  const sinceIndex = args.indexOf('--since')
+  const since = sinceIndex >= 0 ? args[sinceIndex + 1] : undefined
+  if (sinceIndex >= 0 && (!since || since.startsWith('-'))) {
+    console.error('--since requires a git ref, e.g. --since main')
+    process.exit(1)
+  }
+  if (stagedOnly && since) {
+    console.error('--staged and --since cannot be used together')
+    process.exit(1)
+  }
+
   // Get git changes
   try {
-    // Get changed files with status
-    const stagedRaw = execSync('git diff --cached --name-status', {
-      cwd: projectPath,
-      encoding: 'utf-8',
-    })
-      .trim()
-      .split('\n')
-      .filter((f) => f.length > 0)
+    if (since) {
+      try {
+        // Arguments go straight to git, never through a shell
+        execFileSync(
+          'git',
+          ['rev-parse', '--verify', '--quiet', `${since}^{commit}`],
+          { cwd: projectPath, stdio: 'ignore' }
+        )
+      } catch {
+        console.error(`Unknown git ref: ${since}`)
+        process.exit(1)
+      }
+    }
 
-    const unstagedRaw = execSync('git diff --name-status', {
-      cwd: projectPath,
-      encoding: 'utf-8',
-    })
-      .trim()
-      .split('\n')
-      .filter((f) => f.length > 0)
+    const gitNameStatus = (...range: string[]) =>
+      execFileSync('git', ['diff', '--name-status', ...range], {
+        cwd: projectPath,
+        encoding: 'utf-8',
+      })
+        .trim()
+        .split('\n')
+        .filter((f) => f.length > 0)
+
+    // Changes since a ref are reported as staged: they are all "in" the
+    // comparison, with nothing left outside it
+    const stagedRaw = since
+      ? gitNameStatus(since, '--')
+      : gitNameStatus('--cached')
+    const unstagedRaw = since || stagedOnly ? [] : gitNameStatus()
 
     // Parse file changes with their status
     const parseChanges = (lines: string[]) =>
@@ -1148,8 +1200,23 @@ async function handleChangesImpact(
     const allChanged = Array.from(allChangesMap.values())
 
     if (allChanged.length === 0) {
-      if (!isCompact) {
-        console.log('\n✨ No changes in working directory')
+      if (isJSON) {
+        console.log(
+          JSON.stringify({
+            files: [],
+            totalImpact: 0,
+            affectedSymbols: 0,
+            highImpactSymbols: [],
+          })
+        )
+      } else if (!isCompact) {
+        console.log(
+          since
+            ? `\n✨ No changes since ${since}`
+            : stagedOnly
+            ? '\n✨ No staged changes'
+            : '\n✨ No changes in working directory'
+        )
       }
       return
     }
@@ -1164,12 +1231,15 @@ async function handleChangesImpact(
       console.log(`\n📊 Changes Impact Analysis`)
       console.log(`📍 Branch: ${branch}`)
       console.log(
-        `📝 Status: ${staged.length} staged, ${unstaged.length} unstaged\n`
+        since
+          ? `📝 Status: ${staged.length} files changed since ${since}\n`
+          : `📝 Status: ${staged.length} staged, ${unstaged.length} unstaged\n`
       )
     }
 
     // Analyze each changed file
     let totalImpact = 0
+    let affectedSymbols = 0
     const highImpactSymbols: {
       symbol: string
       file: string
@@ -1301,6 +1371,7 @@ async function handleChangesImpact(
         console.log('')
       } else if (impactful.length > 0 && isCompact) {
         // Still count for compact mode
+        affectedSymbols += impactful.length
         impactful.forEach((s) => {
           totalImpact += s.usageCount || 0
           if ((s.usageCount || 0) > 5) {
@@ -1314,6 +1385,23 @@ async function handleChangesImpact(
       }
     }
 
+    if (isJSON) {
+      console.log(
+        JSON.stringify(
+          {
+            branch,
+            files: allChanged,
+            totalImpact,
+            affectedSymbols,
+            highImpactSymbols,
+          },
+          null,
+          2
+        )
+      )
+      return
+    }
+
     // Compact mode - actionable one-liner risk assessment
     if (isCompact) {
       if (totalImpact === 0) {
@@ -1611,6 +1699,9 @@ Usage:
   smartgrep refs "processPayment"               # Full impact analysis
   smartgrep changes                             # Analyze uncommitted changes impact
   smartgrep changes --compact                   # One-line risk assessment
+  smartgrep changes --json                      # Changed files and impact as JSON
+  smartgrep changes --staged                    # Only what is staged for commit
+  smartgrep changes --since main                # Everything changed since a ref
   smartgrep group service --type class --max 10 # Top 10 service classes
   smartgrep group add api endpoint,route,handler,controller  # Add custom group
   